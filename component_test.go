@@ -0,0 +1,142 @@
+package lander_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/experimental/hooks"
+	"github.com/minivera/go-lander/nodes"
+)
+
+type hookCounterProps struct {
+	name    string
+	renders map[string]int
+}
+
+func hookCounter(ctx context.Context, props hookCounterProps, _ nodes.Children) nodes.Child {
+	props.renders[props.name]++
+	count, setCount, _ := hooks.UseState[int](ctx, 0)
+
+	return lander.Html("button", nodes.Attributes{
+		"id": props.name,
+		"click": func(*events.DOMEvent) error {
+			return setCount(func(value int) int {
+				return value + 1
+			})
+		},
+	}, nodes.Children{
+		lander.Text(fmt.Sprintf("%s: %d", props.name, count)),
+	})
+}
+
+func TestDomEnvironment_UpdateComponentWithHooks(t *testing.T) {
+	document, app := setupDocument(t)
+
+	renders := map[string]int{}
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		renders["root"]++
+		return lander.Html("div", nodes.Attributes{}, nodes.Children{
+			lander.Component(hookCounter, hookCounterProps{name: "first", renders: renders}, nodes.Children{}),
+			lander.Component(hookCounter, hookCounterProps{name: "second", renders: renders}, nodes.Children{}),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(hooks.Provider, nodes.Props{}, nodes.Children{
+		lander.Component(root, nodes.Props{}, nodes.Children{}),
+	}), "#app")
+	require.NoError(t, err)
+
+	second := document.QuerySelector("#second").(*dom.MemoryElement)
+	second.Dispatch("click")
+	second.Dispatch("click")
+
+	assert.Equal(t, `<div><button id="first">first: 0</button><button id="second">second: 2</button></div>`, app.InnerHTML())
+	// Only the component owning the state was rendered again
+	assert.Equal(t, map[string]int{"root": 1, "first": 1, "second": 3}, renders)
+	assert.True(t, document.QuerySelector("#second").IsSameNode(second))
+
+	document.QuerySelector("#first").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, `<div><button id="first">first: 1</button><button id="second">second: 2</button></div>`, app.InnerHTML())
+
+	// A full render keeps the state of each component
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<div><button id="first">first: 1</button><button id="second">second: 2</button></div>`, app.InnerHTML())
+	assert.Equal(t, map[string]int{"root": 2, "first": 3, "second": 4}, renders)
+}
+
+type labelProps struct {
+	label string
+}
+
+func TestDomEnvironment_UpdateComponent(t *testing.T) {
+	document, app := setupDocument(t)
+
+	label := "initial"
+	suffix := ""
+	var child interface{}
+	labelComponent := func(ctx context.Context, props labelProps, _ nodes.Children) nodes.Child {
+		child = ctx.Component()
+		return lander.Text(props.label + suffix)
+	}
+
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Html("p", nodes.Attributes{}, nodes.Children{
+			lander.Text("Label: "),
+			lander.Component(labelComponent, labelProps{label: label}, nodes.Children{}),
+			lander.Text("."),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	label = "updated"
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<p>Label: updated.</p>`, app.InnerHTML())
+
+	// The component is rendered with the properties of the last full render
+	require.NoError(t, env.UpdateComponent(child, func() {
+		label = "ignored"
+		suffix = "!"
+	}))
+	assert.Equal(t, `<p>Label: updated!.</p>`, app.InnerHTML())
+	assert.Equal(t, 3, env.RenderCount())
+}
+
+func TestDomEnvironment_UpdateComponentWithoutDOMNodes(t *testing.T) {
+	document, app := setupDocument(t)
+
+	visible := false
+	var child interface{}
+	toggle := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		child = ctx.Component()
+		if !visible {
+			return nil
+		}
+
+		return lander.Text("visible")
+	}
+
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Html("div", nodes.Attributes{}, nodes.Children{
+			lander.Component(toggle, nodes.Props{}, nodes.Children{}),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, `<div></div>`, app.InnerHTML())
+
+	// The component has no DOM node to find its position, the whole tree is rendered instead
+	require.NoError(t, env.UpdateComponent(child, func() {
+		visible = true
+	}))
+	assert.Equal(t, `<div>visible</div>`, app.InnerHTML())
+}
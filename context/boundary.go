@@ -0,0 +1,53 @@
+package context
+
+import (
+	"fmt"
+)
+
+// PanicError is the error given to error handlers when a component or a lifecycle listener panics. The
+// value given to panic is kept in Value.
+type PanicError struct {
+	Value interface{}
+}
+
+// NewPanicError creates a new PanicError for the value recovered from a panic.
+func NewPanicError(value interface{}) *PanicError {
+	return &PanicError{
+		Value: value,
+	}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the value given to panic if it was an error, so errors.Is and errors.As can be used on
+// a PanicError.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// ErrorHandler handles the errors of the components in a subtree, see lander.ErrorBoundary.
+type ErrorHandler func(err error)
+
+var errorHandlers = CreateContext[ErrorHandler](nil)
+
+// ProvideErrorHandler provides the error handler to all the descendants of the component currently rendering.
+// The errors of a component, panics while rendering, errors while patching and errors from its lifecycle
+// listeners, are sent to the nearest handler.
+func ProvideErrorHandler(ctx Context, handler ErrorHandler) {
+	errorHandlers.Provide(ctx, handler)
+}
+
+// HandleError sends the error to the nearest error handler provided in the given scope. Returns false if no
+// ancestor provided an error handler, the error should then be returned or panic as usual.
+func HandleError(scope *Scope, err error) bool {
+	handler, found := scope.lookup(errorHandlers)
+	if !found || handler == nil {
+		return false
+	}
+
+	handler.(ErrorHandler)(err)
+	return true
+}
@@ -1,6 +1,7 @@
 package context
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/minivera/go-lander/internal"
@@ -16,12 +17,15 @@ var CurrentContext Context
 //
 // IMPORTANT: Contrary to how React handles context, setting values inside the context uses pointers and
 // will replace the value for all other components, regardless of where they are in the tree. React instead
-// uses a context hierarchy based on the component tree.
+// uses a context hierarchy based on the component tree. Use CreateContext for values scoped to a subtree.
 type Context interface {
 	// OnMount adds an event listener for when a component is first mounted into the tree.
 	// This only triggers if the new component is inserted into the tree for the first time
 	// due to a complete change in the layout. Component are reused, so this may not fire when
 	// adding new components into a list. Use OnRender to consistently get render updates.
+	//
+	// A component can add many listeners for the same event, they are called in the order they were added.
+	// Mount, render and layout listeners of children are called before the ones of their parents.
 	OnMount(func() error)
 
 	// OnRender triggers every time a component is updated and its content is render into the tree
@@ -30,9 +34,14 @@ type Context interface {
 	OnRender(func() error)
 
 	// OnUnmount triggers an event listener when a component is removed from the tree. This will only
-	// fire once and after the component has been removed from the tree.
+	// fire once and after the component has been removed from the tree. Unmount listeners of parents are
+	// called before the ones of their children.
 	OnUnmount(func() error)
 
+	// OnLayout triggers every time a component is rendered, like OnRender. Layout listeners are called
+	// before all the mount and render listeners of the render.
+	OnLayout(func() error)
+
 	// HasValue returns if the internal context has the given value saved in memory. This does not check
 	// if the value is nil or undefined, only if the context was set to something.
 	HasValue(name string) bool
@@ -52,14 +61,60 @@ type Context interface {
 	// may be ignored otherwise.
 	IsDirty() bool
 
-	// Update triggers an update in the virtual DOM tree. Updates are thread safe and only one update can happen
-	// at a time. Only once the update has completed and all hook listeners have been notified will this resolve.
+	// Update requests an update of the virtual DOM tree. Updates are thread safe, they are queued in the render
+	// loop and coalesced, only one render can happen at a time.
 	Update() error
+
+	// UpdateWith queues the mutation in the render loop and triggers an update. The mutation is executed right
+	// before the next render, while no other render or event listener can run, so state shared with the
+	// components can safely be changed from any goroutine.
+	UpdateWith(mutation func()) error
+
+	// UpdateComponent queues the mutation in the render loop, like UpdateWith, but only requests a render of the
+	// given component rather than the whole tree. The component's ancestors and siblings are not rendered again,
+	// its descendants are. Use Component during a render to get the component to update.
+	UpdateComponent(component interface{}, mutation func()) error
+
+	// Component returns the component currently rendering, as registered by the diffing process. Keep the
+	// returned value to identify the component later, for example to update it with UpdateComponent.
+	Component() interface{}
+
+	// ReportError sends an error that has no caller to be returned to, such as the error of an update
+	// requested from a browser callback, to the error handler of the environment.
+	ReportError(err error)
+}
+
+// Updater is the render loop the context sends its updates to, usually the DOM environment.
+type Updater interface {
+	Update() error
+	UpdateWith(mutation func()) error
+	UpdateComponent(component interface{}, mutation func()) error
+	ReportError(err error)
+}
+
+// ListenerError is returned by WithNewContext when lifecycle listeners failed. The render itself completed,
+// every listener was called and the errors of the ones that failed are aggregated in Errors.
+type ListenerError struct {
+	Errors []error
+}
+
+func (e *ListenerError) Error() string {
+	return errors.Join(e.Errors...).Error()
+}
+
+// Unwrap returns the errors of the failed listeners, so errors.Is and errors.As can be used on a
+// ListenerError.
+func (e *ListenerError) Unwrap() []error {
+	return e.Errors
 }
 
+// componentKey is the key of the components in the scope, it tracks the parent of the components while
+// rendering.
+type componentKey struct{}
+
 // baseContext is the implemented version of the context interface for internal use only.
 type baseContext struct {
-	updateFunc func() error
+	updater Updater
 
 	previousContext *baseContext
 
@@ -67,26 +122,37 @@ type baseContext struct {
 	isDirty       bool
 
 	contextPerComponent map[interface{}][]string
+	componentOrder      []interface{}
+	componentParents    map[interface{}]interface{}
 	currentComponent    interface{}
-	componentEvents     map[interface{}]map[string]func() error
+	componentEvents     map[interface{}]map[string][]func() error
+
+	scope           *Scope
+	componentScopes map[interface{}]*Scope
 }
 
 // WithNewContext wraps the given function with a CurrentContext. The function will keep a reference of the
-// previous version of CurrentContext and will restore it once it resolves. The function expects a update
-// function to set as the value of Update in the Context interface and a previous context.
+// previous version of CurrentContext and will restore it once it resolves. The function expects an updater
+// to forward the Update and UpdateWith calls of the Context interface to and a previous context.
 //
 // previousContext is not the previous version of the context, this is handled internally. Rather this is
 // the context from a previous render cycle. This must be set for unmounts to work properly and for
 // context values to carry over subsequent renders.
-func WithNewContext(updateFunc func() error, previousContext Context, call func() error) error {
+//
+// The lifecycle listeners are called synchronously once call returns, the errors of the listeners are
+// returned as a *ListenerError.
+func WithNewContext(updater Updater, previousContext Context, call func() error) error {
 	prevContext := CurrentContext
 	localContext := &baseContext{
-		updateFunc:    updateFunc,
+		updater:       updater,
 		contextValues: map[string]interface{}{},
 
 		contextPerComponent: map[interface{}][]string{},
+		componentParents:    map[interface{}]interface{}{},
 		currentComponent:    nil,
-		componentEvents:     map[interface{}]map[string]func() error{},
+		componentEvents:     map[interface{}]map[string][]func() error{},
+
+		componentScopes: map[interface{}]*Scope{},
 	}
 
 	// Restore the old context if it was provided
@@ -96,32 +162,66 @@ func WithNewContext(updateFunc func() error, previousContext Context, call func(
 		for key, value := range localContext.previousContext.contextValues {
 			localContext.contextValues[key] = value
 		}
+
+		// Components that are not rendered again keep their listeners, components that render register them
+		// again. The listeners of components unmounted by the previous render are dropped.
+		for component, events := range localContext.previousContext.componentEvents {
+			if hasContextType(localContext.previousContext.contextPerComponent[component], "unmount") {
+				continue
+			}
+
+			copied := make(map[string][]func() error, len(events))
+			for name, listener := range events {
+				copied[name] = listener
+			}
+			localContext.componentEvents[component] = copied
+		}
+
+		for component, scope := range localContext.previousContext.componentScopes {
+			if hasContextType(localContext.previousContext.contextPerComponent[component], "unmount") {
+				continue
+			}
+
+			localContext.componentScopes[component] = scope
+		}
 	}
 
 	CurrentContext = localContext
+	defer func() {
+		CurrentContext = prevContext
+	}()
 
 	err := call()
 	if err != nil {
 		return err
 	}
 
-	// trigger this async, so we have finished restoring the tree before this happens
-	go func() {
-		if err := localContext.triggerEvents(); err != nil {
-			panic(err)
-		}
-	}()
-
-	CurrentContext = prevContext
-	return nil
+	// The tree and the DOM are patched, trigger the listeners while the render still holds the tree
+	return localContext.triggerEvents()
 }
 
 // RegisterComponent registers the given interface as the current component being rendered in the
 // CurrentContext. This is needed to properly link hooks like OnMount to the given component without
 // asking consumers to pass the component reference.
+//
+// The current scope is saved as the scope of the component, see ComponentScope. The listeners the component
+// registered in a previous render are dropped, the component registers them again as it renders. The
+// component is then added to the scope, so its descendants know their parent. Call RegisterComponent between
+// CurrentScope and RestoreScope, like any component providing values.
 func RegisterComponent(component interface{}) {
 	converted := CurrentContext.(*baseContext)
 	converted.currentComponent = component
+	converted.componentScopes[component] = converted.scope
+	delete(converted.componentEvents, component)
+
+	if parent, ok := converted.scope.lookup(componentKey{}); ok {
+		converted.componentParents[component] = parent
+	}
+	converted.scope = &Scope{
+		parent: converted.scope,
+		key:    componentKey{},
+		value:  component,
+	}
 }
 
 // RegisterComponentContext registers the given context type for the given component. Only when a context
@@ -131,6 +231,9 @@ func RegisterComponent(component interface{}) {
 func RegisterComponentContext(contextType string, component interface{}) {
 	internal.Debugf("Registering context type %s for component %T, %v\n", contextType, component, component)
 	converted := CurrentContext.(*baseContext)
+	if _, ok := converted.contextPerComponent[component]; !ok {
+		converted.componentOrder = append(converted.componentOrder, component)
+	}
 	converted.contextPerComponent[component] = append(converted.contextPerComponent[component], contextType)
 	converted.currentComponent = component
 }
@@ -141,9 +244,22 @@ func RegisterComponentContext(contextType string, component interface{}) {
 func UnregisterAllComponentContexts(component interface{}) {
 	internal.Debugf("Removing all context types for component %T, %v\n", component, component)
 	converted := CurrentContext.(*baseContext)
+	if _, ok := converted.contextPerComponent[component]; !ok {
+		converted.componentOrder = append(converted.componentOrder, component)
+	}
 	converted.contextPerComponent[component] = []string{}
 }
 
+func hasContextType(contextTypes []string, contextType string) bool {
+	for _, name := range contextTypes {
+		if name == contextType {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *baseContext) OnMount(listener func() error) {
 	c.registerListener("mount", listener)
 }
@@ -156,75 +272,144 @@ func (c *baseContext) OnUnmount(listener func() error) {
 	c.registerListener("unmount", listener)
 }
 
+func (c *baseContext) OnLayout(listener func() error) {
+	c.registerListener("layout", listener)
+}
+
 func (c *baseContext) registerListener(contextType string, listener func() error) {
 	internal.Debugf("Registering event type %s for component %T (%p) %v\n", contextType, c.currentComponent, c.currentComponent, c.currentComponent)
 	if _, ok := c.componentEvents[c.currentComponent]; !ok {
-		c.componentEvents[c.currentComponent] = map[string]func() error{}
+		c.componentEvents[c.currentComponent] = map[string][]func() error{}
 	}
 
-	c.componentEvents[c.currentComponent][contextType] = listener
+	c.componentEvents[c.currentComponent][contextType] = append(c.componentEvents[c.currentComponent][contextType], listener)
 }
 
-func (c *baseContext) triggerEvents() error {
-	internal.Debugf("Trying to trigger events %v\n", c.componentEvents)
-	for component, contextEvents := range c.contextPerComponent {
-		internal.Debugf("Trying to trigger events for component %T, %v\n", component, component)
-		internal.Debugf("Events are %v\n", contextEvents)
+// orderedComponents returns the components registered in the context in tree order. Parents come before
+// their children, unless childrenFirst is true. Siblings are kept in the order they were registered.
+func (c *baseContext) orderedComponents(childrenFirst bool) []interface{} {
+	children := map[interface{}][]interface{}{}
+	var roots []interface{}
+	for _, component := range c.componentOrder {
+		parent, ok := c.componentParents[component]
+		if _, registered := c.contextPerComponent[parent]; !ok || !registered {
+			roots = append(roots, component)
+			continue
+		}
 
-		// Ignore any context listeners for contexts that are not set on this particular component
-		for _, name := range contextEvents {
-			if name == "unmount" {
-				internal.Debugf("Searching for unmount listener of component (%p) %T in previous context\n", component, component)
-				// If the context is to unmount, then find the listener in the previous context instead
-				if c.previousContext == nil {
-					continue
-				}
+		children[parent] = append(children[parent], component)
+	}
 
-				for component, events := range c.previousContext.componentEvents {
-					internal.Debugf("Previous context has component %T (%p) and events %v\n", component, component, events)
-				}
+	ordered := make([]interface{}, 0, len(c.componentOrder))
+	var visit func(component interface{})
+	visit = func(component interface{}) {
+		if !childrenFirst {
+			ordered = append(ordered, component)
+		}
+		for _, child := range children[component] {
+			visit(child)
+		}
+		if childrenFirst {
+			ordered = append(ordered, component)
+		}
+	}
 
-				listener, ok := c.previousContext.componentEvents[component]["unmount"]
-				if !ok {
-					internal.Debugf("Listener for unmount was not found in previous context with component %T\n", component)
-					// skip if the unmounted component doesn't trigger unmount
-					continue
-				}
+	for _, root := range roots {
+		visit(root)
+	}
 
-				internal.Debugf("Executing unmount with component %T\n", component)
-				err := listener()
-				if err != nil {
-					return fmt.Errorf("error in unmount listener for component. %w", err)
-				}
+	return ordered
+}
 
+// triggerEvents calls the listeners of all the components registered in the context. Unmount listeners are
+// called first, parents before their children, then the layout listeners and finally the mount and render
+// listeners, children before their parents. All listeners are called, even if some fail.
+func (c *baseContext) triggerEvents() error {
+	internal.Debugf("Trying to trigger events %v\n", c.componentEvents)
+	var errs []error
+
+	// If the context is to unmount, then find the listeners in the previous context instead
+	if c.previousContext != nil {
+		for _, component := range c.orderedComponents(false) {
+			if !hasContextType(c.contextPerComponent[component], "unmount") {
 				continue
 			}
 
-			// Don't continue with this component if it was never registered
-			events, ok := c.componentEvents[component]
-			if !ok {
-				internal.Debugf("Component %T was never registered\n", component)
-				continue
+			internal.Debugf("Executing unmount with component %T\n", component)
+			scope := c.previousContext.componentScopes[component]
+			for _, listener := range c.previousContext.componentEvents[component]["unmount"] {
+				if err := runListener(scope, "unmount", listener); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	components := c.orderedComponents(true)
+	for _, component := range components {
+		// Layout listeners follow the render listeners, components that did not render are ignored
+		if !hasContextType(c.contextPerComponent[component], "render") {
+			continue
+		}
+
+		internal.Debugf("Executing layout with component %T\n", component)
+		for _, listener := range c.componentEvents[component]["layout"] {
+			if err := runListener(c.componentScopes[component], "layout", listener); err != nil {
+				errs = append(errs, err)
 			}
+		}
+	}
 
-			internal.Debugf("Testing for %s with component %T\n", name, component)
-			listener, found := events[name]
-			if !found {
-				internal.Debugf("%s with component %T was never registered\n", name, component)
+	for _, component := range components {
+		internal.Debugf("Trying to trigger events for component %T, %v\n", component, component)
+		// Ignore any context listeners for contexts that are not set on this particular component
+		for _, name := range c.contextPerComponent[component] {
+			if name == "unmount" {
 				continue
 			}
 
 			internal.Debugf("Executing %s with component %T\n", name, component)
-			err := listener()
-			if err != nil {
-				return fmt.Errorf("error in %s listener for component. %w", name, err)
+			for _, listener := range c.componentEvents[component][name] {
+				if err := runListener(c.componentScopes[component], name, listener); err != nil {
+					errs = append(errs, err)
+				}
 			}
 		}
 	}
 
+	if len(errs) > 0 {
+		return &ListenerError{
+			Errors: errs,
+		}
+	}
+
 	return nil
 }
 
+// runListener executes the listener of a component. Errors and panics are sent to the nearest error handler
+// of the component's scope, errors are only returned when no ancestor handles them.
+func runListener(scope *Scope, name string, listener func() error) error {
+	err := func() (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = NewPanicError(recovered)
+			}
+		}()
+
+		return listener()
+	}()
+	if err == nil {
+		return nil
+	}
+
+	err = fmt.Errorf("error in %s listener for component. %w", name, err)
+	if HandleError(scope, err) {
+		return nil
+	}
+
+	return err
+}
+
 func (c *baseContext) HasValue(name string) bool {
 	_, ok := c.contextValues[name]
 	return ok
@@ -240,7 +425,23 @@ func (c *baseContext) SetValue(name string, value interface{}) {
 }
 
 func (c *baseContext) Update() error {
-	return c.updateFunc()
+	return c.updater.Update()
+}
+
+func (c *baseContext) UpdateWith(mutation func()) error {
+	return c.updater.UpdateWith(mutation)
+}
+
+func (c *baseContext) UpdateComponent(component interface{}, mutation func()) error {
+	return c.updater.UpdateComponent(component, mutation)
+}
+
+func (c *baseContext) ReportError(err error) {
+	c.updater.ReportError(err)
+}
+
+func (c *baseContext) Component() interface{} {
+	return c.currentComponent
 }
 
 func (c *baseContext) IsDirty() bool {
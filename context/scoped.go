@@ -0,0 +1,98 @@
+package context
+
+// Scope is a set of values provided by the ancestors of a component, created with the Provide method of
+// a ScopedContext. Scopes are immutable, providing a value creates a new scope that points to its parent.
+type Scope struct {
+	parent *Scope
+	key    interface{}
+	value  interface{}
+}
+
+func (s *Scope) lookup(key interface{}) (interface{}, bool) {
+	for current := s; current != nil; current = current.parent {
+		if current.key == key {
+			return current.value, true
+		}
+	}
+
+	return nil, false
+}
+
+// ScopedContext is a typed context value resolved based on the position of a component in the tree. Contrary
+// to SetValue, a value provided by a component is only visible to its descendants, and the nearest provider
+// wins. Many providers of the same ScopedContext can coexist in the same tree.
+type ScopedContext[T any] struct {
+	defaultValue T
+}
+
+// CreateContext creates a new scoped context, which returns the default value when no ancestor provided
+// a value. Keep the created context in a package variable and share it with the provider and consumers.
+func CreateContext[T any](defaultValue T) *ScopedContext[T] {
+	return &ScopedContext[T]{
+		defaultValue: defaultValue,
+	}
+}
+
+// Provide provides the value to all the descendants of the component currently rendering. Provide must be
+// called while rendering a component, usually in a provider component. See lander.Provider.
+func (c *ScopedContext[T]) Provide(ctx Context, value T) {
+	converted, ok := ctx.(*baseContext)
+	if !ok {
+		return
+	}
+
+	converted.scope = &Scope{
+		parent: converted.scope,
+		key:    c,
+		value:  value,
+	}
+}
+
+// Use returns the value provided by the nearest ancestor of the component currently rendering, or the
+// default value if no ancestor provided a value. Use must be called while rendering a component.
+func (c *ScopedContext[T]) Use(ctx Context) T {
+	converted, ok := ctx.(*baseContext)
+	if !ok {
+		return c.defaultValue
+	}
+
+	value, found := converted.scope.lookup(c)
+	if !found {
+		return c.defaultValue
+	}
+
+	return value.(T)
+}
+
+// CurrentScope returns the scope of the component currently rendering in the CurrentContext. The diffing
+// process uses the scope to restore the values provided by the ancestors of the components it renders.
+func CurrentScope() *Scope {
+	converted, ok := CurrentContext.(*baseContext)
+	if !ok {
+		return nil
+	}
+
+	return converted.scope
+}
+
+// RestoreScope sets the scope of the CurrentContext. Call RestoreScope with the result of CurrentScope once
+// a component and its descendants are rendered, so the values it provided do not leak to its siblings.
+func RestoreScope(scope *Scope) {
+	converted, ok := CurrentContext.(*baseContext)
+	if !ok {
+		return
+	}
+
+	converted.scope = scope
+}
+
+// ComponentScope returns the scope the given component was last rendered in, I.E. the values provided by its
+// ancestors. Restore this scope before rendering a component on its own, without its ancestors.
+func ComponentScope(component interface{}) *Scope {
+	converted, ok := CurrentContext.(*baseContext)
+	if !ok {
+		return nil
+	}
+
+	return converted.componentScopes[component]
+}
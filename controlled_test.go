@@ -0,0 +1,134 @@
+package lander_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/nodes"
+)
+
+func TestControlled_TextInput(t *testing.T) {
+	document, app := setupDocument(t)
+
+	value := "ab"
+	var update func(func()) error
+	_, err := lander.RenderIntoDocument(document, lander.Component(
+		func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			update = ctx.UpdateWith
+			return lander.Html("input", nodes.Attributes{
+				"value": value,
+				"input": func(event *events.InputEvent) error {
+					return ctx.UpdateWith(func() {
+						value = strings.ToUpper(event.Target().Value())
+					})
+				},
+			}, nodes.Children{})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+
+	input := app.QuerySelector("input").(*dom.MemoryElement)
+	assert.Equal(t, "ab", input.GetProperty("value"))
+
+	// Typing in the middle of the value keeps the caret in place when the app changes the value
+	input.SetProperty("value", "axb")
+	input.SetProperty("selectionStart", 2)
+	input.SetProperty("selectionEnd", 2)
+	input.Dispatch("input")
+	assert.Equal(t, "AXB", input.GetProperty("value"))
+	assert.Equal(t, 2, input.GetProperty("selectionStart"))
+	assert.Equal(t, 2, input.GetProperty("selectionEnd"))
+
+	// The value is not set again when the DOM already has it, which would move the caret to the end
+	input.SetProperty("selectionStart", 1)
+	input.SetProperty("selectionEnd", 1)
+	require.NoError(t, update(func() {}))
+	assert.Equal(t, 1, input.GetProperty("selectionStart"))
+
+	// The value is not set while composing, the app is rendered again once the composition ends
+	input.Dispatch("compositionstart")
+	input.SetProperty("value", "AXBk")
+	input.DispatchWith("input", map[string]interface{}{"isComposing": true})
+	assert.Equal(t, "AXBK", value)
+	assert.Equal(t, "AXBk", input.GetProperty("value"))
+	assert.True(t, events.IsComposing(input))
+
+	input.Dispatch("compositionend")
+	assert.False(t, events.IsComposing(input))
+	assert.Equal(t, "AXBK", input.GetProperty("value"))
+}
+
+func TestControlled_Checkbox(t *testing.T) {
+	document, app := setupDocument(t)
+
+	checked := false
+	_, err := lander.RenderIntoDocument(document, lander.Component(
+		func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			return lander.Html("input", nodes.Attributes{
+				"type":    "checkbox",
+				"checked": checked,
+				"change": func(*events.DOMEvent) error {
+					// The change is rejected, the checkbox must be unchecked again
+					return ctx.UpdateWith(func() {})
+				},
+			}, nodes.Children{})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+
+	checkbox := app.QuerySelector("input").(*dom.MemoryElement)
+	assert.Equal(t, false, checkbox.GetProperty("checked"))
+
+	checkbox.SetProperty("checked", true)
+	checkbox.Dispatch("change")
+	assert.Equal(t, false, checkbox.GetProperty("checked"))
+}
+
+func TestControlled_Select(t *testing.T) {
+	document, app := setupDocument(t)
+
+	selected := "b"
+	options := []string{"a", "b"}
+	var update func(func()) error
+	_, err := lander.RenderIntoDocument(document, lander.Component(
+		func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			update = ctx.UpdateWith
+
+			children := nodes.Children{}
+			for _, option := range options {
+				children = append(children, lander.Html("option", nodes.Attributes{"value": option}, nodes.Children{
+					lander.Text(option),
+				}))
+			}
+
+			return lander.Html("select", nodes.Attributes{"value": selected}, children)
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+
+	// The value is set once the options exist
+	selectElement := app.QuerySelector("select")
+	assert.Equal(t, "b", selectElement.GetProperty("value"))
+	assert.Equal(t, 1, selectElement.GetProperty("selectedIndex"))
+
+	// Options added in the same render can be selected
+	require.NoError(t, update(func() {
+		options = append(options, "c")
+		selected = "c"
+	}))
+	assert.Equal(t, "c", selectElement.GetProperty("value"))
+	assert.Equal(t, 2, selectElement.GetProperty("selectedIndex"))
+}
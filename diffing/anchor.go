@@ -0,0 +1,81 @@
+package diffing
+
+import (
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/nodes"
+)
+
+// Anchor returns the DOM node before which the DOM nodes at a position of a DOM parent must be inserted, or
+// nil to append them. Anchors are resolved when the patches are executed rather than when they are generated,
+// so they point to a DOM node still in the parent whatever the patches executed before. A nil anchor appends.
+type Anchor func() dom.Node
+
+// Node resolves the anchor to the DOM node to insert before, nil to append.
+func (a Anchor) Node() dom.Node {
+	if a == nil {
+		return nil
+	}
+
+	return a()
+}
+
+// siblingAnchor returns an anchor to the first DOM node rendered by the given siblings, or to the next anchor
+// if none of them renders a DOM node, like nil children, empty fragments or components rendering nil. The
+// siblings must not be mutated by the patches, give a copy of the children.
+func siblingAnchor(siblings []nodes.Node, next Anchor) Anchor {
+	return func() dom.Node {
+		for _, sibling := range siblings {
+			if domNodes := domNodesOf(sibling); len(domNodes) > 0 {
+				return domNodes[0]
+			}
+		}
+
+		return next.Node()
+	}
+}
+
+// portalAnchor returns an anchor to the DOM node following the DOM nodes of the portal's children in its
+// container, which may contain other nodes. The anchor appends if the children have no DOM node, or if the
+// following node was removed from the container.
+func portalAnchor(portal *nodes.PortalNode) Anchor {
+	var last dom.Node
+	for _, child := range portal.Children {
+		if domNodes := domNodesOf(child); len(domNodes) > 0 {
+			last = domNodes[len(domNodes)-1]
+		}
+	}
+	if last == nil {
+		return nil
+	}
+
+	reference := last.NextSibling()
+	container := portal.Container
+	return func() dom.Node {
+		if reference == nil || reference.ParentNode() == nil || !reference.ParentNode().IsSameNode(container) {
+			return nil
+		}
+
+		return reference
+	}
+}
+
+// placeChild places the node in the given slot of children if the slot is empty, or appends it otherwise.
+// Removed children leave an empty slot, so the children always line up with the children of the next render.
+func placeChild(children []nodes.Node, slot int, node nodes.Node) []nodes.Node {
+	if slot >= 0 && slot < len(children) && children[slot] == nil {
+		children[slot] = node
+		return children
+	}
+
+	return append(children, node)
+}
+
+// removeDOMNodes removes the DOM nodes rendered by the given node from parent, then releases them.
+func removeDOMNodes(delegator *events.Delegator, parent dom.Element, node nodes.Node) {
+	for _, domNode := range domNodesOf(node) {
+		parent.RemoveChild(domNode)
+	}
+
+	releaseNodes(delegator, node)
+}
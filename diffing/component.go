@@ -0,0 +1,150 @@
+package diffing
+
+import (
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/internal"
+	"github.com/minivera/go-lander/nodes"
+)
+
+// ComponentRoot is a component of the tree that can be rendered again on its own, with the information
+// needed to generate its patches.
+type ComponentRoot struct {
+	// Component is the component to render again.
+	Component *nodes.FuncNode
+	// DOMParent is the closest DOM element containing the component's DOM nodes.
+	DOMParent dom.Element
+	// Next is the anchor of the DOM node following the component's DOM nodes in DOMParent.
+	Next Anchor
+}
+
+// FindComponentRoots walks the tree to find the given components, in tree order. Components inside
+// another given component are skipped, as they will be rendered with their ancestor. Components that
+// are no longer part of the tree are ignored. Components rendering no DOM node are positioned against
+// the DOM nodes of their siblings, they can be rendered on their own like any other component.
+func FindComponentRoots(tree nodes.Node, rootElement dom.Element,
+	components map[*nodes.FuncNode]bool) []ComponentRoot {
+
+	var roots []ComponentRoot
+	findComponentRoots(tree, rootElement, nil, components, &roots)
+
+	return roots
+}
+
+func findComponentRoots(currentNode nodes.Node, parent dom.Element, next Anchor,
+	components map[*nodes.FuncNode]bool, roots *[]ComponentRoot) {
+
+	var children []nodes.Node
+	switch typedNode := currentNode.(type) {
+	case *nodes.FuncNode:
+		if !components[typedNode] {
+			findComponentRoots(typedNode.RenderResult, parent, next, components, roots)
+			return
+		}
+
+		*roots = append(*roots, ComponentRoot{Component: typedNode, DOMParent: parent, Next: next})
+		return
+	case *nodes.FragmentNode:
+		children = typedNode.Children
+	case *nodes.PortalNode:
+		if typedNode.Container == nil {
+			return
+		}
+
+		children = typedNode.Children
+		parent = typedNode.Container
+		next = portalAnchor(typedNode)
+	case *nodes.HTMLNode:
+		children = typedNode.Children
+		parent = typedNode.DomNode
+		next = nil
+	}
+
+	siblings := append([]nodes.Node(nil), children...)
+	for index, child := range children {
+		findComponentRoots(child, parent, siblingAnchor(siblings[index+1:], next), components, roots)
+	}
+}
+
+// CollectStyles returns the styles of all the HTML nodes of the tree, in tree order. This slice should be
+// added in a style tag in the page's head for elements to be properly styled.
+func CollectStyles(currentNode nodes.Node) []string {
+	var styles []string
+	switch typedNode := currentNode.(type) {
+	case *nodes.FuncNode:
+		styles = CollectStyles(typedNode.RenderResult)
+	case *nodes.FragmentNode:
+		for _, child := range typedNode.Children {
+			styles = append(styles, CollectStyles(child)...)
+		}
+	case *nodes.PortalNode:
+		for _, child := range typedNode.Children {
+			styles = append(styles, CollectStyles(child)...)
+		}
+	case *nodes.HTMLNode:
+		styles = append(styles, typedNode.Styles...)
+		for _, child := range typedNode.Children {
+			styles = append(styles, CollectStyles(child)...)
+		}
+	}
+
+	return styles
+}
+
+// renderComponent renders the given component node with the CurrentContext. The live node is the node
+// stored in the tree, which owns the hook slots of the component, while rendered may be one of its clones
+// or the new node generated by the parent's render.
+//
+// A panic while rendering is sent to the nearest error handler, the component then renders nothing. The
+// panic continues if no ancestor handles errors.
+func renderComponent(live, rendered *nodes.FuncNode) (result nodes.Node) {
+	scope := context.CurrentScope()
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		internal.Debugf("Component %T panicked while rendering, %v\n", live, recovered)
+		if !context.HandleError(scope, context.NewPanicError(recovered)) {
+			panic(recovered)
+		}
+
+		rendered.RenderResult = nil
+		result = nil
+	}()
+
+	live.StartRender()
+	result = rendered.Render(context.CurrentContext)
+	live.EndRender()
+
+	return result
+}
+
+// unmountComponents registers an unmount for every component of the given tree, which is about to be removed
+// from the DOM. Components are unmounted before their descendants and their hook slots are discarded.
+func unmountComponents(node nodes.Node) {
+	switch typedNode := node.(type) {
+	case *nodes.FuncNode:
+		internal.Debugf("Unmounting component %T, %v\n", typedNode, typedNode)
+		defer context.RestoreScope(context.CurrentScope())
+		context.RegisterComponent(typedNode)
+		context.UnregisterAllComponentContexts(typedNode)
+		context.RegisterComponentContext("unmount", typedNode)
+		typedNode.ClearSlots()
+
+		unmountComponents(typedNode.RenderResult)
+	case *nodes.FragmentNode:
+		for _, child := range typedNode.Children {
+			unmountComponents(child)
+		}
+	case *nodes.PortalNode:
+		for _, child := range typedNode.Children {
+			unmountComponents(child)
+		}
+	case *nodes.HTMLNode:
+		for _, child := range typedNode.Children {
+			unmountComponents(child)
+		}
+	}
+}
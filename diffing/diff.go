@@ -3,9 +3,9 @@ package diffing
 import (
 	"fmt"
 	"reflect"
-	"syscall/js"
 
 	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
 	"github.com/minivera/go-lander/events"
 	"github.com/minivera/go-lander/internal"
 	"github.com/minivera/go-lander/nodes"
@@ -16,91 +16,78 @@ import (
 // executed sequentially. GeneratePatches will handle all type of nodes, the tree it is given as the
 // oldNode should be the complete tree, components and fragments included.
 //
-// The listenerFunc argument is a function for JS event listeners. All listeners should use the same
-// listener function to lock the tree when an event is being handled.
+// The delegator argument is the event delegator of the app, the event listeners of the patched HTML nodes
+// are registered in it.
 //
 // The prev and prevDOMNode arguments take the previous valid virtual DOM node and the previous valid
 // real DOM node respectively. This ensures that patches can run on the virtual and real parents properly.
 //
-// indexInPrevDOMNode is a pointer to the numerical index of the current tree in the last seen DOM node,
-// stored in prevDOMNode. The pointer is used to update it recursively without the need to add more return
-// values. This is necessary for insert patches to insert in the right location. Pass nil to append.
+// slot is the index of the old node in the children of prev. A node inserted where the old node was a nil
+// child takes its slot, so the children of prev keep lining up with the children of the next render. Pass -1
+// to append.
+//
+// next is the anchor of the DOM node following the current tree in prevDOMNode, new DOM nodes are inserted
+// before it. Text nodes, fragments and components rendering nil are all positioned against real sibling DOM
+// nodes this way. Pass nil to append.
 //
 // The function returns a slice of patches, a slice of styles detected from the various children, and a
 // potential error. The slice of styles should be appended to the head for HTML nodes to be properly styled.
-func GeneratePatches(listenerFunc func(listener events.EventListenerFunc, this js.Value, args []js.Value) interface{},
-	prev nodes.Node, prevDOMNode js.Value, indexInPrevDOMNode *int, old, new nodes.Node) ([]Patch, []string, error) {
+func GeneratePatches(delegator *events.Delegator,
+	prev nodes.Node, prevDOMNode dom.Element, slot int, next Anchor, old, new nodes.Node) ([]Patch, []string, error) {
 
 	var patches []Patch
 	var currentStyles []string
 
 	var oldChildren []nodes.Node
 	var newChildren []nodes.Node
-	isDOMNode := false
+	// childNext is the anchor of the DOM node following the children, they are appended in their own element
+	childNext := next
+	// controlled is the patched HTML node to sync once its children are patched, if it is a form element
+	var controlled *nodes.HTMLNode
 
 	internal.Debugf("Diffing %T, %v against %T, %v\n", old, old, new, new)
-	if new == nil {
-		if typedNode, ok := old.(*nodes.FuncNode); ok {
-			// If we hit a function as the old node when there's a need to remove, then we
-			// should do nothing and trigger an unmount on the old node, then keep going so we
-			// can remove the HTML nodes.
-			internal.Debugln("New was missing and old node is a component, triggering a unmount")
-			context.RegisterComponent(typedNode)
-			context.UnregisterAllComponentContexts(typedNode)
-			context.RegisterComponentContext("unmount", typedNode)
-		}
+	if old == nil && new == nil {
+		// Nil children render nothing, their slot is kept as is
+		return patches, currentStyles, nil
+	} else if new == nil {
+		// Trigger an unmount on all the components of the old node, then keep going so we can
+		// remove the HTML nodes.
+		unmountComponents(old)
 
 		internal.Debugln("New was missing, removing")
 		// If the new is missing, then we should remove unneeded children
-		patches = append(patches, newPatchRemove(prev, prevDOMNode, old))
+		patches = append(patches, newPatchRemove(delegator, prev, prevDOMNode, old))
 
 		return patches, currentStyles, nil
 	} else if old == nil {
 		internal.Debugln("Old was missing, inserting")
 		// If the old node is missing, then we are mounting for the first time
-		if indexInPrevDOMNode != nil {
-			patches = append(patches, newPatchInsertAt(listenerFunc, prevDOMNode, *indexInPrevDOMNode, prev, new))
-		} else {
-			patches = append(patches, newPatchInsert(listenerFunc, prevDOMNode, prev, new))
-		}
+		patches = append(patches, newPatchInsert(delegator, prevDOMNode, slot, next, prev, new))
 
-		switch typedNode := new.(type) {
-		case *nodes.HTMLNode:
-			if indexInPrevDOMNode != nil {
-				*indexInPrevDOMNode += 1
-			}
+		if typedNode, ok := new.(*nodes.HTMLNode); ok {
 			currentStyles = append(currentStyles, typedNode.Styles...)
-		case *nodes.TextNode:
-			if indexInPrevDOMNode != nil {
-				*indexInPrevDOMNode += 1
-			}
 		}
 
 		return patches, currentStyles, nil
 	} else if reflect.TypeOf(old) != reflect.TypeOf(new) {
 		internal.Debugln("Types were different, replacing")
 		// If both nodes exist, but they are of a different type, replace and patch
-		patches = append(patches, newPatchReplace(listenerFunc, prevDOMNode, *indexInPrevDOMNode, prev, old, new))
+		patches = append(patches, newPatchReplace(delegator, prevDOMNode, next, prev, old, new))
 
-		switch typedNode := new.(type) {
-		case *nodes.FuncNode:
-			// If we hit a function node as the old when there's a need to replace, then we should
-			// trigger an unmount on the old node and not render. We don't care about the old node here
-			// as we should never rerender it.
-			internal.Debugln("Types were different and old node is a component, keep going on the new children")
-			context.UnregisterAllComponentContexts(typedNode)
-			context.RegisterComponentContext("unmount", typedNode)
-		case *nodes.HTMLNode:
-			if indexInPrevDOMNode != nil {
-				*indexInPrevDOMNode += 1
-			}
+		// The components of the old node are never rendered again, trigger an unmount on all of them
+		unmountComponents(old)
+
+		if typedNode, ok := new.(*nodes.HTMLNode); ok {
 			currentStyles = append(currentStyles, typedNode.Styles...)
-		case *nodes.TextNode:
-			if indexInPrevDOMNode != nil {
-				*indexInPrevDOMNode += 1
-			}
 		}
 
+		return patches, currentStyles, nil
+	} else if component, ok := old.(*nodes.FuncNode); ok && !component.SameComponent(new.(*nodes.FuncNode)) {
+		internal.Debugln("Components were different, replacing")
+		// A different component is mounted from scratch, it must never receive the hook slots of the old one
+		patches = append(patches, newPatchReplace(delegator, prevDOMNode, next, prev, old, new))
+		unmountComponents(old)
+
 		return patches, currentStyles, nil
 	} else if old.Diff(new) {
 		internal.Debugln("Nodes were different, updating")
@@ -109,15 +96,20 @@ func GeneratePatches(listenerFunc func(listener events.EventListenerFunc, this j
 		case *nodes.FuncNode:
 			// If we hit a function node for both nodes, and they are different, then we should render the
 			// new node and assign its result as the result of the old node. We can then keep going on
-			// both children. No need to update the function nodes themselves
+			// both children. The old node is updated with the new properties so it can be rendered again
+			// on its own.
 			oldChildren = nodes.Children{typedNode.RenderResult}
 			newConverted := new.(*nodes.FuncNode)
 
+			// Values provided by the component are only visible to its descendants
+			defer context.RestoreScope(context.CurrentScope())
+
 			// Registering with old node so we can keep the references of the current
 			// tree alive. Otherwise, the context will track the wrong nodes.
 			context.RegisterComponent(typedNode)
 			context.RegisterComponentContext("render", typedNode)
-			newChildren = nodes.Children{newConverted.Clone().Render(context.CurrentContext)}
+			newChildren = nodes.Children{renderComponent(typedNode, newConverted.Clone())}
+			patches = append(patches, newPatchComponent(typedNode, newConverted))
 		case *nodes.FragmentNode:
 			// If we hit a function node for both nodes, and they are different, then we should render the
 			// new node and assign its result as the result of the old node. We can then keep going on
@@ -125,24 +117,37 @@ func GeneratePatches(listenerFunc func(listener events.EventListenerFunc, this j
 			oldChildren = typedNode.Children
 			newConverted := new.(*nodes.FragmentNode)
 			newChildren = newConverted.Children
+		case *nodes.PortalNode:
+			// Portals are only different when they render into another target, replace them
+			patches = append(patches, newPatchReplace(delegator, prevDOMNode, next, prev, old, new))
+			unmountComponents(old)
 		case *nodes.HTMLNode:
-			isDOMNode = true
 			newConverted := new.(*nodes.HTMLNode)
 			if typedNode.Tag != newConverted.Tag {
 				// If the tags are different, this is not a diff, this is a replace
-				patches = append(patches, newPatchReplace(listenerFunc, prevDOMNode, *indexInPrevDOMNode, prev, old, new))
+				patches = append(patches, newPatchReplace(delegator, prevDOMNode, next, prev, old, new))
+				unmountComponents(old)
 				currentStyles = append(currentStyles, newConverted.Styles...)
 			} else {
-				patches = append(patches, newPatchHTML(listenerFunc, typedNode, new.(*nodes.HTMLNode)))
+				patches = append(patches, newPatchHTML(delegator, typedNode, new.(*nodes.HTMLNode)))
+				controlled = typedNode
 				oldChildren = typedNode.Children
 				newChildren = newConverted.Children
 
 				currentStyles = append(currentStyles, new.(*nodes.HTMLNode).Styles...)
 				prevDOMNode = typedNode.DomNode
+				childNext = nil
 			}
 		case *nodes.TextNode:
-			isDOMNode = true
 			patches = append(patches, newPatchText(prev, typedNode, new.(*nodes.TextNode).Text))
+		case *nodes.RawHTMLNode:
+			newConverted := new.(*nodes.RawHTMLNode)
+			if typedNode.Tag != newConverted.Tag {
+				// If the tags are different, the container must be replaced
+				patches = append(patches, newPatchReplace(delegator, prevDOMNode, next, prev, old, new))
+			} else {
+				patches = append(patches, newPatchRawHTML(typedNode, newConverted.HTML))
+			}
 		default:
 			return nil, []string{}, fmt.Errorf("somehow got neither a text, nor a HTML node during patching, cannot process node")
 		}
@@ -156,42 +161,75 @@ func GeneratePatches(listenerFunc func(listener events.EventListenerFunc, this j
 			oldChildren = nodes.Children{oldConverted.RenderResult}
 			newConverted := new.(*nodes.FuncNode)
 
+			// Values provided by the component are only visible to its descendants
+			defer context.RestoreScope(context.CurrentScope())
+
 			// Registering with old node so we can keep the references of the current
 			// tree alive. Otherwise, the context will track the wrong nodes.
 			context.RegisterComponent(oldConverted)
 			context.RegisterComponentContext("render", oldConverted)
-			newChildren = nodes.Children{newConverted.Clone().Render(context.CurrentContext)}
+			newChildren = nodes.Children{renderComponent(oldConverted, newConverted.Clone())}
+			patches = append(patches, newPatchComponent(oldConverted, newConverted))
 		case *nodes.FragmentNode:
 			oldChildren = oldConverted.Children
 			newConverted := new.(*nodes.FragmentNode)
 			newChildren = newConverted.Children
+		case *nodes.PortalNode:
+			if oldConverted.Container == nil {
+				// The target could not be found when the portal was mounted, try again by replacing it
+				patches = append(patches, newPatchReplace(delegator, prevDOMNode, next, prev, old, new))
+				unmountComponents(old)
+				break
+			}
+
+			// The children of portals are positioned in their container, which may contain other nodes
+			oldChildren = oldConverted.Children
+			newChildren = new.(*nodes.PortalNode).Children
+			prevDOMNode = oldConverted.Container
+			childNext = portalAnchor(oldConverted)
 		case *nodes.HTMLNode:
-			isDOMNode = true
 			oldChildren = oldConverted.Children
 			currentStyles = append(currentStyles, oldConverted.Styles...)
-			patches = append(patches, newPatchListeners(listenerFunc, oldConverted))
-
 			newConverted := new.(*nodes.HTMLNode)
+			patches = append(patches, newPatchListeners(delegator, oldConverted, newConverted))
+			controlled = oldConverted
+
 			newChildren = newConverted.Children
 			prevDOMNode = oldConverted.DomNode
-		case *nodes.TextNode:
-			isDOMNode = true
+			childNext = nil
 		}
 	}
 
-	// If the current node is a DOM node, then we should reset the current index to 0 and start counting.
-	// all subsequent children are not children of this node.
-	currentIndexInDomNode := indexInPrevDOMNode
-	if isDOMNode {
-		reset := 0
-		currentIndexInDomNode = &reset
-		// Also add 1 to the general index so the parent can keep counting
-		if indexInPrevDOMNode != nil {
-			*indexInPrevDOMNode += 1
+	// Controlled form elements are synced once their children, such as the options of a select, are patched.
+	// The DOM may differ from the virtual node even if the node did not change, when the user typed in it.
+	finish := func(patches []Patch, styles []string) ([]Patch, []string, error) {
+		if controlled != nil && controlled.IsControlled() {
+			patches = append(patches, newPatchControlled(controlled))
 		}
+
+		return patches, styles, nil
 	}
 
-	// Start by running through the old children and patch individually
+	// Keyed children are matched by key rather than by position
+	if _, isComponent := old.(*nodes.FuncNode); !isComponent && (hasKeys(oldChildren) || hasKeys(newChildren)) {
+		childPatches, styles, err := generateKeyedPatches(
+			delegator,
+			old,
+			prevDOMNode,
+			childNext,
+			oldChildren,
+			newChildren,
+		)
+		if err != nil {
+			return nil, []string{}, err
+		}
+
+		return finish(append(patches, childPatches...), append(currentStyles, styles...))
+	}
+
+	// Start by running through the old children and patch individually. Each child is positioned before the
+	// first DOM node of the old children following it, the patches never mutate the copy of the children.
+	siblings := append([]nodes.Node(nil), oldChildren...)
 	count := 0
 	for _, child := range oldChildren {
 		var newChild nodes.Node
@@ -199,7 +237,15 @@ func GeneratePatches(listenerFunc func(listener events.EventListenerFunc, this j
 			newChild = newChildren[count]
 		}
 
-		childPatches, styles, err := GeneratePatches(listenerFunc, old, prevDOMNode, currentIndexInDomNode, child, newChild)
+		childPatches, styles, err := GeneratePatches(
+			delegator,
+			old,
+			prevDOMNode,
+			count,
+			siblingAnchor(siblings[count+1:], childNext),
+			child,
+			newChild,
+		)
 		if err != nil {
 			return nil, []string{}, err
 		}
@@ -211,11 +257,11 @@ func GeneratePatches(listenerFunc func(listener events.EventListenerFunc, this j
 
 	// If we still have new nodes left, then loop over them and insert
 	if count >= len(newChildren) {
-		return patches, currentStyles, nil
+		return finish(patches, currentStyles)
 	}
 
 	for _, child := range newChildren[count:] {
-		childPatches, styles, err := GeneratePatches(listenerFunc, old, prevDOMNode, nil, nil, child)
+		childPatches, styles, err := GeneratePatches(delegator, old, prevDOMNode, -1, childNext, nil, child)
 		if err != nil {
 			return nil, []string{}, err
 		}
@@ -223,5 +269,5 @@ func GeneratePatches(listenerFunc func(listener events.EventListenerFunc, this j
 		currentStyles = append(currentStyles, styles...)
 	}
 
-	return patches, currentStyles, nil
+	return finish(patches, currentStyles)
 }
@@ -0,0 +1,332 @@
+package diffing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/internal"
+	"github.com/minivera/go-lander/nodes"
+)
+
+// HydrationMismatchType is an enum of the kinds of mismatches hydration can detect between the virtual
+// tree and the existing DOM.
+type HydrationMismatchType string
+
+const (
+	// TagMismatch is reported when the existing DOM node is not the element the virtual tree expected.
+	TagMismatch HydrationMismatchType = "tag"
+	// TextMismatch is reported when the content of an existing text node differs from the virtual text.
+	TextMismatch HydrationMismatchType = "text"
+	// AttributeMismatch is reported when an attribute, the ID or a class of an existing element differs
+	// from the virtual element, or when the element has an attribute the virtual element does not expect.
+	AttributeMismatch HydrationMismatchType = "attribute"
+	// MissingNodeMismatch is reported when the virtual tree expected a node, but none was left in the DOM.
+	MissingNodeMismatch HydrationMismatchType = "missing"
+	// ExtraNodeMismatch is reported when the DOM contains a node the virtual tree did not expect.
+	ExtraNodeMismatch HydrationMismatchType = "extra"
+)
+
+// HydrationMismatch describes a single difference found between the virtual tree and the existing DOM
+// during hydration. Hydration always fixes the DOM to match the virtual tree, mismatches are reported so
+// the differences between the server and client render can be found and fixed.
+type HydrationMismatch struct {
+	// Type is the kind of mismatch.
+	Type HydrationMismatchType
+	// Node is the virtual node that was being hydrated, nil for extra nodes.
+	Node nodes.Node
+	// Name is the name of the attribute that did not match, only set for attribute mismatches.
+	Name string
+	// Expected is the value expected by the virtual tree, such as the tag or text.
+	Expected string
+	// Actual is the value found in the DOM.
+	Actual string
+}
+
+func (m HydrationMismatch) String() string {
+	switch m.Type {
+	case AttributeMismatch:
+		return fmt.Sprintf("attribute %q mismatch, expected %q but found %q", m.Name, m.Expected, m.Actual)
+	case MissingNodeMismatch:
+		return fmt.Sprintf("missing node, expected %s", m.Expected)
+	case ExtraNodeMismatch:
+		return fmt.Sprintf("extra node %s was removed", m.Actual)
+	default:
+		return fmt.Sprintf("%s mismatch, expected %q but found %q", m.Type, m.Expected, m.Actual)
+	}
+}
+
+// HydrationError is the error returned when hydration found mismatches between the virtual tree and the
+// existing DOM. The DOM was still updated to match the virtual tree.
+type HydrationError struct {
+	Mismatches []HydrationMismatch
+}
+
+func (e *HydrationError) Error() string {
+	messages := make([]string, len(e.Mismatches))
+	for i, mismatch := range e.Mismatches {
+		messages[i] = mismatch.String()
+	}
+
+	return fmt.Sprintf("hydration found %d mismatches: %s", len(e.Mismatches), strings.Join(messages, "; "))
+}
+
+// HydrateChildren hydrates the existing DOM children of parent, starting from the start node, with the given
+// virtual children. Rather than creating new DOM nodes, hydration binds the existing DOM nodes to the virtual
+// nodes and attaches their event listeners. Components are rendered and registered for their mount and render
+// contexts, like RecursivelyMount.
+//
+// Any mismatch between the virtual tree and the DOM is fixed so the DOM matches the virtual tree, then returned
+// in the mismatches slice. Nodes left in the parent after the last child are removed.
+//
+// The function returns a slice of style strings from the encountered DOM nodes. This slice should be added
+// in a style tag in the page's head for elements to be properly styled.
+func HydrateChildren(delegator *events.Delegator,
+	document dom.Document, parent dom.Element, start dom.Node, children []nodes.Node) ([]string, []HydrationMismatch) {
+
+	h := &hydrator{
+		delegator: delegator,
+		document:  document,
+	}
+
+	next := start
+	var styles []string
+	for _, child := range children {
+		styles = append(styles, h.hydrate(parent, &next, child)...)
+	}
+	h.removeRemaining(parent, next)
+
+	return styles, h.mismatches
+}
+
+type hydrator struct {
+	delegator  *events.Delegator
+	document   dom.Document
+	mismatches []HydrationMismatch
+}
+
+// hydrate hydrates the current virtual node against the DOM node pointed by next in parent. next is moved
+// to the next unclaimed DOM node as nodes are claimed.
+func (h *hydrator) hydrate(parent dom.Element, next *dom.Node, currentNode nodes.Node) []string {
+	if currentNode == nil {
+		return []string{}
+	}
+
+	internal.Debugf("Hydrating %T node, %v\n", currentNode, currentNode)
+	var styles []string
+	switch typedNode := currentNode.(type) {
+	case *nodes.FuncNode:
+		// Values provided by the component are only visible to its descendants
+		scope := context.CurrentScope()
+		context.RegisterComponent(typedNode)
+		context.RegisterComponentContext("mount", typedNode)
+		context.RegisterComponentContext("render", typedNode)
+		styles = h.hydrate(parent, next, renderComponent(typedNode, typedNode))
+		context.RestoreScope(scope)
+	case *nodes.FragmentNode:
+		for _, child := range typedNode.Children {
+			styles = append(styles, h.hydrate(parent, next, child)...)
+		}
+	case *nodes.PortalNode:
+		// The server never renders the children of portals, they are mounted in their container
+		styles = RecursivelyMount(h.delegator, h.document, parent, typedNode)
+	case *nodes.HTMLNode:
+		element, ok := (*next).(dom.Element)
+		if !ok || element.TagName() != strings.ToLower(typedNode.Tag) {
+			if *next == nil {
+				h.report(HydrationMismatch{Type: MissingNodeMismatch, Node: typedNode, Expected: typedNode.Tag})
+			} else {
+				h.report(HydrationMismatch{Type: TagMismatch, Node: typedNode, Expected: typedNode.Tag, Actual: describe(*next)})
+			}
+
+			return h.mountInstead(parent, next, typedNode, true)
+		}
+		*next = element.NextSibling()
+
+		h.checkAttributes(element, typedNode)
+		typedNode.Mount(element)
+		addEventListeners(h.delegator, typedNode)
+		styles = append(styles, typedNode.Styles...)
+
+		var childNext dom.Node
+		if childNodes := element.ChildNodes(); len(childNodes) > 0 {
+			childNext = childNodes[0]
+		}
+		for _, child := range typedNode.Children {
+			styles = append(styles, h.hydrate(element, &childNext, child)...)
+		}
+		h.removeRemaining(element, childNext)
+		typedNode.SyncControlled()
+	case *nodes.TextNode:
+		if typedNode.Text == "" {
+			// Empty text is never rendered by the server, create it silently without claiming the next node
+			return h.mountInstead(parent, next, typedNode, false)
+		}
+
+		text, ok := (*next).(dom.Text)
+		if !ok {
+			h.report(HydrationMismatch{Type: MissingNodeMismatch, Node: typedNode, Expected: typedNode.Text})
+
+			return h.mountInstead(parent, next, typedNode, false)
+		}
+		*next = text.NextSibling()
+
+		value := text.NodeValue()
+		if value != typedNode.Text && strings.HasPrefix(value, typedNode.Text) {
+			// The browser merges adjacent text nodes when parsing HTML, split the text so the remaining
+			// content can be claimed by the next virtual text nodes.
+			remaining := h.document.CreateTextNode(value[len(typedNode.Text):])
+			parent.InsertBefore(remaining, *next)
+			*next = remaining
+		} else if value != typedNode.Text {
+			h.report(HydrationMismatch{Type: TextMismatch, Node: typedNode, Expected: typedNode.Text, Actual: value})
+		}
+
+		typedNode.Mount(text)
+	case *nodes.RawHTMLNode:
+		element, ok := (*next).(dom.Element)
+		if !ok || element.TagName() != strings.ToLower(typedNode.Tag) {
+			if *next == nil {
+				h.report(HydrationMismatch{Type: MissingNodeMismatch, Node: typedNode, Expected: typedNode.Tag})
+			} else {
+				h.report(HydrationMismatch{Type: TagMismatch, Node: typedNode, Expected: typedNode.Tag, Actual: describe(*next)})
+			}
+
+			return h.mountInstead(parent, next, typedNode, true)
+		}
+		*next = element.NextSibling()
+
+		// The content is owned by the node and was rendered by the server, it is kept as is
+		typedNode.Hydrate(element)
+	}
+
+	return styles
+}
+
+// mountInstead mounts the virtual node from scratch when it could not be hydrated. The mounted node is
+// inserted before the next DOM node, which is replaced if replace is set.
+func (h *hydrator) mountInstead(parent dom.Element, next *dom.Node, currentNode nodes.Node, replace bool) []string {
+	styles := RecursivelyMount(h.delegator, h.document, parent, currentNode)
+
+	var mounted dom.Node
+	switch typedNode := currentNode.(type) {
+	case *nodes.HTMLNode:
+		mounted = typedNode.DomNode
+	case *nodes.TextNode:
+		mounted = typedNode.DomNode
+	case *nodes.RawHTMLNode:
+		mounted = typedNode.DomNode
+	}
+
+	if *next == nil {
+		return styles
+	}
+
+	parent.InsertBefore(mounted, *next)
+	if replace {
+		toRemove := *next
+		*next = toRemove.NextSibling()
+		parent.RemoveChild(toRemove)
+	}
+
+	return styles
+}
+
+// checkAttributes reports any attribute, ID, or class expected by the virtual node and missing from
+// the DOM element. Classes not expected by the virtual node are removed from the element, as the active
+// class generated for styles is random and will never match the server's. Other attributes not expected
+// by the virtual node are removed and reported, properties and inline styles are rendered as attributes by
+// the server and are expected.
+func (h *hydrator) checkAttributes(element dom.Element, node *nodes.HTMLNode) {
+	for _, name := range element.AttributeNames() {
+		if _, ok := node.Attributes[name]; ok || name == "id" || name == "class" {
+			continue
+		}
+		if _, ok := node.Properties[name]; ok {
+			continue
+		}
+		if name == nodes.StyleAttribute && len(node.InlineStyle) > 0 {
+			continue
+		}
+
+		actual, _ := element.GetAttribute(name)
+		h.report(HydrationMismatch{Type: AttributeMismatch, Node: node, Name: name, Actual: actual})
+		element.RemoveAttribute(name)
+	}
+
+	for name, expected := range node.Attributes {
+		if name == "id" || name == "class" {
+			continue
+		}
+
+		actual, ok := element.GetAttribute(name)
+		if !ok || actual != expected {
+			h.report(HydrationMismatch{
+				Type:     AttributeMismatch,
+				Node:     node,
+				Name:     name,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+
+	if actual, _ := element.GetAttribute("id"); actual != node.DomID {
+		h.report(HydrationMismatch{Type: AttributeMismatch, Node: node, Name: "id", Expected: node.DomID, Actual: actual})
+	}
+
+	expectedClasses := map[string]bool{}
+	for _, class := range node.Classes {
+		expectedClasses[class] = true
+	}
+
+	actualClasses := map[string]bool{}
+	for _, class := range element.ClassList() {
+		actualClasses[class] = true
+		if !expectedClasses[class] {
+			element.RemoveClass(class)
+		}
+	}
+
+	for _, class := range node.Classes {
+		if class != "" && !actualClasses[class] {
+			h.report(HydrationMismatch{
+				Type:     AttributeMismatch,
+				Node:     node,
+				Name:     "class",
+				Expected: strings.Join(node.Classes, " "),
+				Actual:   strings.Join(element.ClassList(), " "),
+			})
+			break
+		}
+	}
+}
+
+// removeRemaining removes all the DOM nodes left in parent, starting from next, and reports them.
+func (h *hydrator) removeRemaining(parent dom.Element, next dom.Node) {
+	for next != nil {
+		toRemove := next
+		next = next.NextSibling()
+
+		h.report(HydrationMismatch{Type: ExtraNodeMismatch, Actual: describe(toRemove)})
+		parent.RemoveChild(toRemove)
+	}
+}
+
+func (h *hydrator) report(mismatch HydrationMismatch) {
+	internal.Debugf("Hydration mismatch: %s\n", mismatch)
+	h.mismatches = append(h.mismatches, mismatch)
+}
+
+func describe(node dom.Node) string {
+	switch typed := node.(type) {
+	case dom.Element:
+		return typed.TagName()
+	case dom.Text:
+		return fmt.Sprintf("%q", typed.NodeValue())
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,201 @@
+package diffing
+
+import (
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/internal"
+	"github.com/minivera/go-lander/nodes"
+)
+
+// hasKeys returns true if any of the given children has a key, in which case the children should be
+// reconciled with generateKeyedPatches.
+func hasKeys(children []nodes.Node) bool {
+	for _, child := range children {
+		if child != nil && nodes.KeyOf(child) != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// generateKeyedPatches generates the patches to reconcile the children of a node where at least one child
+// is keyed. Keyed children are matched by key, unkeyed children are matched in order with the remaining
+// unkeyed children. Unmatched old children are removed and unmatched new children are inserted, then a
+// single move patch puts the DOM nodes and the virtual children in the new order, moving as few DOM nodes as
+// possible.
+//
+// next is the anchor of the DOM node following the children in parentDOMNode, in case the children do not
+// fill the whole DOM parent, like in a fragment.
+func generateKeyedPatches(delegator *events.Delegator,
+	parent nodes.Node, parentDOMNode dom.Element, next Anchor,
+	oldChildren, newChildren []nodes.Node) ([]Patch, []string, error) {
+
+	var patches []Patch
+	var currentStyles []string
+
+	oldByKey := map[interface{}]int{}
+	var unkeyedOld []int
+	for index, child := range oldChildren {
+		if child == nil {
+			continue
+		}
+
+		if key := nodes.KeyOf(child); key != nil {
+			oldByKey[key] = index
+		} else {
+			unkeyedOld = append(unkeyedOld, index)
+		}
+	}
+
+	// Match each new child with an old child, -1 means the new child will be inserted
+	used := make([]bool, len(oldChildren))
+	var matchedNew []nodes.Node
+	var matchedOld []int
+	for _, child := range newChildren {
+		if child == nil {
+			continue
+		}
+
+		oldIndex := -1
+		if key := nodes.KeyOf(child); key != nil {
+			if index, ok := oldByKey[key]; ok && !used[index] {
+				oldIndex = index
+			}
+		} else if len(unkeyedOld) > 0 {
+			oldIndex, unkeyedOld = unkeyedOld[0], unkeyedOld[1:]
+		}
+
+		if oldIndex >= 0 {
+			used[oldIndex] = true
+		}
+		matchedNew = append(matchedNew, child)
+		matchedOld = append(matchedOld, oldIndex)
+	}
+
+	internal.Debugf("Keyed children matched as %v\n", matchedOld)
+	for index, child := range oldChildren {
+		if child == nil || used[index] {
+			continue
+		}
+
+		childPatches, styles, err := GeneratePatches(delegator, parent, parentDOMNode, -1, nil, child, nil)
+		if err != nil {
+			return nil, []string{}, err
+		}
+		patches = append(patches, childPatches...)
+		currentStyles = append(currentStyles, styles...)
+	}
+
+	// Stable children keep their DOM position, the DOM nodes they add must be inserted before the stable
+	// children following them in the new order. Stable children are patched in order, so the DOM nodes of the
+	// following ones are still in place when the anchor is resolved. Other children are moved as a whole by
+	// the move patch, their anchor only needs to keep their own DOM nodes in order.
+	stable := longestIncreasingSubsequence(matchedOld)
+	anchors := make([]Anchor, len(matchedNew))
+	var stableSiblings []nodes.Node
+	for index := len(matchedNew) - 1; index >= 0; index-- {
+		anchors[index] = siblingAnchor(stableSiblings, next)
+		if stable[index] {
+			stableSiblings = append([]nodes.Node{oldChildren[matchedOld[index]]}, stableSiblings...)
+		}
+	}
+
+	for index, child := range matchedNew {
+		var oldChild nodes.Node
+		if matchedOld[index] >= 0 {
+			oldChild = oldChildren[matchedOld[index]]
+		}
+
+		childPatches, styles, err := GeneratePatches(
+			delegator,
+			parent,
+			parentDOMNode,
+			-1,
+			anchors[index],
+			oldChild,
+			child,
+		)
+		if err != nil {
+			return nil, []string{}, err
+		}
+		patches = append(patches, childPatches...)
+		currentStyles = append(currentStyles, styles...)
+	}
+
+	patches = append(patches, newPatchMove(parent, parentDOMNode, next, oldChildren, matchedOld, matchedNew))
+
+	return patches, currentStyles, nil
+}
+
+// domNodesOf returns the DOM nodes rendered by the given virtual node, in order.
+func domNodesOf(node nodes.Node) []dom.Node {
+	switch typed := node.(type) {
+	case *nodes.HTMLNode:
+		if typed.DomNode != nil {
+			return []dom.Node{typed.DomNode}
+		}
+	case *nodes.TextNode:
+		if typed.DomNode != nil {
+			return []dom.Node{typed.DomNode}
+		}
+	case *nodes.RawHTMLNode:
+		if typed.DomNode != nil {
+			return []dom.Node{typed.DomNode}
+		}
+	case *nodes.FuncNode:
+		return domNodesOf(typed.RenderResult)
+	case *nodes.FragmentNode:
+		var domNodes []dom.Node
+		for _, child := range typed.Children {
+			domNodes = append(domNodes, domNodesOf(child)...)
+		}
+		return domNodes
+	}
+
+	return nil
+}
+
+// longestIncreasingSubsequence returns a set of the indexes of the values part of the longest increasing
+// subsequence of values. Negative values are ignored.
+func longestIncreasingSubsequence(values []int) map[int]bool {
+	// tails[i] is the index of the smallest tail of all increasing subsequences of length i+1
+	var tails []int
+	previous := make([]int, len(values))
+	for index, value := range values {
+		previous[index] = -1
+		if value < 0 {
+			continue
+		}
+
+		low, high := 0, len(tails)
+		for low < high {
+			middle := (low + high) / 2
+			if values[tails[middle]] < value {
+				low = middle + 1
+			} else {
+				high = middle
+			}
+		}
+
+		if low > 0 {
+			previous[index] = tails[low-1]
+		}
+		if low == len(tails) {
+			tails = append(tails, index)
+		} else {
+			tails[low] = index
+		}
+	}
+
+	result := map[int]bool{}
+	if len(tails) == 0 {
+		return result
+	}
+
+	for index := tails[len(tails)-1]; index >= 0; index = previous[index] {
+		result[index] = true
+	}
+
+	return result
+}
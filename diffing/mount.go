@@ -1,9 +1,8 @@
 package diffing
 
 import (
-	"syscall/js"
-
 	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
 	"github.com/minivera/go-lander/events"
 	"github.com/minivera/go-lander/internal"
 	"github.com/minivera/go-lander/nodes"
@@ -13,20 +12,30 @@ import (
 // not mutate the tree, it only mounts it to the document. This will also render and register the mount
 // context for components it encounters.
 //
-// The listenerFunc argument is a function for JS event listeners. All listeners should use the same
-// listener function to lock the tree when an event is being handled.
+// The delegator argument is the event delegator of the app, the event listeners of the mounted HTML nodes
+// are registered in it.
 //
 // The function returns a slice of style strings from the encountered DOM nodes. This slice should be added
 // in a style tag in the page's head for elements to be properly styled.
-func RecursivelyMount(listenerFunc func(listener events.EventListenerFunc, this js.Value, args []js.Value) interface{},
-	document js.Value, lastElement js.Value, currentNode nodes.Node) []string {
+func RecursivelyMount(delegator *events.Delegator,
+	document dom.Document, lastElement dom.Element, currentNode nodes.Node) []string {
+
+	return mountBefore(delegator, document, lastElement, nil, currentNode)
+}
+
+// mountBefore mounts the given tree like RecursivelyMount, but inserts its DOM nodes in lastElement before
+// the reference node rather than appending them. A nil reference appends.
+func mountBefore(delegator *events.Delegator,
+	document dom.Document, lastElement dom.Element, reference dom.Node, currentNode nodes.Node) []string {
 
 	if currentNode == nil {
 		return []string{}
 	}
 
-	add := false
+	var toAdd dom.Node
 	domElement := lastElement
+	// childReference is the node the children are inserted before, they are appended in their own element
+	childReference := reference
 	var styles []string
 	var children []nodes.Node
 
@@ -34,24 +43,30 @@ func RecursivelyMount(listenerFunc func(listener events.EventListenerFunc, this
 	switch typedNode := currentNode.(type) {
 	case *nodes.FuncNode:
 		// If the current node is a func node, we want to render it and keep going
-		// so we eventually hit a normal HTML node.
+		// so we eventually hit a normal HTML node. Values provided by the component are
+		// only visible to its descendants.
+		defer context.RestoreScope(context.CurrentScope())
 		context.RegisterComponent(typedNode)
 		context.RegisterComponentContext("mount", typedNode)
 		context.RegisterComponentContext("render", typedNode)
-		children = []nodes.Node{typedNode.Render(context.CurrentContext)}
+		children = []nodes.Node{renderComponent(typedNode, typedNode)}
 	case *nodes.FragmentNode:
 		children = typedNode.Children
+	case *nodes.PortalNode:
+		// The children of portals are appended to their container rather than to the DOM parent
+		if !mountPortal(delegator, document, lastElement, typedNode) {
+			return []string{}
+		}
+
+		domElement = typedNode.Container
+		childReference = nil
+		children = typedNode.Children
 	case *nodes.HTMLNode:
-		add = true
 		domElement = nodes.NewHTMLElement(document, typedNode)
+		childReference = nil
+		toAdd = domElement
 		typedNode.Mount(domElement)
-
-		for event, listener := range typedNode.EventListeners {
-			listener.Wrapper = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-				return listenerFunc(listener.Func, this, args)
-			})
-			domElement.Call("addEventListener", event, listener.Wrapper)
-		}
+		addEventListeners(delegator, typedNode)
 
 		children = typedNode.Children
 
@@ -59,9 +74,13 @@ func RecursivelyMount(listenerFunc func(listener events.EventListenerFunc, this
 			styles = append(styles, style)
 		}
 	case *nodes.TextNode:
-		add = true
-		domElement = document.Call("createTextNode", typedNode.Text)
-		typedNode.Mount(domElement)
+		textNode := document.CreateTextNode(typedNode.Text)
+		toAdd = textNode
+		typedNode.Mount(textNode)
+	case *nodes.RawHTMLNode:
+		container := document.CreateElement(typedNode.Tag)
+		toAdd = container
+		typedNode.Mount(container)
 	default:
 		return []string{}
 	}
@@ -71,15 +90,87 @@ func RecursivelyMount(listenerFunc func(listener events.EventListenerFunc, this
 			continue
 		}
 
-		childStyles := RecursivelyMount(listenerFunc, document, domElement, child)
+		childStyles := mountBefore(delegator, document, domElement, childReference, child)
 		for _, style := range childStyles {
 			styles = append(styles, style)
 		}
 	}
 
-	if add {
-		lastElement.Call("appendChild", domElement)
+	// Controlled form elements are synced once their children, such as the options of a select, are mounted
+	if htmlNode, ok := currentNode.(*nodes.HTMLNode); ok {
+		htmlNode.SyncControlled()
+	}
+
+	if toAdd != nil {
+		lastElement.InsertBefore(toAdd, reference)
 	}
 
 	return styles
 }
+
+// addEventListeners sets the event listeners of the given HTML node in the delegator, replacing any listeners
+// previously set for its DOM node.
+func addEventListeners(delegator *events.Delegator, node *nodes.HTMLNode) {
+	delegator.SetListeners(node.DomNode, node.EventListeners)
+}
+
+// mountPortal resolves the container of the given portal and registers it in the delegator, so the events
+// of its children bubble to the host, the closest DOM parent of the portal. Returns false if the target of
+// the portal could not be found, its children are not mounted.
+func mountPortal(delegator *events.Delegator, document dom.Document, host dom.Element, portal *nodes.PortalNode) bool {
+	container := portal.Resolve(document)
+	if container == nil {
+		internal.Debugf("Portal target %v could not be found, skipping its children\n", portal.Target)
+		return false
+	}
+
+	portal.Mount(container, delegator.AddPortal(container, host, func(node dom.Node) bool {
+		for _, child := range portal.Children {
+			for _, domNode := range domNodesOf(child) {
+				if domNode.IsSameNode(node) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}))
+
+	return true
+}
+
+// releaseNodes removes the event listeners of the DOM nodes of the given node and all its
+// descendants from the delegator, and clears their refs, once they were removed from the DOM. The children of
+// portals are removed from their container, which is never removed with the DOM nodes of the portal's parent.
+func releaseNodes(delegator *events.Delegator, node nodes.Node) {
+	switch typedNode := node.(type) {
+	case *nodes.HTMLNode:
+		if typedNode.DomNode != nil {
+			delegator.Release(typedNode.DomNode)
+		}
+		typedNode.ClearRef()
+
+		for _, child := range typedNode.Children {
+			releaseNodes(delegator, child)
+		}
+	case *nodes.FragmentNode:
+		for _, child := range typedNode.Children {
+			releaseNodes(delegator, child)
+		}
+	case *nodes.PortalNode:
+		if typedNode.Container != nil {
+			for _, child := range typedNode.Children {
+				for _, domNode := range domNodesOf(child) {
+					typedNode.Container.RemoveChild(domNode)
+				}
+			}
+		}
+
+		for _, child := range typedNode.Children {
+			releaseNodes(delegator, child)
+		}
+		typedNode.Release()
+	case *nodes.FuncNode:
+		releaseNodes(delegator, typedNode.RenderResult)
+	}
+}
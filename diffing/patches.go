@@ -1,12 +1,10 @@
-//go:build js && wasm
-
 package diffing
 
 import (
 	"strings"
-	"syscall/js"
 
 	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
 	"github.com/minivera/go-lander/events"
 	"github.com/minivera/go-lander/internal"
 	"github.com/minivera/go-lander/nodes"
@@ -22,7 +20,32 @@ import (
 // function. This slice contains the style of the encountered DOM elements if there is a need to generate
 // and insert new ones. These styles should be added to the head for the DOM elements to be properly styled.
 type Patch interface {
-	Execute(js.Value, *[]string) error
+	Execute(dom.Document, *[]string) error
+}
+
+// scopedPatch is a patch that renders components while executing, it keeps the scope it was created in.
+type scopedPatch interface {
+	Scope() *context.Scope
+}
+
+// ExecutePatches executes the patches in order. The errors of patches rendering components are sent to the
+// nearest error handler of the scope they were created in, the other patches are still executed. Returns
+// the first error no ancestor handled.
+func ExecutePatches(document dom.Document, patches []Patch, styles *[]string) error {
+	for _, patch := range patches {
+		err := patch.Execute(document, styles)
+		if err == nil {
+			continue
+		}
+
+		if scoped, ok := patch.(scopedPatch); ok && context.HandleError(scoped.Scope(), err) {
+			continue
+		}
+
+		return err
+	}
+
+	return nil
 }
 
 type patchText struct {
@@ -41,45 +64,102 @@ func newPatchText(parentNode nodes.Node, old *nodes.TextNode, text string) Patch
 
 // Execute executes the logic to patch a text node. This will trigger a node update, which
 // handles updating the DOM.
-func (p *patchText) Execute(_ js.Value, _ *[]string) error {
+func (p *patchText) Execute(_ dom.Document, _ *[]string) error {
 	internal.Debugf("Executing patch Text on %T, %v\n", p.oldNode, p.oldNode)
 	p.oldNode.Update(p.newText)
 
 	return nil
 }
 
+type patchRawHTML struct {
+	oldNode *nodes.RawHTMLNode
+	newHTML string
+}
+
+func newPatchRawHTML(old *nodes.RawHTMLNode, html string) Patch {
+	return &patchRawHTML{
+		oldNode: old,
+		newHTML: html,
+	}
+}
+
+// Execute executes the logic to patch a raw HTML node. This will trigger a node update, which
+// replaces the whole content of its DOM container.
+func (p *patchRawHTML) Execute(_ dom.Document, _ *[]string) error {
+	internal.Debugf("Executing patch raw HTML on %T, %v\n", p.oldNode, p.oldNode)
+	p.oldNode.Update(p.newHTML)
+
+	return nil
+}
+
+type patchComponent struct {
+	oldNode, newNode *nodes.FuncNode
+}
+
+func newPatchComponent(old, new *nodes.FuncNode) Patch {
+	return &patchComponent{
+		oldNode: old,
+		newNode: new,
+	}
+}
+
+// Execute executes the logic to patch a component node. The component has no DOM node, only the
+// component stored in the tree is updated with the new properties, so it can be rendered again on its own.
+func (p *patchComponent) Execute(_ dom.Document, _ *[]string) error {
+	internal.Debugf("Executing patch component on %T, %v\n", p.oldNode, p.oldNode)
+	p.oldNode.Update(p.newNode)
+
+	return nil
+}
+
 type patchHTML struct {
-	listenerFunc     func(listener events.EventListenerFunc, this js.Value, args []js.Value) interface{}
+	delegator        *events.Delegator
 	oldNode, newNode *nodes.HTMLNode
 }
 
 func newPatchHTML(
-	listenerFunc func(listener events.EventListenerFunc, this js.Value, args []js.Value) interface{},
+	delegator *events.Delegator,
 	old,
 	new *nodes.HTMLNode,
 ) Patch {
 	return &patchHTML{
-		listenerFunc: listenerFunc,
-		oldNode:      old,
-		newNode:      new,
+		delegator: delegator,
+		oldNode:   old,
+		newNode:   new,
 	}
 }
 
 // Execute executes the logic to patch an HTML node. This will trigger a node update, which
 // handles updating the DOM. The new DOM attributes will be generated from the attributes saved
-// on the previous virtual DOM node. Event listeners will be released to avoid memory and closure
-// issues, then readded with the listenerFunc given to the patch.
-func (p *patchHTML) Execute(_ js.Value, _ *[]string) error {
+// on the previous virtual DOM node. The event listeners are replaced in the delegator given to the patch.
+func (p *patchHTML) Execute(_ dom.Document, _ *[]string) error {
 	internal.Debugf("Executing patch HTML on %T, %v\n", p.oldNode, p.oldNode)
-	newAttributes := make(map[string]interface{}, len(p.newNode.Attributes)+len(p.newNode.EventListeners)+2)
+	newAttributes := make(map[string]interface{}, len(p.newNode.Attributes)+len(p.newNode.EventListeners)+4)
 
-	// Run only on the properties since they retain their original type prior to extraction
+	// Run on the properties since they retain their original type prior to extraction, values that were
+	// only extracted as an attribute or a property are forced to keep them that way.
 	for key, value := range p.newNode.Properties {
-		newAttributes[key] = value
+		if _, ok := p.newNode.Attributes[key]; ok {
+			newAttributes[key] = value
+		} else {
+			newAttributes[key] = nodes.AsProperty(value)
+		}
+	}
+	for key, value := range p.newNode.Attributes {
+		if _, ok := p.newNode.Properties[key]; !ok {
+			newAttributes[key] = nodes.AsAttribute(value)
+		}
+	}
+	if p.newNode.InlineStyle != nil {
+		newAttributes[nodes.StyleAttribute] = p.newNode.InlineStyle
+	}
+	if p.newNode.Ref != nil {
+		newAttributes[nodes.RefAttribute] = p.newNode.Ref
 	}
 
+	// Keep the listeners as is, so their options are kept
 	for key, value := range p.newNode.EventListeners {
-		newAttributes[key] = value.Func
+		newAttributes[key] = value
 	}
 
 	if p.newNode.DomID != "" {
@@ -89,118 +169,122 @@ func (p *patchHTML) Execute(_ js.Value, _ *[]string) error {
 		newAttributes["class"] = strings.Join(p.newNode.Classes, " ")
 	}
 
-	// Remove any event listeners to avoid old closures leaking into them
-	for event, listener := range p.oldNode.EventListeners {
-		p.oldNode.DomNode.Call("removeEventListener", event, listener.Wrapper)
-		listener.Wrapper.Release()
+	// The active class is not part of the classes, remove it here if it changed
+	if p.oldNode.ActiveClass != "" && p.oldNode.ActiveClass != p.newNode.ActiveClass {
+		p.oldNode.DomNode.RemoveClass(p.oldNode.ActiveClass)
 	}
 
 	p.oldNode.Update(newAttributes)
 
-	// Add new event listeners using the attributes
-	for event, listener := range p.oldNode.EventListeners {
-		listener.Wrapper = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			return p.listenerFunc(listener.Func, this, args)
-		})
-		p.oldNode.DomNode.Call("addEventListener", event, listener.Wrapper)
-	}
+	// Replace the event listeners using the attributes
+	addEventListeners(p.delegator, p.oldNode)
 
 	// Update the active class with the new value, replace the styles
 	p.oldNode.ActiveClass = p.newNode.ActiveClass
 	p.oldNode.Styles = p.newNode.Styles
 
-	classList := p.oldNode.DomNode.Get("classList")
 	if p.oldNode.ActiveClass != "" {
-		classList.Call("add", p.oldNode.ActiveClass)
+		p.oldNode.DomNode.AddClass(p.oldNode.ActiveClass)
 	}
 
 	return nil
 }
 
 type patchListeners struct {
-	listenerFunc func(listener events.EventListenerFunc, this js.Value, args []js.Value) interface{}
-	oldNode      *nodes.HTMLNode
+	delegator *events.Delegator
+	oldNode   *nodes.HTMLNode
+	newNode   *nodes.HTMLNode
 }
 
 func newPatchListeners(
-	listenerFunc func(listener events.EventListenerFunc, this js.Value, args []js.Value) interface{},
+	delegator *events.Delegator,
 	old *nodes.HTMLNode,
+	new *nodes.HTMLNode,
 ) Patch {
 	return &patchListeners{
-		listenerFunc: listenerFunc,
-		oldNode:      old,
+		delegator: delegator,
+		oldNode:   old,
+		newNode:   new,
 	}
 }
 
 // Execute executes the logic to patch the listeners of an HTML node. This is a utility patch
 // that should run on all HTML nodes that make sure event listeners are always up-to-date and no
-// closure issue can happen due to outdated variable states.
-func (p *patchListeners) Execute(_ js.Value, _ *[]string) error {
+// closure issue can happen due to outdated variable states. Listeners are delegated, this only
+// replaces them in the delegator and never touches the DOM. The options of the listeners are
+// updated with them. The ref of the node is also replaced, as refs are not diffed.
+func (p *patchListeners) Execute(_ dom.Document, _ *[]string) error {
 	internal.Debugf("Executing patch listeners on %T, %v\n", p.oldNode, p.oldNode)
-	// Remove any event listeners to avoid old closures leaking into them
-	for event, listener := range p.oldNode.EventListeners {
-		p.oldNode.DomNode.Call("removeEventListener", event, listener.Wrapper)
-		listener.Wrapper.Release()
-	}
+	p.oldNode.EventListeners = p.newNode.EventListeners
+	addEventListeners(p.delegator, p.oldNode)
+	p.oldNode.SetRef(p.newNode.Ref)
+
+	return nil
+}
+
+type patchControlled struct {
+	node *nodes.HTMLNode
+}
 
-	// Add new event listeners using the attributes
-	for event, listener := range p.oldNode.EventListeners {
-		listener.Wrapper = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			return p.listenerFunc(listener.Func, this, args)
-		})
-		p.oldNode.DomNode.Call("addEventListener", event, listener.Wrapper)
+func newPatchControlled(node *nodes.HTMLNode) Patch {
+	return &patchControlled{
+		node: node,
 	}
+}
+
+// Execute executes the logic to sync a controlled form element with the DOM. It runs after the children of
+// the element were patched, and only sets the value, checked and selectedIndex properties that differ from
+// the DOM. See nodes.HTMLNode.SyncControlled.
+func (p *patchControlled) Execute(_ dom.Document, _ *[]string) error {
+	internal.Debugf("Executing patch controlled on %T, %v\n", p.node, p.node)
+	p.node.SyncControlled()
 
 	return nil
 }
 
 type patchInsert struct {
-	listenerFunc        func(listener events.EventListenerFunc, this js.Value, args []js.Value) interface{}
-	closestDOMParent    js.Value
-	positionInDOMParent int
-	parent, newNode     nodes.Node
+	delegator        *events.Delegator
+	closestDOMParent dom.Element
+	slot             int
+	next             Anchor
+	parent, newNode  nodes.Node
+	scope            *context.Scope
 }
 
 func newPatchInsert(
-	listenerFunc func(listener events.EventListenerFunc, this js.Value, args []js.Value) interface{},
-	closestDOMParent js.Value,
+	delegator *events.Delegator,
+	closestDOMParent dom.Element,
+	slot int,
+	next Anchor,
 	parent,
 	new nodes.Node,
 ) Patch {
 	return &patchInsert{
-		listenerFunc:        listenerFunc,
-		closestDOMParent:    closestDOMParent,
-		positionInDOMParent: -1,
-		parent:              parent,
-		newNode:             new,
+		delegator:        delegator,
+		closestDOMParent: closestDOMParent,
+		slot:             slot,
+		next:             next,
+		parent:           parent,
+		newNode:          new,
+		scope:            context.CurrentScope(),
 	}
 }
 
-func newPatchInsertAt(
-	listenerFunc func(listener events.EventListenerFunc, this js.Value, args []js.Value) interface{},
-	closestDOMParent js.Value,
-	positionInDOMParent int,
-	parent,
-	new nodes.Node,
-) Patch {
-	return &patchInsert{
-		listenerFunc:        listenerFunc,
-		closestDOMParent:    closestDOMParent,
-		positionInDOMParent: positionInDOMParent,
-		parent:              parent,
-		newNode:             new,
-	}
+// Scope returns the scope of values that was current when the patch was generated.
+func (p *patchInsert) Scope() *context.Scope {
+	return p.scope
 }
 
-// Execute executes the logic to insert new nodes at specific positions inside a parent. This patch
-// may be recursive and could call other patches to handles fragments or components. The patch is
-// built to handle all possible types of parents and all the children they may contain, but it makes
-// some assumptions that could lead to bugs.
+// Execute executes the logic to insert new nodes at specific positions inside a parent. The new node takes
+// its slot in the virtual parent's children, and its DOM nodes are inserted before the DOM node resolved by
+// the patch's anchor, or appended if there is none. Fragments and components are mounted recursively.
 //
-// The patch is configured to handle both appending at the end of the parent and inserting at a specific
-// position using insertBefore.
-func (p *patchInsert) Execute(document js.Value, styles *[]string) error {
+// Components are rendered with the scope of values that was current when the patch was generated.
+func (p *patchInsert) Execute(document dom.Document, styles *[]string) error {
 	internal.Debugf("Executing patch insert on %T, %v\n", p.newNode, p.newNode)
+	defer context.RestoreScope(context.CurrentScope())
+	context.RestoreScope(p.scope)
+
 	internal.Debugf("Parent is %T, %v\n", p.parent, p.parent)
 	switch parent := p.parent.(type) {
 	case *nodes.FuncNode:
@@ -208,17 +292,15 @@ func (p *patchInsert) Execute(document js.Value, styles *[]string) error {
 
 		return p.insertChild(document, styles, p.closestDOMParent)
 	case *nodes.FragmentNode:
-		err := parent.InsertChildren(p.newNode, -1)
-		if err != nil {
-			return err
-		}
+		parent.Children = placeChild(parent.Children, p.slot, p.newNode)
 
 		return p.insertChild(document, styles, p.closestDOMParent)
+	case *nodes.PortalNode:
+		parent.Children = placeChild(parent.Children, p.slot, p.newNode)
+
+		return p.insertChild(document, styles, parent.Container)
 	case *nodes.HTMLNode:
-		err := parent.InsertChildren(p.newNode, -1)
-		if err != nil {
-			return err
-		}
+		parent.Children = placeChild(parent.Children, p.slot, p.newNode)
 
 		return p.insertChild(document, styles, parent.DomNode)
 	default:
@@ -227,97 +309,47 @@ func (p *patchInsert) Execute(document js.Value, styles *[]string) error {
 	}
 }
 
-func (p *patchInsert) insertChild(document js.Value, styles *[]string, parentDOMNode js.Value) error {
-	var domElement js.Value
-	toAdd := false
-	switch typedNode := p.newNode.(type) {
-	case *nodes.HTMLNode:
-		toAdd = true
-		domElement = nodes.NewHTMLElement(document, typedNode)
-		typedNode.Mount(domElement)
-
-		// Trigger a recursive mount for all its children
-		for _, child := range typedNode.Children {
-			if child == nil {
-				continue
-			}
-
-			childStyles := RecursivelyMount(p.listenerFunc, document, domElement, child)
-
-			for _, style := range childStyles {
-				*styles = append(*styles, style)
-			}
-		}
-	case *nodes.TextNode:
-		toAdd = true
-		domElement = document.Call("createTextNode", typedNode.Text)
-		typedNode.Mount(domElement)
-	case *nodes.FuncNode:
-		context.RegisterComponent(typedNode)
-		context.RegisterComponentContext("render", typedNode)
-		context.RegisterComponentContext("mount", typedNode)
-
-		return newPatchInsert(p.listenerFunc, parentDOMNode, typedNode, typedNode.Clone().Render(context.CurrentContext)).
-			Execute(document, styles)
-	case *nodes.FragmentNode:
-		// Trigger a recursive mount for all its children
-		for _, child := range typedNode.Children {
-			if child == nil {
-				continue
-			}
-
-			childStyles := RecursivelyMount(p.listenerFunc, document, p.closestDOMParent, child)
-
-			for _, style := range childStyles {
-				*styles = append(*styles, style)
-			}
-		}
-	default:
-		// Ignore anything that's not dom related
-		return nil
-	}
-
-	if toAdd {
-		childCount := parentDOMNode.Get("children").Length()
-		if p.positionInDOMParent >= 0 && p.positionInDOMParent < childCount {
-			parentDOMNode.Call("insertBefore", domElement, parentDOMNode.Get("children").Index(p.positionInDOMParent))
-		} else {
-			parentDOMNode.Call("appendChild", domElement)
-		}
-	}
+func (p *patchInsert) insertChild(document dom.Document, styles *[]string, parentDOMNode dom.Element) error {
+	*styles = append(*styles, mountBefore(p.delegator, document, parentDOMNode, p.next.Node(), p.newNode)...)
 
 	return nil
 }
 
 type patchRemove struct {
-	closestDOMParent js.Value
+	delegator        *events.Delegator
+	closestDOMParent dom.Element
 	parent, oldNode  nodes.Node
 }
 
-func newPatchRemove(parent nodes.Node, closestDOMParent js.Value, old nodes.Node) Patch {
+func newPatchRemove(delegator *events.Delegator, parent nodes.Node, closestDOMParent dom.Element, old nodes.Node) Patch {
 	return &patchRemove{
+		delegator:        delegator,
 		parent:           parent,
 		closestDOMParent: closestDOMParent,
 		oldNode:          old,
 	}
 }
 
-// Execute executes the logic to remove an existing nodes at specific positions inside a parent. This
-// patch may be recursive and could call other patches to handles fragments or components. The patch is
-// built to handle all possible types of parents and all the children they may contain, but it makes
-// some assumptions that could lead to bugs.
-func (p *patchRemove) Execute(document js.Value, styles *[]string) error {
+// Execute executes the logic to remove an existing node from its parent. The node leaves an empty slot in the
+// virtual parent's children, and all the DOM nodes it rendered, through fragments and components included,
+// are removed from the closest DOM parent.
+func (p *patchRemove) Execute(_ dom.Document, _ *[]string) error {
 	internal.Debugf("Executing patch remove on %T, %v\n", p.oldNode, p.oldNode)
 	switch typedNode := p.parent.(type) {
 	case *nodes.FuncNode:
 		typedNode.RenderResult = nil
 	case *nodes.HTMLNode:
-		err := typedNode.RemoveChildren(p.oldNode)
+		err := typedNode.ReplaceChildren(p.oldNode, nil)
 		if err != nil {
 			return err
 		}
 	case *nodes.FragmentNode:
-		err := typedNode.RemoveChildren(p.oldNode)
+		err := typedNode.ReplaceChildren(p.oldNode, nil)
+		if err != nil {
+			return err
+		}
+	case *nodes.PortalNode:
+		err := typedNode.ReplaceChildren(p.oldNode, nil)
 		if err != nil {
 			return err
 		}
@@ -326,76 +358,58 @@ func (p *patchRemove) Execute(document js.Value, styles *[]string) error {
 		return nil
 	}
 
-	switch typedNode := p.oldNode.(type) {
-	case *nodes.HTMLNode:
-		p.closestDOMParent.Call("removeChild", typedNode.DomNode)
-	case *nodes.TextNode:
-		p.closestDOMParent.Call("removeChild", typedNode.DomNode)
-	case *nodes.FuncNode:
-		return newPatchRemove(typedNode, p.closestDOMParent, typedNode.RenderResult).Execute(document, styles)
-	case *nodes.FragmentNode:
-		// Recursively remove all its children
-		for _, child := range typedNode.Children {
-			if child == nil {
-				continue
-			}
-
-			err := newPatchRemove(typedNode, p.closestDOMParent, child).Execute(document, styles)
-			if err != nil {
-				return err
-			}
-		}
-	}
+	removeDOMNodes(p.delegator, p.closestDOMParent, p.oldNode)
 
 	return nil
 }
 
 type patchReplace struct {
-	listenerFunc             func(listener events.EventListenerFunc, this js.Value, args []js.Value) interface{}
-	closestDOMParent         js.Value
-	positionInDOMParent      int
+	delegator                *events.Delegator
+	closestDOMParent         dom.Element
+	next                     Anchor
 	parent, newNode, oldNode nodes.Node
+	scope                    *context.Scope
 }
 
 func newPatchReplace(
-	listenerFunc func(listener events.EventListenerFunc, this js.Value, args []js.Value) interface{},
-	closestDOMParent js.Value,
-	positionInDOMParent int,
+	delegator *events.Delegator,
+	closestDOMParent dom.Element,
+	next Anchor,
 	parent,
 	old,
 	new nodes.Node,
 ) Patch {
 	return &patchReplace{
-		listenerFunc:        listenerFunc,
-		closestDOMParent:    closestDOMParent,
-		positionInDOMParent: positionInDOMParent,
-		parent:              parent,
-		newNode:             new,
-		oldNode:             old,
+		delegator:        delegator,
+		closestDOMParent: closestDOMParent,
+		next:             next,
+		parent:           parent,
+		newNode:          new,
+		oldNode:          old,
+		scope:            context.CurrentScope(),
 	}
 }
 
-// Execute executes the logic to replace an existing nodes at specific positions inside a parent with a
-// new node. This patch may be recursive and could call other patches to handles fragments or components.
-// The patch is built to handle all possible types of parents and all the children they may contain, but
-// it makes some assumptions that could lead to bugs.
-func (p *patchReplace) Execute(document js.Value, styles *[]string) error {
+// Scope returns the scope of values that was current when the patch was generated.
+func (p *patchReplace) Scope() *context.Scope {
+	return p.scope
+}
+
+// Execute executes the logic to replace an existing node with a new node inside a parent. The new node is
+// mounted before the first DOM node of the old node, or before the DOM node resolved by the patch's anchor
+// if the old node rendered no DOM node, then the DOM nodes of the old node are removed.
+//
+// Components are rendered with the scope of values that was current when the patch was generated.
+func (p *patchReplace) Execute(document dom.Document, styles *[]string) error {
 	internal.Debugf("Executing patch replace on %T, %v\n", p.oldNode, p.oldNode)
+	defer context.RestoreScope(context.CurrentScope())
+	context.RestoreScope(p.scope)
+
 	switch parent := p.parent.(type) {
 	case *nodes.FuncNode:
 		parent.RenderResult = p.newNode
 
-		if oldConverted, ok := p.oldNode.(*nodes.HTMLNode); ok {
-			// If the old node was an HTML node, remove it, so we can mount from fresh
-			p.closestDOMParent.Call("removeChild", oldConverted.DomNode)
-		}
-
-		// Trigger a recursive mount for its render result
-		childStyles := RecursivelyMount(p.listenerFunc, document, p.closestDOMParent, parent.RenderResult)
-
-		for _, style := range childStyles {
-			*styles = append(*styles, style)
-		}
+		return p.replaceChild(document, styles, p.closestDOMParent)
 	case *nodes.FragmentNode:
 		err := parent.ReplaceChildren(p.oldNode, p.newNode)
 		if err != nil {
@@ -403,6 +417,13 @@ func (p *patchReplace) Execute(document js.Value, styles *[]string) error {
 		}
 
 		return p.replaceChild(document, styles, p.closestDOMParent)
+	case *nodes.PortalNode:
+		err := parent.ReplaceChildren(p.oldNode, p.newNode)
+		if err != nil {
+			return err
+		}
+
+		return p.replaceChild(document, styles, parent.Container)
 	case *nodes.HTMLNode:
 		err := parent.ReplaceChildren(p.oldNode, p.newNode)
 		if err != nil {
@@ -414,141 +435,103 @@ func (p *patchReplace) Execute(document js.Value, styles *[]string) error {
 		// Ignore anything that's not dom related
 		return nil
 	}
-
-	return nil
 }
 
-func (p *patchReplace) replaceChild(document js.Value, styles *[]string, parentDOMNode js.Value) error {
-	var oldDomNode js.Value
-	// If we're replacing HTML nodes against HTML nodes, then we'll have an easy time.
-	// Simply replace directly
-	switch converted := p.oldNode.(type) {
-	case *nodes.HTMLNode:
-		oldDomNode = converted.DomNode
-	case *nodes.TextNode:
-		oldDomNode = converted.DomNode
-	case *nodes.FuncNode:
-		// If the render result is nil, this means we have to insert the new node
-		// in the HTML where this old node would be. Trigger an insert.
-		if converted.RenderResult == nil {
-			return (&patchInsert{
-				listenerFunc:        p.listenerFunc,
-				closestDOMParent:    p.closestDOMParent,
-				positionInDOMParent: p.positionInDOMParent,
-				parent:              p.parent,
-				newNode:             p.newNode,
-			}).insertChild(document, styles, p.closestDOMParent)
-		}
+func (p *patchReplace) replaceChild(document dom.Document, styles *[]string, parentDOMNode dom.Element) error {
+	// Fragments, portals and components rendering nil may have no DOM node, use the position of the old node
+	reference := p.next.Node()
+	if domNodes := domNodesOf(p.oldNode); len(domNodes) > 0 {
+		reference = domNodes[0]
+	}
 
-		// If we hit a func node, trigger again on this func node's render result as the old
-		// node, this should make sure we will, at some point, hit the root HTML element
-		// of this node.
-		return newPatchReplace(
-			p.listenerFunc,
-			p.closestDOMParent,
-			p.positionInDOMParent,
-			p.parent,
-			converted.RenderResult,
-			p.newNode,
-		).Execute(document, styles)
-	case *nodes.FragmentNode:
-		// If we hit a fragment node, things get a bit more complex. Trigger on the first children
-		// so we replace it and remove all other children. The fragment should not leak into the DOM
-		// once replaced.
-		err := newPatchReplace(
-			p.listenerFunc,
-			p.closestDOMParent,
-			p.positionInDOMParent,
-			p.parent,
-			converted.Children[0],
-			p.newNode,
-		).Execute(document, styles)
-		if err != nil {
-			return err
-		}
+	*styles = append(*styles, mountBefore(p.delegator, document, parentDOMNode, reference, p.newNode)...)
+	removeDOMNodes(p.delegator, parentDOMNode, p.oldNode)
 
-		for _, child := range converted.Children[1:] {
-			err = newPatchRemove(
-				p.parent,
-				p.closestDOMParent,
-				child,
-			).Execute(document, styles)
-			if err != nil {
-				return err
-			}
-		}
+	return nil
+}
 
-		return nil
+type patchMove struct {
+	closestDOMParent dom.Element
+	next             Anchor
+	parent           nodes.Node
+	oldChildren      []nodes.Node
+	oldIndexes       []int
+	newChildren      []nodes.Node
+}
+
+func newPatchMove(
+	parent nodes.Node,
+	closestDOMParent dom.Element,
+	next Anchor,
+	oldChildren []nodes.Node,
+	oldIndexes []int,
+	newChildren []nodes.Node,
+) Patch {
+	return &patchMove{
+		closestDOMParent: closestDOMParent,
+		next:             next,
+		parent:           parent,
+		oldChildren:      oldChildren,
+		oldIndexes:       oldIndexes,
+		newChildren:      newChildren,
 	}
+}
 
-	switch typedNode := p.newNode.(type) {
+// Execute executes the logic to move keyed children in their new order, both in the virtual and real
+// DOM. This patch must run after all the other patches of the children have been executed. oldIndexes
+// gives, for each of the new children, the index of the old child it was matched with, or -1 if it was
+// inserted. Matched children that are part of the longest increasing sequence of old indexes keep their
+// DOM position, only the other children are moved with insertBefore.
+func (p *patchMove) Execute(_ dom.Document, _ *[]string) error {
+	internal.Debugf("Executing patch move on %T, %v\n", p.parent, p.parent)
+	var currentChildren []nodes.Node
+	switch parent := p.parent.(type) {
 	case *nodes.HTMLNode:
-		domElement := nodes.NewHTMLElement(document, typedNode)
-		typedNode.Mount(domElement)
-
-		// Trigger a recursive mount for all its children
-		for _, child := range typedNode.Children {
-			if child == nil {
-				continue
-			}
+		currentChildren = parent.Children
+	case *nodes.FragmentNode:
+		currentChildren = parent.Children
+	case *nodes.PortalNode:
+		currentChildren = parent.Children
+	default:
+		// Ignore anything that's not dom related
+		return nil
+	}
 
-			childStyles := RecursivelyMount(p.listenerFunc, document, domElement, child)
+	present := make(map[nodes.Node]bool, len(currentChildren))
+	for _, child := range currentChildren {
+		present[child] = true
+	}
 
-			for _, style := range childStyles {
-				*styles = append(*styles, style)
-			}
+	// Matched old nodes were patched in place, unless they were replaced by the new node
+	finalChildren := make([]nodes.Node, len(p.newChildren))
+	for index, child := range p.newChildren {
+		finalChildren[index] = child
+		if p.oldIndexes[index] >= 0 && present[p.oldChildren[p.oldIndexes[index]]] {
+			finalChildren[index] = p.oldChildren[p.oldIndexes[index]]
 		}
+	}
 
-		parentDOMNode.Call("replaceChild", domElement, oldDomNode)
-	case *nodes.TextNode:
-		domElement := document.Call("createTextNode", typedNode.Text)
-		typedNode.Mount(domElement)
-
-		parentDOMNode.Call("replaceChild", domElement, oldDomNode)
-	case *nodes.FuncNode:
-		context.RegisterComponent(typedNode)
-		context.RegisterComponentContext("render", typedNode)
-		context.RegisterComponentContext("mount", typedNode)
-
-		return newPatchReplace(
-			p.listenerFunc,
-			parentDOMNode,
-			-1,
-			typedNode,
-			p.oldNode,
-			typedNode.Clone().Render(context.CurrentContext),
-		).
-			Execute(document, styles)
+	switch parent := p.parent.(type) {
+	case *nodes.HTMLNode:
+		parent.Children = finalChildren
 	case *nodes.FragmentNode:
-		if len(typedNode.Children) < 1 {
-			return nil
-		}
-
-		// Replace the last node with the first children of the fragment
-		err := newPatchReplace(
-			p.listenerFunc,
-			parentDOMNode,
-			-1,
-			typedNode,
-			p.oldNode,
-			typedNode.Children[0],
-		).
-			Execute(document, styles)
-		if err != nil {
-			return err
-		}
+		parent.Children = finalChildren
+	case *nodes.PortalNode:
+		parent.Children = finalChildren
+	}
 
-		// Trigger a recursive mount for all its remaining children
-		for _, child := range typedNode.Children[1:] {
-			if child == nil {
-				continue
+	stable := longestIncreasingSubsequence(p.oldIndexes)
+	reference := p.next.Node()
+	for index := len(finalChildren) - 1; index >= 0; index-- {
+		domNodes := domNodesOf(finalChildren[index])
+		if !stable[index] {
+			for _, domNode := range domNodes {
+				p.closestDOMParent.InsertBefore(domNode, reference)
 			}
+		}
 
-			childStyles := RecursivelyMount(p.listenerFunc, document, p.closestDOMParent, child)
-
-			for _, style := range childStyles {
-				*styles = append(*styles, style)
-			}
+		if len(domNodes) > 0 {
+			reference = domNodes[0]
 		}
 	}
 
@@ -0,0 +1,48 @@
+package diffing
+
+import (
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/internal"
+	"github.com/minivera/go-lander/nodes"
+)
+
+// RecursivelyRender recursively renders the components of the given tree from currentNode without mounting
+// anything to a document. Once rendered, the tree can be converted to HTML using ToString. Lifecycle listeners
+// are never registered for the rendered components, as the tree is never mounted.
+//
+// The function returns a slice of style strings from the encountered HTML nodes. This slice should be added
+// in a style tag in the page's head for elements to be properly styled.
+func RecursivelyRender(currentNode nodes.Node) []string {
+	if currentNode == nil {
+		return []string{}
+	}
+
+	var styles []string
+	var children []nodes.Node
+
+	internal.Debugf("Rendering %T node, %v\n", currentNode, currentNode)
+	switch typedNode := currentNode.(type) {
+	case *nodes.FuncNode:
+		// Values provided by the component are only visible to its descendants
+		defer context.RestoreScope(context.CurrentScope())
+		context.RegisterComponent(typedNode)
+		children = []nodes.Node{renderComponent(typedNode, typedNode)}
+	case *nodes.FragmentNode:
+		children = typedNode.Children
+	case *nodes.HTMLNode:
+		children = typedNode.Children
+		styles = append(styles, typedNode.Styles...)
+	default:
+		return []string{}
+	}
+
+	for _, child := range children {
+		if child == nil {
+			continue
+		}
+
+		styles = append(styles, RecursivelyRender(child)...)
+	}
+
+	return styles
+}
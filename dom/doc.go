@@ -0,0 +1,5 @@
+// Package dom defines the set of interfaces lander uses to mount and patch the real DOM. Two
+// implementations are provided: one backed by `syscall/js` for the browser, and an in-memory
+// implementation that can run outside of WASM, which is useful to test the diffing and mounting
+// algorithms with the standard Go tooling.
+package dom
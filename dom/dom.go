@@ -0,0 +1,226 @@
+package dom
+
+// Node is the generic interface for any node of a DOM tree, be it an element or a text node. All the
+// implementations of the DOM interfaces must be able to compare their nodes through IsSameNode, as
+// two different values may point to the same underlying DOM node.
+type Node interface {
+	// ParentNode returns the element this node is attached to, or nil if the node is not attached.
+	ParentNode() Element
+
+	// NextSibling returns the node immediately following this node in its parent's child nodes, or
+	// nil if this node is the last node or is not attached. Nodes that are neither elements nor text, such
+	// as comments, are skipped.
+	NextSibling() Node
+
+	// IsSameNode returns true if both nodes reference the same underlying DOM node.
+	IsSameNode(other Node) bool
+}
+
+// Listener is a handle to an event listener registered on an element. It is returned when adding an
+// event listener and must be given back to remove it. Release must be called once the listener is no
+// longer needed to free any resources associated with it.
+type Listener interface {
+	// Release frees the resources associated with this listener. The listener cannot be used once
+	// released.
+	Release()
+}
+
+// ListenerOptions configures how an event listener is registered on an element.
+type ListenerOptions struct {
+	// Capture registers the listener for the capture phase, it is called before the listeners of the
+	// descendants of the element, like `addEventListener(type, listener, {capture: true})`.
+	Capture bool
+	// Passive tells the browser the listener never prevents the default behavior of the event, so it can,
+	// for example, scroll without waiting for the listener.
+	Passive bool
+}
+
+// EventHandler is the type definition for the Go function executed when an event is dispatched
+// to an element.
+type EventHandler func(event Event)
+
+// Element is the interface for a DOM element, such as a `div`. It defines all the operations lander
+// needs to mount and patch an element.
+type Element interface {
+	Node
+
+	// TagName returns the lowercase tag name of the element, such as "div".
+	TagName() string
+
+	// GetAttribute returns the value of the attribute under the given name and true if it exists.
+	GetAttribute(name string) (string, bool)
+	// SetAttribute sets the attribute under the given name, like `setAttribute`.
+	SetAttribute(name, value string)
+	// RemoveAttribute removes the attribute under the given name, like `removeAttribute`.
+	RemoveAttribute(name string)
+	// AttributeNames returns the names of all the attributes of the element, like `getAttributeNames`.
+	AttributeNames() []string
+
+	// GetProperty returns the value of the object property under the given name, or nil if unset.
+	GetProperty(name string) interface{}
+	// SetProperty sets the given value as an object property on the element.
+	SetProperty(name string, value interface{})
+	// DeleteProperty deletes the object property under the given name.
+	DeleteProperty(name string)
+
+	// ClassList returns the list of classes currently assigned on the element.
+	ClassList() []string
+	// AddClass adds the given class to the element's class list.
+	AddClass(class string)
+	// RemoveClass removes the given class from the element's class list.
+	RemoveClass(class string)
+
+	// GetStyleProperty returns the value of the given inline style property, such as "width", or an empty
+	// string if unset.
+	GetStyleProperty(name string) string
+	// SetStyleProperty sets the given inline style property, like `style.setProperty`. The value may end
+	// with "!important".
+	SetStyleProperty(name, value string)
+	// RemoveStyleProperty removes the given inline style property, like `style.removeProperty`.
+	RemoveStyleProperty(name string)
+
+	// AddEventListener registers the handler for the given event type with the given options and returns
+	// the listener handle, which can be used to remove it.
+	AddEventListener(eventType string, handler EventHandler, options ListenerOptions) Listener
+	// RemoveEventListener removes a listener previously returned by AddEventListener.
+	RemoveEventListener(eventType string, listener Listener)
+
+	// ChildNodes returns all the child nodes of this element, text nodes included. Nodes that are neither
+	// elements nor text, such as comments, are left out.
+	ChildNodes() []Node
+	// Children returns the child elements of this element, text nodes excluded.
+	Children() []Element
+	// AppendChild appends the given node at the end of this element's child nodes.
+	AppendChild(child Node)
+	// InsertBefore inserts the given node before the reference node in this element's child nodes.
+	// The node is appended if the reference is nil. Panics if the reference is not a child of this element.
+	InsertBefore(child, reference Node)
+	// RemoveChild removes the given node from this element's child nodes. Panics if the node is not a child
+	// of this element.
+	RemoveChild(child Node)
+	// ReplaceChild replaces the old child node with the new node in this element's child nodes.
+	ReplaceChild(newChild, oldChild Node)
+
+	// SetInnerHTML replaces the content of the element with the given HTML string.
+	SetInnerHTML(html string)
+
+	// QuerySelector returns the first descendant of this element matching the selector, or nil.
+	QuerySelector(selector string) Element
+}
+
+// Text is the interface for a DOM text node.
+type Text interface {
+	Node
+
+	// NodeValue returns the text content of the text node.
+	NodeValue() string
+	// SetNodeValue replaces the text content of the text node.
+	SetNodeValue(value string)
+}
+
+// Event is the interface for a DOM event dispatched to an element's event listeners.
+type Event interface {
+	// Type returns the event type, such as "click".
+	Type() string
+	// Target returns the node the event was dispatched to.
+	Target() Node
+	// CurrentTarget returns the element whose listener is currently being executed.
+	CurrentTarget() Node
+	// PreventDefault prevents the default browser behavior for this event.
+	PreventDefault()
+	// StopPropagation stops the event from propagating further in the tree.
+	StopPropagation()
+	// StopImmediatePropagation stops the event from propagating further in the tree, the listeners of the
+	// current target that were not called yet are skipped.
+	StopImmediatePropagation()
+	// GetProperty returns the value of the event property under the given name, such as "key" or "clientX",
+	// or nil if unset. Values are converted like Element.GetProperty, nodes are returned as Node, file lists
+	// as []File and data transfers as *DataTransfer.
+	GetProperty(name string) interface{}
+}
+
+// File describes a file selected in a file input or dropped on an element, like the `File` object.
+type File struct {
+	// Name is the name of the file, without its path.
+	Name string
+	// Type is the MIME type of the file, if known.
+	Type string
+	// Size is the size of the file in bytes.
+	Size int64
+
+	// value is the underlying value of the DOM implementation, used to read the file content.
+	value interface{}
+}
+
+// DataTransfer holds the data of a drag and drop operation, like the `DataTransfer` object. It is a copy
+// of the data at the time the event was dispatched.
+type DataTransfer struct {
+	// DropEffect is the effect of the drop operation, such as "copy" or "move".
+	DropEffect string
+	// EffectAllowed is the set of effects allowed for the operation, such as "copyMove".
+	EffectAllowed string
+	// Types lists the formats of the data being dragged, such as "text/plain".
+	Types []string
+	// Data maps the formats of the data being dragged to their value. The browser only gives access to the
+	// data while dropping.
+	Data map[string]string
+	// Files lists the files being dragged, if any.
+	Files []File
+}
+
+// Document is the interface for a DOM document, which is used to create and query nodes.
+type Document interface {
+	// CreateElement creates a new element with the given tag.
+	CreateElement(tag string) Element
+	// CreateElementNS creates a new element with the given namespace and tag, such as SVG elements.
+	CreateElementNS(namespace, tag string) Element
+	// CreateTextNode creates a new text node with the given text.
+	CreateTextNode(text string) Text
+	// QuerySelector returns the first element of the document matching the selector, or nil.
+	QuerySelector(selector string) Element
+}
+
+// nonBubblingEvents is the set of the common DOM events that do not bubble up to the ancestors of their target.
+var nonBubblingEvents = map[string]bool{
+	"abort":          true,
+	"blur":           true,
+	"cancel":         true,
+	"canplay":        true,
+	"canplaythrough": true,
+	"close":          true,
+	"durationchange": true,
+	"emptied":        true,
+	"ended":          true,
+	"error":          true,
+	"focus":          true,
+	"invalid":        true,
+	"load":           true,
+	"loadeddata":     true,
+	"loadedmetadata": true,
+	"loadstart":      true,
+	"mouseenter":     true,
+	"mouseleave":     true,
+	"pause":          true,
+	"play":           true,
+	"playing":        true,
+	"pointerenter":   true,
+	"pointerleave":   true,
+	"progress":       true,
+	"ratechange":     true,
+	"scroll":         true,
+	"seeked":         true,
+	"seeking":        true,
+	"stalled":        true,
+	"suspend":        true,
+	"timeupdate":     true,
+	"toggle":         true,
+	"volumechange":   true,
+	"waiting":        true,
+}
+
+// Bubbles returns true if events of the given type bubble up to the ancestors of their target. Events like
+// `focus` or `mouseenter` are only dispatched to their target, they can only be caught by the ancestors in
+// the capture phase.
+func Bubbles(eventType string) bool {
+	return !nonBubblingEvents[eventType]
+}
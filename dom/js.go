@@ -0,0 +1,416 @@
+//go:build js && wasm
+
+package dom
+
+import (
+	"strings"
+	"syscall/js"
+)
+
+// JSDocument returns the browser's global document wrapped in the Document interface.
+func JSDocument() Document {
+	return &jsDocument{value: js.Global().Get("document")}
+}
+
+// JSValue returns the underlying js.Value of a node, event, or document created by the `syscall/js`
+// implementation. Returns undefined for any other value, such as the in-memory nodes.
+func JSValue(value interface{}) js.Value {
+	switch typed := value.(type) {
+	case *jsDocument:
+		return typed.value
+	case *jsElement:
+		return typed.value
+	case *jsText:
+		return typed.value
+	case *jsEvent:
+		return typed.value
+	case File:
+		if value, ok := typed.value.(js.Value); ok {
+			return value
+		}
+		return js.Undefined()
+	default:
+		return js.Undefined()
+	}
+}
+
+// WrapNode wraps the given js.Value as a Node. Returns an Element for element nodes, a Text for
+// text nodes, and nil for any other value. Other nodes, such as comments, are skipped by NextSibling and
+// ChildNodes.
+func WrapNode(value js.Value) Node {
+	if !value.Truthy() {
+		return nil
+	}
+
+	switch value.Get("nodeType").Int() {
+	case 1:
+		return &jsElement{jsNode{value: value}}
+	case 3:
+		return &jsText{jsNode{value: value}}
+	default:
+		return nil
+	}
+}
+
+func wrapElement(value js.Value) Element {
+	if !value.Truthy() {
+		return nil
+	}
+
+	return &jsElement{jsNode{value: value}}
+}
+
+type jsDocument struct {
+	value js.Value
+}
+
+func (d *jsDocument) CreateElement(tag string) Element {
+	return &jsElement{jsNode{value: d.value.Call("createElement", tag)}}
+}
+
+func (d *jsDocument) CreateElementNS(namespace, tag string) Element {
+	return &jsElement{jsNode{value: d.value.Call("createElementNS", namespace, tag)}}
+}
+
+func (d *jsDocument) CreateTextNode(text string) Text {
+	return &jsText{jsNode{value: d.value.Call("createTextNode", text)}}
+}
+
+func (d *jsDocument) QuerySelector(selector string) Element {
+	return wrapElement(d.value.Call("querySelector", selector))
+}
+
+type jsNode struct {
+	value js.Value
+}
+
+func (n *jsNode) ParentNode() Element {
+	return wrapElement(n.value.Get("parentElement"))
+}
+
+// NextSibling returns the next sibling element or text node. Other nodes, such as comments, are skipped so the
+// siblings following them are still reached.
+func (n *jsNode) NextSibling() Node {
+	for sibling := n.value.Get("nextSibling"); sibling.Truthy(); sibling = sibling.Get("nextSibling") {
+		if node := WrapNode(sibling); node != nil {
+			return node
+		}
+	}
+
+	return nil
+}
+
+func (n *jsNode) IsSameNode(other Node) bool {
+	if other == nil {
+		return false
+	}
+
+	return n.value.Equal(JSValue(other))
+}
+
+type jsElement struct {
+	jsNode
+}
+
+func (e *jsElement) TagName() string {
+	return strings.ToLower(e.value.Get("tagName").String())
+}
+
+func (e *jsElement) GetAttribute(name string) (string, bool) {
+	if !e.value.Call("hasAttribute", name).Bool() {
+		return "", false
+	}
+
+	return e.value.Call("getAttribute", name).String(), true
+}
+
+func (e *jsElement) SetAttribute(name, value string) {
+	e.value.Call("setAttribute", name, value)
+}
+
+func (e *jsElement) RemoveAttribute(name string) {
+	e.value.Call("removeAttribute", name)
+}
+
+func (e *jsElement) AttributeNames() []string {
+	attributeNames := e.value.Call("getAttributeNames")
+	length := attributeNames.Length()
+
+	names := make([]string, length)
+	for i := 0; i < length; i++ {
+		names[i] = attributeNames.Index(i).String()
+	}
+
+	return names
+}
+
+func (e *jsElement) GetProperty(name string) interface{} {
+	return jsToGo(e.value.Get(name))
+}
+
+func (e *jsElement) SetProperty(name string, value interface{}) {
+	// Other values, such as js.Value or js.Func, are given to js.ValueOf as is
+	if converted, ok := PropertyValue(value); ok {
+		value = converted
+	}
+
+	e.value.Set(name, value)
+}
+
+func (e *jsElement) DeleteProperty(name string) {
+	e.value.Delete(name)
+}
+
+func (e *jsElement) ClassList() []string {
+	classList := e.value.Get("classList")
+	length := classList.Get("length").Int()
+
+	classes := make([]string, length)
+	for i := 0; i < length; i++ {
+		classes[i] = classList.Call("item", i).String()
+	}
+
+	return classes
+}
+
+func (e *jsElement) AddClass(class string) {
+	e.value.Get("classList").Call("add", class)
+}
+
+func (e *jsElement) RemoveClass(class string) {
+	e.value.Get("classList").Call("remove", class)
+}
+
+func (e *jsElement) GetStyleProperty(name string) string {
+	return e.value.Get("style").Call("getPropertyValue", name).String()
+}
+
+func (e *jsElement) SetStyleProperty(name, value string) {
+	// setProperty ignores values with a priority, it must be given separately
+	priority := ""
+	if trimmed := strings.TrimSuffix(value, "!important"); trimmed != value {
+		value = strings.TrimSpace(trimmed)
+		priority = "important"
+	}
+
+	e.value.Get("style").Call("setProperty", name, value, priority)
+}
+
+func (e *jsElement) RemoveStyleProperty(name string) {
+	e.value.Get("style").Call("removeProperty", name)
+}
+
+type jsListener struct {
+	wrapper js.Func
+	options ListenerOptions
+}
+
+func (l *jsListener) Release() {
+	l.wrapper.Release()
+}
+
+func (l *jsListener) jsOptions() map[string]interface{} {
+	return map[string]interface{}{
+		"capture": l.options.Capture,
+		"passive": l.options.Passive,
+	}
+}
+
+func (e *jsElement) AddEventListener(eventType string, handler EventHandler, options ListenerOptions) Listener {
+	listener := &jsListener{
+		options: options,
+		wrapper: js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) < 1 {
+				return nil
+			}
+
+			handler(&jsEvent{value: args[0]})
+			return nil
+		}),
+	}
+
+	e.value.Call("addEventListener", eventType, listener.wrapper, listener.jsOptions())
+	return listener
+}
+
+func (e *jsElement) RemoveEventListener(eventType string, listener Listener) {
+	converted, ok := listener.(*jsListener)
+	if !ok {
+		return
+	}
+
+	e.value.Call("removeEventListener", eventType, converted.wrapper, converted.jsOptions())
+}
+
+func (e *jsElement) ChildNodes() []Node {
+	childNodes := e.value.Get("childNodes")
+	length := childNodes.Length()
+
+	children := make([]Node, 0, length)
+	for i := 0; i < length; i++ {
+		if child := WrapNode(childNodes.Index(i)); child != nil {
+			children = append(children, child)
+		}
+	}
+
+	return children
+}
+
+func (e *jsElement) Children() []Element {
+	elements := e.value.Get("children")
+	length := elements.Length()
+
+	children := make([]Element, length)
+	for i := 0; i < length; i++ {
+		children[i] = &jsElement{jsNode{value: elements.Index(i)}}
+	}
+
+	return children
+}
+
+func (e *jsElement) AppendChild(child Node) {
+	e.value.Call("appendChild", JSValue(child))
+}
+
+func (e *jsElement) InsertBefore(child, reference Node) {
+	if reference == nil {
+		e.value.Call("insertBefore", JSValue(child), js.Null())
+		return
+	}
+
+	e.value.Call("insertBefore", JSValue(child), JSValue(reference))
+}
+
+func (e *jsElement) RemoveChild(child Node) {
+	e.value.Call("removeChild", JSValue(child))
+}
+
+func (e *jsElement) ReplaceChild(newChild, oldChild Node) {
+	e.value.Call("replaceChild", JSValue(newChild), JSValue(oldChild))
+}
+
+func (e *jsElement) SetInnerHTML(html string) {
+	e.value.Set("innerHTML", html)
+}
+
+func (e *jsElement) QuerySelector(selector string) Element {
+	return wrapElement(e.value.Call("querySelector", selector))
+}
+
+type jsText struct {
+	jsNode
+}
+
+func (t *jsText) NodeValue() string {
+	return t.value.Get("nodeValue").String()
+}
+
+func (t *jsText) SetNodeValue(value string) {
+	t.value.Set("nodeValue", value)
+}
+
+type jsEvent struct {
+	value js.Value
+}
+
+func (e *jsEvent) Type() string {
+	return e.value.Get("type").String()
+}
+
+func (e *jsEvent) Target() Node {
+	return WrapNode(e.value.Get("target"))
+}
+
+func (e *jsEvent) CurrentTarget() Node {
+	return WrapNode(e.value.Get("currentTarget"))
+}
+
+func (e *jsEvent) PreventDefault() {
+	e.value.Call("preventDefault")
+}
+
+func (e *jsEvent) StopPropagation() {
+	e.value.Call("stopPropagation")
+}
+
+func (e *jsEvent) StopImmediatePropagation() {
+	e.value.Call("stopImmediatePropagation")
+}
+
+func (e *jsEvent) GetProperty(name string) interface{} {
+	return jsToGo(e.value.Get(name))
+}
+
+// jsToGo converts the given JS value to the Go value returned by GetProperty. Values that cannot be
+// converted are returned as is.
+func jsToGo(value js.Value) interface{} {
+	switch value.Type() {
+	case js.TypeString:
+		return value.String()
+	case js.TypeBoolean:
+		return value.Bool()
+	case js.TypeNumber:
+		return value.Float()
+	case js.TypeUndefined, js.TypeNull:
+		return nil
+	case js.TypeObject:
+		switch {
+		case instanceOf(value, "FileList"):
+			return wrapFiles(value)
+		case instanceOf(value, "DataTransfer"):
+			return wrapDataTransfer(value)
+		case value.Get("nodeType").Type() == js.TypeNumber:
+			if node := WrapNode(value); node != nil {
+				return node
+			}
+		}
+
+		return value
+	default:
+		return value
+	}
+}
+
+// instanceOf returns true if the value is an instance of the global constructor with the given name. Returns
+// false if the constructor does not exist in this environment.
+func instanceOf(value js.Value, constructor string) bool {
+	global := js.Global().Get(constructor)
+	return global.Truthy() && value.InstanceOf(global)
+}
+
+func wrapFiles(fileList js.Value) []File {
+	length := fileList.Length()
+
+	files := make([]File, length)
+	for i := 0; i < length; i++ {
+		file := fileList.Index(i)
+		files[i] = File{
+			Name:  file.Get("name").String(),
+			Type:  file.Get("type").String(),
+			Size:  int64(file.Get("size").Int()),
+			value: file,
+		}
+	}
+
+	return files
+}
+
+func wrapDataTransfer(dataTransfer js.Value) *DataTransfer {
+	types := dataTransfer.Get("types")
+	length := types.Length()
+
+	wrapped := &DataTransfer{
+		DropEffect:    dataTransfer.Get("dropEffect").String(),
+		EffectAllowed: dataTransfer.Get("effectAllowed").String(),
+		Types:         make([]string, length),
+		Data:          make(map[string]string, length),
+		Files:         wrapFiles(dataTransfer.Get("files")),
+	}
+	for i := 0; i < length; i++ {
+		format := types.Index(i).String()
+		wrapped.Types[i] = format
+		wrapped.Data[format] = dataTransfer.Call("getData", format).String()
+	}
+
+	return wrapped
+}
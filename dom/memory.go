@@ -0,0 +1,734 @@
+package dom
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// MemoryDocument is an in-memory implementation of the Document interface. It does not require a browser
+// and can be used to mount, patch and inspect a lander app with plain `go test`. The document is created
+// with an empty `html`, `head` and `body` structure.
+type MemoryDocument struct {
+	root *MemoryElement
+}
+
+// NewMemoryDocument creates a new in-memory document with empty `head` and `body` elements.
+func NewMemoryDocument() *MemoryDocument {
+	document := &MemoryDocument{}
+	document.root = document.newElement("", "html")
+	document.root.AppendChild(document.newElement("", "head"))
+	document.root.AppendChild(document.newElement("", "body"))
+
+	return document
+}
+
+func (d *MemoryDocument) newElement(namespace, tag string) *MemoryElement {
+	return &MemoryElement{
+		namespace:  namespace,
+		tag:        strings.ToLower(tag),
+		attributes: map[string]string{},
+		properties: map[string]interface{}{},
+		listeners:  map[string][]*memoryListener{},
+	}
+}
+
+// Root returns the `html` element of the document.
+func (d *MemoryDocument) Root() *MemoryElement {
+	return d.root
+}
+
+// Head returns the `head` element of the document.
+func (d *MemoryDocument) Head() *MemoryElement {
+	return d.root.childNodes[0].(*MemoryElement)
+}
+
+// Body returns the `body` element of the document.
+func (d *MemoryDocument) Body() *MemoryElement {
+	return d.root.childNodes[1].(*MemoryElement)
+}
+
+func (d *MemoryDocument) CreateElement(tag string) Element {
+	return d.newElement("", tag)
+}
+
+func (d *MemoryDocument) CreateElementNS(namespace, tag string) Element {
+	return d.newElement(namespace, tag)
+}
+
+func (d *MemoryDocument) CreateTextNode(text string) Text {
+	return &MemoryText{value: text}
+}
+
+func (d *MemoryDocument) QuerySelector(selector string) Element {
+	if d.root.matches(selector) {
+		return d.root
+	}
+
+	return d.root.QuerySelector(selector)
+}
+
+type memoryListener struct {
+	handler  EventHandler
+	capture  bool
+	passive  bool
+	released bool
+}
+
+func (l *memoryListener) Release() {
+	l.released = true
+}
+
+// MemoryElement is an in-memory implementation of the Element interface. On top of the Element methods,
+// it provides utilities to dispatch events and serialize its content to help with testing.
+type MemoryElement struct {
+	parent *MemoryElement
+
+	namespace  string
+	tag        string
+	attributes map[string]string
+	properties map[string]interface{}
+	listeners  map[string][]*memoryListener
+	childNodes []Node
+	innerHTML  string
+}
+
+func (e *MemoryElement) ParentNode() Element {
+	if e.parent == nil {
+		return nil
+	}
+
+	return e.parent
+}
+
+func (e *MemoryElement) NextSibling() Node {
+	return nextSibling(e.parent, e)
+}
+
+func (e *MemoryElement) IsSameNode(other Node) bool {
+	converted, ok := other.(*MemoryElement)
+	return ok && converted == e
+}
+
+// Namespace returns the namespace the element was created with, if any.
+func (e *MemoryElement) Namespace() string {
+	return e.namespace
+}
+
+func (e *MemoryElement) TagName() string {
+	return e.tag
+}
+
+func (e *MemoryElement) GetAttribute(name string) (string, bool) {
+	value, ok := e.attributes[name]
+	return value, ok
+}
+
+func (e *MemoryElement) SetAttribute(name, value string) {
+	e.attributes[name] = value
+}
+
+func (e *MemoryElement) RemoveAttribute(name string) {
+	delete(e.attributes, name)
+}
+
+// AttributeNames returns the names of all the attributes of the element, sorted to make the output
+// deterministic.
+func (e *MemoryElement) AttributeNames() []string {
+	names := make([]string, 0, len(e.attributes))
+	for name := range e.attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (e *MemoryElement) GetProperty(name string) interface{} {
+	if name == "id" {
+		return e.attributes["id"]
+	}
+
+	return e.properties[name]
+}
+
+func (e *MemoryElement) SetProperty(name string, value interface{}) {
+	// Reflect the ID on the attributes like the browser would do
+	if name == "id" {
+		e.attributes["id"] = fmt.Sprint(value)
+		return
+	}
+
+	// Setting the value of a select selects the matching option, like the browser would do
+	if name == "value" && e.tag == "select" {
+		e.selectOption(fmt.Sprint(value))
+		return
+	}
+
+	// The browser panics on values that cannot be converted to JavaScript, except the browser objects
+	// returned by GetProperty
+	converted, ok := PropertyValue(value)
+	if !ok && !isBrowserObject(value) {
+		panic(fmt.Sprintf("TypeError: cannot set property %s, %T has no JavaScript equivalent", name, value))
+	} else if ok {
+		value = converted
+	}
+
+	e.properties[name] = value
+
+	// Setting the value of a text field moves its selection to the end, like the browser would do
+	if _, hasSelection := e.properties["selectionStart"]; name == "value" && hasSelection {
+		length := len([]rune(fmt.Sprint(value)))
+		e.properties["selectionStart"] = length
+		e.properties["selectionEnd"] = length
+	}
+}
+
+// isBrowserObject returns true if the value is a browser object returned by GetProperty, such as files or
+// DOM nodes, which can be assigned back as properties.
+func isBrowserObject(value interface{}) bool {
+	switch value.(type) {
+	case Node, []File, *DataTransfer:
+		return true
+	}
+
+	return false
+}
+
+// selectOption sets the selectedIndex of a select element to the index of the first option with the given
+// value, or -1 if there are none. The value is empty if no option is selected.
+func (e *MemoryElement) selectOption(value string) {
+	e.properties["selectedIndex"] = -1
+	e.properties["value"] = ""
+
+	for index, option := range e.Children() {
+		if optionValue, _ := option.GetAttribute("value"); optionValue == value {
+			e.properties["selectedIndex"] = index
+			e.properties["value"] = value
+			return
+		}
+	}
+}
+
+func (e *MemoryElement) DeleteProperty(name string) {
+	delete(e.properties, name)
+}
+
+func (e *MemoryElement) ClassList() []string {
+	return strings.Fields(e.attributes["class"])
+}
+
+func (e *MemoryElement) AddClass(class string) {
+	classes := e.ClassList()
+	for _, existing := range classes {
+		if existing == class {
+			return
+		}
+	}
+
+	e.attributes["class"] = strings.Join(append(classes, class), " ")
+}
+
+func (e *MemoryElement) RemoveClass(class string) {
+	var classes []string
+	for _, existing := range e.ClassList() {
+		if existing != class {
+			classes = append(classes, existing)
+		}
+	}
+
+	e.attributes["class"] = strings.Join(classes, " ")
+}
+
+// GetStyleProperty reads the given property from the style attribute, which holds the inline styles like
+// in the browser.
+func (e *MemoryElement) GetStyleProperty(name string) string {
+	for _, declaration := range parseStyle(e.attributes["style"]) {
+		if declaration[0] == name {
+			return declaration[1]
+		}
+	}
+
+	return ""
+}
+
+func (e *MemoryElement) SetStyleProperty(name, value string) {
+	declarations := parseStyle(e.attributes["style"])
+	for i, declaration := range declarations {
+		if declaration[0] == name {
+			declarations[i][1] = value
+			e.attributes["style"] = serializeStyle(declarations)
+			return
+		}
+	}
+
+	e.attributes["style"] = serializeStyle(append(declarations, [2]string{name, value}))
+}
+
+func (e *MemoryElement) RemoveStyleProperty(name string) {
+	declarations := parseStyle(e.attributes["style"])
+	for i, declaration := range declarations {
+		if declaration[0] == name {
+			e.attributes["style"] = serializeStyle(append(declarations[:i], declarations[i+1:]...))
+			break
+		}
+	}
+
+	// The browser keeps an empty style attribute, but it is easier to test without it
+	if e.attributes["style"] == "" {
+		delete(e.attributes, "style")
+	}
+}
+
+func (e *MemoryElement) AddEventListener(eventType string, handler EventHandler, options ListenerOptions) Listener {
+	listener := &memoryListener{handler: handler, capture: options.Capture, passive: options.Passive}
+	e.listeners[eventType] = append(e.listeners[eventType], listener)
+
+	return listener
+}
+
+func (e *MemoryElement) RemoveEventListener(eventType string, listener Listener) {
+	var listeners []*memoryListener
+	for _, existing := range e.listeners[eventType] {
+		if existing != listener {
+			listeners = append(listeners, existing)
+		}
+	}
+
+	e.listeners[eventType] = listeners
+}
+
+// ListenerCount returns the number of listeners registered for the given event type.
+func (e *MemoryElement) ListenerCount(eventType string) int {
+	return len(e.listeners[eventType])
+}
+
+// ListenerOptions returns the options of the listeners registered for the given event type, in the order they
+// were added.
+func (e *MemoryElement) ListenerOptions(eventType string) []ListenerOptions {
+	options := make([]ListenerOptions, len(e.listeners[eventType]))
+	for index, listener := range e.listeners[eventType] {
+		options[index] = ListenerOptions{Capture: listener.capture, Passive: listener.passive}
+	}
+
+	return options
+}
+
+// DispatchWith dispatches a new event of the given type on this element, like Dispatch, with the given
+// properties. The properties are returned by GetProperty on the event, for example "key" for keyboard events.
+func (e *MemoryElement) DispatchWith(eventType string, properties map[string]interface{}) *MemoryEvent {
+	event := NewMemoryEvent(eventType, e)
+	event.Properties = properties
+	DispatchEvent(event)
+
+	return event
+}
+
+// Dispatch dispatches a new event of the given type on this element. The event goes through the capture
+// listeners of the element's ancestors, then bubbles up through the ancestors if its type bubbles, until it
+// reaches the root or its propagation is stopped. Returns the dispatched event.
+func (e *MemoryElement) Dispatch(eventType string) *MemoryEvent {
+	event := NewMemoryEvent(eventType, e)
+	DispatchEvent(event)
+
+	return event
+}
+
+func (e *MemoryElement) ChildNodes() []Node {
+	children := make([]Node, len(e.childNodes))
+	copy(children, e.childNodes)
+
+	return children
+}
+
+func (e *MemoryElement) Children() []Element {
+	var children []Element
+	for _, child := range e.childNodes {
+		if element, ok := child.(*MemoryElement); ok {
+			children = append(children, element)
+		}
+	}
+
+	return children
+}
+
+func (e *MemoryElement) AppendChild(child Node) {
+	e.InsertBefore(child, nil)
+}
+
+// InsertBefore panics if the reference is not a child of the element, like the browser throws a
+// NotFoundError, so positioning bugs fail in tests rather than being hidden by an append.
+func (e *MemoryElement) InsertBefore(child, reference Node) {
+	if reference != nil && reference.IsSameNode(child) {
+		reference = child.NextSibling()
+	}
+
+	index := len(e.childNodes)
+	if reference != nil {
+		index = e.indexOf(reference)
+		if index < 0 {
+			panic(fmt.Sprintf("NotFoundError: cannot insert before %s, it is not a child of <%s>", describe(reference), e.tag))
+		}
+	}
+
+	if current := e.indexOf(child); current >= 0 && current < index {
+		// The child is detached first, the reference moves back by one
+		index--
+	}
+	detach(child)
+	setParent(child, e)
+
+	e.childNodes = append(e.childNodes[:index], append([]Node{child}, e.childNodes[index:]...)...)
+}
+
+// RemoveChild panics if the child is not a child of the element, like the browser throws a NotFoundError.
+func (e *MemoryElement) RemoveChild(child Node) {
+	index := e.indexOf(child)
+	if index < 0 {
+		panic(fmt.Sprintf("NotFoundError: cannot remove %s, it is not a child of <%s>", describe(child), e.tag))
+	}
+
+	e.childNodes = append(e.childNodes[:index], e.childNodes[index+1:]...)
+	setParent(child, nil)
+}
+
+// indexOf returns the index of the given node in the child nodes of the element, or -1.
+func (e *MemoryElement) indexOf(node Node) int {
+	for index, existing := range e.childNodes {
+		if existing.IsSameNode(node) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+func (e *MemoryElement) ReplaceChild(newChild, oldChild Node) {
+	e.InsertBefore(newChild, oldChild)
+	e.RemoveChild(oldChild)
+}
+
+func (e *MemoryElement) SetInnerHTML(html string) {
+	for _, child := range e.childNodes {
+		setParent(child, nil)
+	}
+
+	e.childNodes = nil
+	e.innerHTML = html
+}
+
+func (e *MemoryElement) QuerySelector(selector string) Element {
+	for _, child := range e.childNodes {
+		element, ok := child.(*MemoryElement)
+		if !ok {
+			continue
+		}
+
+		if element.matches(selector) {
+			return element
+		}
+
+		if found := element.QuerySelector(selector); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// matches checks if the element matches a simple selector, such as `div`, `#id`, `.class`, or a
+// combination of them like `div#id.class`. Attribute selectors in the form of `[name]` or `[name="value"]`
+// are also supported. Combinators are not supported.
+func (e *MemoryElement) matches(selector string) bool {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return false
+	}
+
+	for len(selector) > 0 {
+		var part string
+		end := strings.IndexAny(selector[1:], "#.[")
+		if selector[0] == '[' {
+			end = strings.Index(selector, "]")
+			if end < 0 {
+				return false
+			}
+			part, selector = selector[:end+1], selector[end+1:]
+		} else if end < 0 {
+			part, selector = selector, ""
+		} else {
+			part, selector = selector[:end+1], selector[end+1:]
+		}
+
+		switch part[0] {
+		case '#':
+			if e.attributes["id"] != part[1:] {
+				return false
+			}
+		case '.':
+			found := false
+			for _, class := range e.ClassList() {
+				if class == part[1:] {
+					found = true
+				}
+			}
+			if !found {
+				return false
+			}
+		case '[':
+			name, value, hasValue := strings.Cut(part[1:len(part)-1], "=")
+			existing, ok := e.attributes[name]
+			if !ok || (hasValue && existing != strings.Trim(value, `"'`)) {
+				return false
+			}
+		default:
+			if part != "*" && e.tag != strings.ToLower(part) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// InnerHTML serializes the content of this element as an HTML string. Attributes are sorted by name to
+// make the output deterministic.
+func (e *MemoryElement) InnerHTML() string {
+	if e.innerHTML != "" {
+		return e.innerHTML
+	}
+
+	content := ""
+	for _, child := range e.childNodes {
+		switch typed := child.(type) {
+		case *MemoryElement:
+			content += typed.OuterHTML()
+		case *MemoryText:
+			content += html.EscapeString(typed.value)
+		}
+	}
+
+	return content
+}
+
+// OuterHTML serializes this element and its content as an HTML string. Attributes are sorted by name to
+// make the output deterministic.
+func (e *MemoryElement) OuterHTML() string {
+	attributes := ""
+	for _, name := range e.AttributeNames() {
+		attributes += fmt.Sprintf(" %s=\"%s\"", name, html.EscapeString(e.attributes[name]))
+	}
+
+	return fmt.Sprintf("<%s%s>%s</%s>", e.tag, attributes, e.InnerHTML(), e.tag)
+}
+
+// MemoryText is an in-memory implementation of the Text interface.
+type MemoryText struct {
+	parent *MemoryElement
+	value  string
+}
+
+func (t *MemoryText) ParentNode() Element {
+	if t.parent == nil {
+		return nil
+	}
+
+	return t.parent
+}
+
+func (t *MemoryText) NextSibling() Node {
+	return nextSibling(t.parent, t)
+}
+
+func (t *MemoryText) IsSameNode(other Node) bool {
+	converted, ok := other.(*MemoryText)
+	return ok && converted == t
+}
+
+func (t *MemoryText) NodeValue() string {
+	return t.value
+}
+
+func (t *MemoryText) SetNodeValue(value string) {
+	t.value = value
+}
+
+// MemoryEvent is an in-memory implementation of the Event interface, which can be dispatched
+// on in-memory elements.
+type MemoryEvent struct {
+	eventType     string
+	target        Node
+	currentTarget Node
+
+	// DefaultPrevented is set to true once PreventDefault has been called on the event.
+	DefaultPrevented bool
+	// PropagationStopped is set to true once StopPropagation has been called on the event.
+	PropagationStopped bool
+	// ImmediatePropagationStopped is set to true once StopImmediatePropagation has been called on the event.
+	ImmediatePropagationStopped bool
+
+	// Properties are the properties of the event returned by GetProperty, such as "key" or "clientX".
+	Properties map[string]interface{}
+}
+
+// NewMemoryEvent creates a new in-memory event of the given type, targeting the given node.
+func NewMemoryEvent(eventType string, target Node) *MemoryEvent {
+	return &MemoryEvent{
+		eventType: eventType,
+		target:    target,
+	}
+}
+
+// DispatchEvent dispatches the in-memory event like the browser would. The capture listeners of the target's
+// ancestors are called first, from the root down, then the listeners of the target. If the event bubbles, see
+// Bubbles, the listeners of the ancestors are then called up to the root. Dispatching ends as soon as the
+// propagation is stopped.
+func DispatchEvent(event *MemoryEvent) {
+	var target *MemoryElement
+	switch typed := event.target.(type) {
+	case *MemoryElement:
+		target = typed
+	case *MemoryText:
+		target = typed.parent
+	}
+
+	var ancestors []*MemoryElement
+	if target != nil {
+		for current := target.parent; current != nil; current = current.parent {
+			ancestors = append(ancestors, current)
+		}
+	}
+
+	for index := len(ancestors) - 1; index >= 0 && !event.PropagationStopped; index-- {
+		event.callListeners(ancestors[index], true)
+	}
+
+	if target != nil && !event.PropagationStopped {
+		event.callListeners(target, true)
+		if !event.PropagationStopped {
+			event.callListeners(target, false)
+		}
+	}
+
+	if Bubbles(event.eventType) {
+		for index := 0; index < len(ancestors) && !event.PropagationStopped; index++ {
+			event.callListeners(ancestors[index], false)
+		}
+	}
+
+	event.currentTarget = nil
+}
+
+// callListeners calls the listeners of the element registered for the capture phase, or the bubbling phase.
+func (e *MemoryEvent) callListeners(element *MemoryElement, capture bool) {
+	e.currentTarget = element
+
+	// Copy the listeners so handlers can safely add or remove listeners
+	listeners := make([]*memoryListener, len(element.listeners[e.eventType]))
+	copy(listeners, element.listeners[e.eventType])
+	for _, listener := range listeners {
+		if e.ImmediatePropagationStopped {
+			return
+		}
+
+		if !listener.released && listener.capture == capture {
+			listener.handler(e)
+		}
+	}
+}
+
+func (e *MemoryEvent) Type() string {
+	return e.eventType
+}
+
+func (e *MemoryEvent) Target() Node {
+	return e.target
+}
+
+func (e *MemoryEvent) CurrentTarget() Node {
+	return e.currentTarget
+}
+
+func (e *MemoryEvent) PreventDefault() {
+	e.DefaultPrevented = true
+}
+
+func (e *MemoryEvent) StopPropagation() {
+	e.PropagationStopped = true
+}
+
+func (e *MemoryEvent) StopImmediatePropagation() {
+	e.PropagationStopped = true
+	e.ImmediatePropagationStopped = true
+}
+
+func (e *MemoryEvent) GetProperty(name string) interface{} {
+	return e.Properties[name]
+}
+
+// parseStyle parses the given style attribute into its name and value declarations, in order.
+func parseStyle(style string) [][2]string {
+	var declarations [][2]string
+	for _, declaration := range strings.Split(style, ";") {
+		name, value, ok := strings.Cut(declaration, ":")
+		if !ok {
+			continue
+		}
+
+		declarations = append(declarations, [2]string{strings.TrimSpace(name), strings.TrimSpace(value)})
+	}
+
+	return declarations
+}
+
+// serializeStyle serializes the declarations like the browser's `cssText`, such as "width: 10px; color: red;".
+func serializeStyle(declarations [][2]string) string {
+	parts := make([]string, len(declarations))
+	for i, declaration := range declarations {
+		parts[i] = declaration[0] + ": " + declaration[1] + ";"
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func nextSibling(parent *MemoryElement, node Node) Node {
+	if parent == nil {
+		return nil
+	}
+
+	for index, child := range parent.childNodes {
+		if child.IsSameNode(node) && index+1 < len(parent.childNodes) {
+			return parent.childNodes[index+1]
+		}
+	}
+
+	return nil
+}
+
+func detach(node Node) {
+	if parent := node.ParentNode(); parent != nil {
+		parent.RemoveChild(node)
+	}
+}
+
+// describe returns a short description of the node for error messages.
+func describe(node Node) string {
+	switch typed := node.(type) {
+	case *MemoryElement:
+		return "<" + typed.tag + ">"
+	case *MemoryText:
+		return fmt.Sprintf("text %q", typed.value)
+	default:
+		return fmt.Sprintf("%T", node)
+	}
+}
+
+func setParent(node Node, parent *MemoryElement) {
+	switch typed := node.(type) {
+	case *MemoryElement:
+		typed.parent = parent
+	case *MemoryText:
+		typed.parent = parent
+	}
+}
@@ -0,0 +1,237 @@
+package dom_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander/dom"
+)
+
+func TestMemoryDocument_TreeOperations(t *testing.T) {
+	document := dom.NewMemoryDocument()
+
+	list := document.CreateElement("ul")
+	list.SetProperty("id", "list")
+	document.Body().AppendChild(list)
+
+	first := document.CreateElement("li")
+	first.AppendChild(document.CreateTextNode("first"))
+	third := document.CreateElement("li")
+	third.AppendChild(document.CreateTextNode("third"))
+	list.AppendChild(first)
+	list.AppendChild(third)
+
+	second := document.CreateElement("li")
+	second.AppendChild(document.CreateTextNode("second"))
+	list.InsertBefore(second, third)
+
+	assert.Equal(t, `<ul id="list"><li>first</li><li>second</li><li>third</li></ul>`, list.(*dom.MemoryElement).OuterHTML())
+	assert.True(t, first.NextSibling().IsSameNode(second))
+	assert.True(t, second.ParentNode().IsSameNode(list))
+
+	replacement := document.CreateTextNode("<replaced>")
+	list.ReplaceChild(replacement, second)
+	assert.Equal(t, `<li>first</li>&lt;replaced&gt;<li>third</li>`, list.(*dom.MemoryElement).InnerHTML())
+	assert.Nil(t, second.ParentNode())
+	assert.Len(t, list.ChildNodes(), 3)
+	assert.Len(t, list.Children(), 2)
+
+	// Appending an attached node moves it
+	list.AppendChild(first)
+	assert.Equal(t, `&lt;replaced&gt;<li>third</li><li>first</li>`, list.(*dom.MemoryElement).InnerHTML())
+
+	list.RemoveChild(third)
+	assert.Equal(t, `&lt;replaced&gt;<li>first</li>`, list.(*dom.MemoryElement).InnerHTML())
+}
+
+func TestMemoryElement_NotFound(t *testing.T) {
+	document := dom.NewMemoryDocument()
+
+	list := document.CreateElement("ul")
+	item := document.CreateElement("li")
+	list.AppendChild(item)
+	other := document.CreateElement("li")
+	document.Body().AppendChild(other)
+
+	// The browser throws a NotFoundError when the reference or the removed node is not a child
+	assert.Panics(t, func() {
+		list.InsertBefore(document.CreateElement("li"), other)
+	})
+	assert.Panics(t, func() {
+		list.RemoveChild(other)
+	})
+	assert.True(t, other.ParentNode().IsSameNode(document.Body()))
+
+	// Moving a child before its next sibling, or before itself, keeps it in place
+	last := document.CreateElement("li")
+	list.AppendChild(last)
+	list.InsertBefore(item, last)
+	list.InsertBefore(last, last)
+	assert.Equal(t, `<li></li><li></li>`, list.(*dom.MemoryElement).InnerHTML())
+	assert.True(t, list.ChildNodes()[0].IsSameNode(item))
+	assert.True(t, list.ChildNodes()[1].IsSameNode(last))
+}
+
+func TestMemoryDocument_QuerySelector(t *testing.T) {
+	document := dom.NewMemoryDocument()
+
+	app := document.CreateElement("div")
+	app.SetProperty("id", "app")
+	document.Body().AppendChild(app)
+
+	button := document.CreateElement("button")
+	button.AddClass("primary")
+	button.SetAttribute("data-tag", "action")
+	app.AppendChild(button)
+
+	assert.True(t, document.QuerySelector("head").IsSameNode(document.Head()))
+	assert.True(t, document.QuerySelector("#app").IsSameNode(app))
+	assert.True(t, document.QuerySelector("button.primary").IsSameNode(button))
+	assert.True(t, document.QuerySelector(`[data-tag="action"]`).IsSameNode(button))
+	assert.True(t, app.QuerySelector(".primary").IsSameNode(button))
+	assert.Nil(t, document.QuerySelector("#missing"))
+	assert.Nil(t, document.QuerySelector("div.primary"))
+}
+
+func TestMemoryElement_AttributesAndClasses(t *testing.T) {
+	document := dom.NewMemoryDocument()
+	element := document.CreateElement("input")
+
+	element.SetAttribute("type", "text")
+	element.SetProperty("value", "test")
+	element.AddClass("first")
+	element.AddClass("second")
+	element.AddClass("first")
+
+	value, ok := element.GetAttribute("type")
+	assert.True(t, ok)
+	assert.Equal(t, "text", value)
+	assert.Equal(t, "test", element.GetProperty("value"))
+	assert.Equal(t, []string{"first", "second"}, element.ClassList())
+	assert.Equal(t, []string{"class", "type"}, element.AttributeNames())
+
+	element.RemoveAttribute("type")
+	element.DeleteProperty("value")
+	element.RemoveClass("first")
+
+	_, ok = element.GetAttribute("type")
+	assert.False(t, ok)
+	assert.Nil(t, element.GetProperty("value"))
+	assert.Equal(t, []string{"second"}, element.ClassList())
+}
+
+func TestMemoryElement_PropertyValues(t *testing.T) {
+	document := dom.NewMemoryDocument()
+	element := document.CreateElement("custom-list")
+
+	// Slices and maps are converted like the browser would convert them to JavaScript arrays and objects
+	element.SetProperty("items", []int{1, 2})
+	element.SetProperty("options", map[string]bool{"open": true})
+	assert.Equal(t, []interface{}{1, 2}, element.GetProperty("items"))
+	assert.Equal(t, map[string]interface{}{"open": true}, element.GetProperty("options"))
+
+	// Browser objects can be assigned back
+	files := []dom.File{{Name: "notes.txt"}}
+	element.SetProperty("files", files)
+	assert.Equal(t, files, element.GetProperty("files"))
+
+	assert.PanicsWithValue(t, "TypeError: cannot set property item, struct { Name string } has no JavaScript equivalent", func() {
+		element.SetProperty("item", struct{ Name string }{Name: "first"})
+	})
+	assert.Panics(t, func() {
+		element.SetProperty("lengths", map[int]int{1: 2})
+	})
+}
+
+func TestMemoryElement_Style(t *testing.T) {
+	element := dom.NewMemoryDocument().CreateElement("div")
+	element.SetAttribute("style", "color: red")
+
+	element.SetStyleProperty("width", "10px")
+	element.SetStyleProperty("color", "blue")
+	value, _ := element.GetAttribute("style")
+	assert.Equal(t, "color: blue; width: 10px;", value)
+	assert.Equal(t, "10px", element.GetStyleProperty("width"))
+
+	element.RemoveStyleProperty("color")
+	element.RemoveStyleProperty("missing")
+	assert.Equal(t, "", element.GetStyleProperty("color"))
+
+	element.RemoveStyleProperty("width")
+	_, ok := element.GetAttribute("style")
+	assert.False(t, ok)
+}
+
+func TestMemoryElement_Dispatch(t *testing.T) {
+	document := dom.NewMemoryDocument()
+
+	parent := document.CreateElement("div")
+	child := document.CreateElement("button")
+	parent.AppendChild(child)
+
+	var calls []string
+	parentListener := parent.AddEventListener("click", func(event dom.Event) {
+		calls = append(calls, "parent")
+		assert.True(t, event.Target().IsSameNode(child))
+		assert.True(t, event.CurrentTarget().IsSameNode(parent))
+	}, dom.ListenerOptions{})
+	child.AddEventListener("click", func(event dom.Event) {
+		calls = append(calls, "child")
+		event.PreventDefault()
+	}, dom.ListenerOptions{})
+
+	event := child.(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, []string{"child", "parent"}, calls)
+	assert.True(t, event.DefaultPrevented)
+
+	parent.RemoveEventListener("click", parentListener)
+	parentListener.Release()
+	require.Equal(t, 0, parent.(*dom.MemoryElement).ListenerCount("click"))
+
+	calls = nil
+	child.(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, []string{"child"}, calls)
+
+	child.AddEventListener("click", func(event dom.Event) {
+		event.StopPropagation()
+	}, dom.ListenerOptions{})
+	parent.AddEventListener("click", func(event dom.Event) {
+		calls = append(calls, "parent")
+	}, dom.ListenerOptions{})
+
+	calls = nil
+	child.(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, []string{"child"}, calls)
+}
+
+func TestMemoryElement_DispatchCapture(t *testing.T) {
+	document := dom.NewMemoryDocument()
+
+	parent := document.CreateElement("div")
+	child := document.CreateElement("input")
+	parent.AppendChild(child)
+
+	var calls []string
+	for _, eventType := range []string{"click", "focus"} {
+		eventType := eventType
+		parent.AddEventListener(eventType, func(dom.Event) {
+			calls = append(calls, eventType+" parent capture")
+		}, dom.ListenerOptions{Capture: true})
+		parent.AddEventListener(eventType, func(dom.Event) {
+			calls = append(calls, eventType+" parent")
+		}, dom.ListenerOptions{})
+		child.AddEventListener(eventType, func(dom.Event) {
+			calls = append(calls, eventType+" child")
+		}, dom.ListenerOptions{})
+	}
+
+	child.(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, []string{"click parent capture", "click child", "click parent"}, calls)
+
+	// Events that do not bubble only reach the ancestors in the capture phase
+	calls = nil
+	child.(*dom.MemoryElement).Dispatch("focus")
+	assert.Equal(t, []string{"focus parent capture", "focus child"}, calls)
+}
@@ -0,0 +1,81 @@
+package dom
+
+import (
+	"reflect"
+)
+
+// basicTypes maps the kinds of the basic values supported as properties to their type, values of named types,
+// such as `type Count int`, are converted to their underlying type.
+var basicTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.String:  reflect.TypeOf(""),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Uintptr: reflect.TypeOf(uintptr(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+}
+
+// PropertyValue converts the given value to a value the browser can assign as an object property. Slices and
+// arrays are converted to `[]interface{}` and maps with string keys to `map[string]interface{}`, recursively,
+// like the arrays and objects of JavaScript. Returns false if the value, or any value it contains, has no
+// JavaScript equivalent, such as a struct or a function.
+func PropertyValue(value interface{}) (interface{}, bool) {
+	if value == nil {
+		return nil, true
+	}
+
+	return propertyValue(reflect.ValueOf(value))
+}
+
+func propertyValue(value reflect.Value) (interface{}, bool) {
+	if basic, ok := basicTypes[value.Kind()]; ok {
+		return value.Convert(basic).Interface(), true
+	}
+
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil, true
+		}
+
+		return propertyValue(value.Elem())
+	case reflect.Slice, reflect.Array:
+		converted := make([]interface{}, value.Len())
+		for index := range converted {
+			item, ok := propertyValue(value.Index(index))
+			if !ok {
+				return nil, false
+			}
+			converted[index] = item
+		}
+
+		return converted, true
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+
+		converted := make(map[string]interface{}, value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			item, ok := propertyValue(iterator.Value())
+			if !ok {
+				return nil, false
+			}
+			converted[iterator.Key().String()] = item
+		}
+
+		return converted, true
+	}
+
+	return nil, false
+}
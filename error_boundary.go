@@ -0,0 +1,68 @@
+package lander
+
+import (
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/nodes"
+)
+
+// ErrorFallback renders the content of an error boundary once it caught an error. Calling reset renders the
+// children of the boundary again, as if they were mounted for the first time.
+type ErrorFallback func(err error, reset func() error) nodes.Child
+
+// ErrorBoundaryProps are the properties of the error boundary component created by ErrorBoundary.
+type ErrorBoundaryProps struct {
+	// Fallback renders the caught error in place of the children of the boundary.
+	Fallback ErrorFallback
+}
+
+type errorBoundaryState struct {
+	err error
+}
+
+func errorBoundary(ctx context.Context, props ErrorBoundaryProps, children nodes.Children) nodes.Child {
+	owner, ok := ctx.Component().(*nodes.FuncNode)
+	if !ok {
+		return nodes.NewFragmentNode(children)
+	}
+
+	slot, created := owner.NextSlot("ErrorBoundary")
+	if created {
+		slot.Value = &errorBoundaryState{}
+	}
+	state := slot.Value.(*errorBoundaryState)
+
+	if state.err != nil {
+		if props.Fallback == nil {
+			return nil
+		}
+
+		return props.Fallback(state.err, func() error {
+			return ctx.UpdateComponent(owner, func() {
+				state.err = nil
+			})
+		})
+	}
+
+	context.ProvideErrorHandler(ctx, func(err error) {
+		// Errors are handled in the render loop, keep the first one until the fallback is rendered
+		if state.err != nil {
+			return
+		}
+
+		state.err = err
+		_ = ctx.UpdateComponent(owner, nil)
+	})
+
+	return nodes.NewFragmentNode(children)
+}
+
+// ErrorBoundary creates a component node that catches the errors of its children and their descendants. Panics
+// while rendering a component, errors while patching the DOM and errors or panics from lifecycle listeners are
+// caught by the nearest boundary, which then renders the fallback in place of its children. Errors outside any
+// boundary are returned or panic as usual.
+//
+// Errors of event listeners are not caught, they are sent to the error handler of the environment, see
+// WithErrorHandler.
+func ErrorBoundary(fallback ErrorFallback, children nodes.Children, options ...ComponentOption) *nodes.FuncNode {
+	return Component(errorBoundary, ErrorBoundaryProps{Fallback: fallback}, children, options...)
+}
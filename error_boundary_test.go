@@ -0,0 +1,193 @@
+package lander_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/nodes"
+)
+
+var errBroken = errors.New("broken")
+
+type brokenProps struct {
+	broken *bool
+}
+
+func brokenComponent(_ context.Context, props brokenProps, _ nodes.Children) nodes.Child {
+	if *props.broken {
+		panic(errBroken)
+	}
+
+	return lander.Text("working")
+}
+
+func boundaryFallback(err error, reset func() error) nodes.Child {
+	return lander.Html("button", nodes.Attributes{
+		"click": func(*events.DOMEvent) error {
+			return reset()
+		},
+	}, nodes.Children{
+		lander.Text(err.Error()),
+	})
+}
+
+func TestErrorBoundary_Render(t *testing.T) {
+	document, app := setupDocument(t)
+
+	broken := true
+	var caught error
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Html("div", nodes.Attributes{}, nodes.Children{
+			lander.Text("outside"),
+			lander.ErrorBoundary(func(err error, reset func() error) nodes.Child {
+				caught = err
+				return boundaryFallback(err, reset)
+			}, nodes.Children{
+				lander.Html("p", nodes.Attributes{}, nodes.Children{
+					lander.Component(brokenComponent, brokenProps{broken: &broken}, nodes.Children{}),
+				}),
+			}),
+		})
+	}
+
+	_, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, `<div>outside<button>panic: broken</button></div>`, app.InnerHTML())
+
+	var panicErr *context.PanicError
+	require.ErrorAs(t, caught, &panicErr)
+	assert.ErrorIs(t, caught, errBroken)
+
+	// Resetting renders the children again
+	broken = false
+	app.QuerySelector("button").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, `<div>outside<p>working</p></div>`, app.InnerHTML())
+}
+
+func TestErrorBoundary_Nested(t *testing.T) {
+	document, app := setupDocument(t)
+
+	broken := false
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.ErrorBoundary(func(err error, _ func() error) nodes.Child {
+			return lander.Text("outer: " + err.Error())
+		}, nodes.Children{
+			lander.Html("div", nodes.Attributes{}, nodes.Children{
+				lander.ErrorBoundary(func(err error, _ func() error) nodes.Child {
+					return lander.Text("inner: " + err.Error())
+				}, nodes.Children{
+					lander.Html("p", nodes.Attributes{}, nodes.Children{
+						lander.Component(brokenComponent, brokenProps{broken: &broken}, nodes.Children{}),
+					}),
+				}),
+			}),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, `<div><p>working</p></div>`, app.InnerHTML())
+
+	// The nearest boundary catches the error
+	broken = true
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<div>inner: panic: broken</div>`, app.InnerHTML())
+}
+
+func TestErrorBoundary_Patch(t *testing.T) {
+	document, app := setupDocument(t)
+
+	broken := true
+	inserted := false
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		children := nodes.Children{lander.Text("first")}
+		if inserted {
+			children = append(children, lander.Component(brokenComponent, brokenProps{broken: &broken}, nodes.Children{}))
+		}
+
+		return lander.ErrorBoundary(boundaryFallback, nodes.Children{
+			lander.Html("div", nodes.Attributes{}, children),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	// The component is rendered by the insert patch, while patching the DOM
+	inserted = true
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<button>panic: broken</button>`, app.InnerHTML())
+}
+
+func TestErrorBoundary_LayoutListener(t *testing.T) {
+	document, app := setupDocument(t)
+
+	failing := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		ctx.OnLayout(func() error {
+			return errBroken
+		})
+
+		return lander.Text("failing")
+	}
+
+	_, err := lander.RenderIntoDocument(document, lander.Component(
+		func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			return lander.ErrorBoundary(boundaryFallback, nodes.Children{
+				lander.Html("div", nodes.Attributes{}, nodes.Children{
+					lander.Component(failing, nodes.Props{}, nodes.Children{}),
+				}),
+			})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, `<button>error in layout listener for component. broken</button>`, app.InnerHTML())
+}
+
+func TestErrorBoundary_MountListener(t *testing.T) {
+	document, app := setupDocument(t)
+
+	failing := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		ctx.OnMount(func() error {
+			panic("mount failed")
+		})
+
+		return lander.Text("failing")
+	}
+
+	_, err := lander.RenderIntoDocument(document, lander.Component(
+		func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			return lander.ErrorBoundary(boundaryFallback, nodes.Children{
+				lander.Html("div", nodes.Attributes{}, nodes.Children{
+					lander.Component(failing, nodes.Props{}, nodes.Children{}),
+				}),
+			})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, `<button>error in mount listener for component. panic: mount failed</button>`, app.InnerHTML())
+}
+
+func TestErrorBoundary_Uncaught(t *testing.T) {
+	document, _ := setupDocument(t)
+
+	broken := true
+	assert.PanicsWithError(t, fmt.Sprintf("%s", errBroken), func() {
+		_, _ = lander.RenderIntoDocument(
+			document,
+			lander.Component(brokenComponent, brokenProps{broken: &broken}, nodes.Children{}),
+			"#app",
+		)
+	})
+}
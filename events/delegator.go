@@ -0,0 +1,469 @@
+package events
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/minivera/go-lander/dom"
+)
+
+// delegatedIDProperty is the name of the DOM property holding the ID of the elements with listeners in
+// the delegator.
+const delegatedIDProperty = "__landerID"
+
+// composingProperty is the name of the DOM property set to true on an element while the user composes
+// text in it.
+const composingProperty = "__landerComposing"
+
+// IsComposing returns true if the user is composing text in the given element with an IME, between the
+// compositionstart and compositionend events. Composition is only tracked in the apps of delegators
+// tracking it, see Delegator.TrackComposition.
+func IsComposing(element dom.Element) bool {
+	composing, _ := element.GetProperty(composingProperty).(bool)
+	return composing
+}
+
+// DispatchHandler is called by the delegator for every native event caught on the root. It should lock the
+// app, then call dispatch, which calls the listeners the event reaches and returns their errors.
+type DispatchHandler func(eventType string, dispatch func() error)
+
+// delegatedElement is an element with listeners in the delegator.
+type delegatedElement struct {
+	listeners map[string]*EventListener
+	// fired is the set of the once listeners that were already called
+	fired map[string]bool
+}
+
+// listenerCounts counts the listeners of an event type, to know which native listeners the root needs.
+type listenerCounts struct {
+	capture, bubble, active int
+}
+
+// nativeKey identifies a native listener on the root, there is at most one per event type and phase.
+type nativeKey struct {
+	eventType string
+	capture   bool
+}
+
+type nativeListener struct {
+	// listeners are the native listeners added on each root, in the order of Delegator.roots
+	listeners []dom.Listener
+	passive   bool
+}
+
+// portal is a container the children of a portal are rendered into, see Delegator.AddPortal.
+type portal struct {
+	container dom.Element
+	host      dom.Element
+	owns      func(node dom.Node) bool
+}
+
+// Delegator dispatches the DOM events of an app through a single native listener per event type and phase,
+// added on the root element of the app. The listeners of the elements are only kept in Go, adding, changing
+// or removing them never touches the DOM. When an event reaches the root, the delegator calls the listeners
+// of the target, then of its ancestors up to the root, as if the event bubbled through them. Capture
+// listeners are called from a native capture listener, from the root down to the target.
+//
+// Events that do not bubble, like focus, are caught in the capture phase and only dispatched to the capture
+// listeners of the ancestors and to their target. The native listener of an event type is passive as long as
+// all the listeners of that type are. The delegator is not thread safe, it must only be used while holding
+// the lock of the app.
+//
+// The containers of portals get the same native listeners as the root, see AddPortal. An event is only
+// dispatched by the closest root or container above its target, so it is never dispatched twice when a
+// container is an ancestor of the root, like the body.
+type Delegator struct {
+	root     dom.Element
+	handler  DispatchHandler
+	native   map[nativeKey]*nativeListener
+	counts   map[string]*listenerCounts
+	elements map[string]*delegatedElement
+	lastID   int
+
+	// roots are the root followed by the distinct containers of the portals
+	roots   []dom.Element
+	portals []*portal
+
+	composition      [][]dom.Listener
+	compositionOnEnd func() error
+}
+
+// NewDelegator creates a new delegator for the given root element. The handler is called with the dispatch
+// of the native events caught on the root.
+func NewDelegator(root dom.Element, handler DispatchHandler) *Delegator {
+	return &Delegator{
+		root:     root,
+		handler:  handler,
+		native:   map[nativeKey]*nativeListener{},
+		counts:   map[string]*listenerCounts{},
+		elements: map[string]*delegatedElement{},
+		roots:    []dom.Element{root},
+	}
+}
+
+// TrackComposition tracks the IME compositions of the elements of the app, so IsComposing can be used on
+// them. The onEnd function is called through the handler of the delegator when a composition ends, so the
+// app can sync the elements it did not update during the composition. Calling it more than once does
+// nothing.
+func (d *Delegator) TrackComposition(onEnd func() error) {
+	if d.compositionOnEnd != nil {
+		return
+	}
+
+	d.compositionOnEnd = onEnd
+	for _, root := range d.roots {
+		d.composition = append(d.composition, d.trackComposition(root))
+	}
+}
+
+// trackComposition adds the native listeners tracking the compositions on the given root.
+func (d *Delegator) trackComposition(root dom.Element) []dom.Listener {
+	var listeners []dom.Listener
+	for _, eventType := range []string{"compositionstart", "compositionend"} {
+		eventType := eventType
+		composing := eventType == "compositionstart"
+
+		// Capture listeners are called before any listener of the app, which can rely on IsComposing
+		listeners = append(listeners, root.AddEventListener(eventType, func(event dom.Event) {
+			d.handler(eventType, func() error {
+				target, ok := event.Target().(dom.Element)
+				if !ok || !d.isClosestRoot(root, target) {
+					return nil
+				}
+
+				target.SetProperty(composingProperty, composing)
+				if composing {
+					return nil
+				}
+
+				return d.compositionOnEnd()
+			})
+		}, dom.ListenerOptions{Capture: true, Passive: true}))
+	}
+
+	return listeners
+}
+
+// AddPortal registers a container the children of a portal are rendered into, the container gets the same
+// native listeners as the root. The events of the DOM nodes the portal owns in the container bubble to the
+// host, the closest DOM parent of the portal in the app, rather than to the container. The owns function
+// must return true for the DOM nodes the portal rendered directly in the container.
+//
+// The returned function removes the portal, it must be called once the children of the portal are removed
+// from the container.
+func (d *Delegator) AddPortal(container, host dom.Element, owns func(node dom.Node) bool) func() {
+	added := &portal{container: container, host: host, owns: owns}
+	d.portals = append(d.portals, added)
+
+	if d.rootIndex(container) < 0 {
+		d.roots = append(d.roots, container)
+		for key, native := range d.native {
+			native.listeners = append(native.listeners, d.addNative(container, key, native.passive))
+		}
+		if d.compositionOnEnd != nil {
+			d.composition = append(d.composition, d.trackComposition(container))
+		}
+	}
+
+	return func() {
+		d.removePortal(added)
+	}
+}
+
+// removePortal removes the given portal, its container loses its native listeners if no other portal
+// renders into it.
+func (d *Delegator) removePortal(removed *portal) {
+	for index, existing := range d.portals {
+		if existing == removed {
+			d.portals = append(d.portals[:index], d.portals[index+1:]...)
+			break
+		}
+	}
+
+	index := d.rootIndex(removed.container)
+	if index <= 0 {
+		return
+	}
+	for _, existing := range d.portals {
+		if existing.container.IsSameNode(removed.container) {
+			return
+		}
+	}
+
+	for key, native := range d.native {
+		removeNative(removed.container, key, native.listeners[index])
+		native.listeners = append(native.listeners[:index], native.listeners[index+1:]...)
+	}
+	if index < len(d.composition) {
+		for position, eventType := range []string{"compositionstart", "compositionend"} {
+			listener := d.composition[index][position]
+			removed.container.RemoveEventListener(eventType, listener)
+			listener.Release()
+		}
+		d.composition = append(d.composition[:index], d.composition[index+1:]...)
+	}
+
+	d.roots = append(d.roots[:index], d.roots[index+1:]...)
+}
+
+// rootIndex returns the index of the given element in the roots, or -1 if it is not a root.
+func (d *Delegator) rootIndex(element dom.Element) int {
+	for index, root := range d.roots {
+		if root.IsSameNode(element) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// isClosestRoot returns true if the given root is the closest root above the element, the element included.
+func (d *Delegator) isClosestRoot(root dom.Element, element dom.Element) bool {
+	// Without portals, the root is the only element with native listeners
+	if len(d.roots) == 1 {
+		return true
+	}
+
+	for current := element; current != nil; current = current.ParentNode() {
+		if d.rootIndex(current) >= 0 {
+			return current.IsSameNode(root)
+		}
+	}
+
+	return false
+}
+
+// hostOf returns the host of the portal owning the given node in its parent, or nil if the parent is not the
+// container of a portal owning the node.
+func (d *Delegator) hostOf(node dom.Node, parent dom.Element) dom.Element {
+	for _, existing := range d.portals {
+		if existing.container.IsSameNode(parent) && existing.owns(node) {
+			return existing.host
+		}
+	}
+
+	return nil
+}
+
+// SetListeners replaces the listeners of the given element with the given listeners, keyed by event type.
+// The native listeners of the root are added, removed or changed to match the options of the listeners.
+func (d *Delegator) SetListeners(element dom.Element, listeners map[string]*EventListener) {
+	id := elementID(element)
+	existing, ok := d.elements[id]
+	if !ok && len(listeners) == 0 {
+		return
+	}
+
+	if id == "" {
+		d.lastID++
+		id = strconv.Itoa(d.lastID)
+		element.SetProperty(delegatedIDProperty, id)
+	}
+
+	if !ok {
+		existing = &delegatedElement{
+			fired: map[string]bool{},
+		}
+		d.elements[id] = existing
+	}
+
+	previous := existing.listeners
+	existing.listeners = listeners
+
+	// Once listeners are armed again when removed, or when they are no longer once listeners
+	for eventType := range existing.fired {
+		if listener, found := listeners[eventType]; !found || !listener.Once {
+			delete(existing.fired, eventType)
+		}
+	}
+
+	touched := map[string]bool{}
+	d.count(previous, -1, touched)
+	d.count(listeners, 1, touched)
+	d.listen(touched)
+	if len(listeners) == 0 {
+		delete(d.elements, id)
+	}
+}
+
+// Release removes all the listeners of the given element. It must be called once the element is removed
+// from the DOM to free its listeners.
+func (d *Delegator) Release(element dom.Element) {
+	id := elementID(element)
+	existing, ok := d.elements[id]
+	if !ok {
+		return
+	}
+
+	delete(d.elements, id)
+
+	touched := map[string]bool{}
+	d.count(existing.listeners, -1, touched)
+	d.listen(touched)
+}
+
+// count adds the given listeners to the counts of their event type, or removes them if delta is negative.
+// The event types are added to touched, so their native listeners can be updated once all counts are done.
+func (d *Delegator) count(listeners map[string]*EventListener, delta int, touched map[string]bool) {
+	for eventType, listener := range listeners {
+		counts, ok := d.counts[eventType]
+		if !ok {
+			counts = &listenerCounts{}
+			d.counts[eventType] = counts
+		}
+
+		if listener.Capture {
+			counts.capture += delta
+		} else {
+			counts.bubble += delta
+		}
+		if !listener.Passive {
+			counts.active += delta
+		}
+
+		touched[eventType] = true
+	}
+}
+
+// listen adds, removes or replaces the native listeners of the root for the given event types, so they match
+// the listeners currently registered.
+func (d *Delegator) listen(eventTypes map[string]bool) {
+	for eventType := range eventTypes {
+		counts := d.counts[eventType]
+		total := counts.capture + counts.bubble
+		bubbles := dom.Bubbles(eventType)
+		passive := counts.active == 0
+
+		// Events that do not bubble never reach the root otherwise
+		d.listenPhase(eventType, true, passive, total > 0 && (counts.capture > 0 || !bubbles))
+		d.listenPhase(eventType, false, passive, counts.bubble > 0 && bubbles)
+
+		if total == 0 {
+			delete(d.counts, eventType)
+		}
+	}
+}
+
+func (d *Delegator) listenPhase(eventType string, capture, passive, needed bool) {
+	key := nativeKey{eventType: eventType, capture: capture}
+	existing, ok := d.native[key]
+	if ok && (!needed || existing.passive != passive) {
+		for index, root := range d.roots {
+			removeNative(root, key, existing.listeners[index])
+		}
+		delete(d.native, key)
+		ok = false
+	}
+
+	if !needed || ok {
+		return
+	}
+
+	native := &nativeListener{passive: passive}
+	for _, root := range d.roots {
+		native.listeners = append(native.listeners, d.addNative(root, key, passive))
+	}
+	d.native[key] = native
+}
+
+// addNative adds the native listener of the given event type and phase on a root.
+func (d *Delegator) addNative(root dom.Element, key nativeKey, passive bool) dom.Listener {
+	return root.AddEventListener(key.eventType, func(event dom.Event) {
+		d.handler(key.eventType, func() error {
+			return d.dispatch(root, event, key.capture)
+		})
+	}, dom.ListenerOptions{Capture: key.capture, Passive: passive})
+}
+
+// removeNative removes a native listener added with addNative from a root.
+func removeNative(root dom.Element, key nativeKey, listener dom.Listener) {
+	root.RemoveEventListener(key.eventType, listener)
+	listener.Release()
+}
+
+// dispatch calls the listeners reached by the native event in the given phase, until a listener stops the
+// propagation. All listeners are called even if some fail, their errors are joined. The event is ignored
+// if the given root, where it was caught, is not the closest root above its target.
+func (d *Delegator) dispatch(root dom.Element, event dom.Event, capture bool) error {
+	var current dom.Element
+	switch typed := event.Target().(type) {
+	case dom.Element:
+		current = typed
+	case dom.Node:
+		current = typed.ParentNode()
+	}
+	if current == nil || !d.isClosestRoot(root, current) {
+		return nil
+	}
+
+	// The path goes from the target up to the root, excluding the root. The nodes owned by a portal continue
+	// to its host rather than to its container.
+	var path []dom.Element
+	for current != nil && !current.IsSameNode(d.root) {
+		path = append(path, current)
+
+		parent := current.ParentNode()
+		if parent != nil {
+			if host := d.hostOf(current, parent); host != nil {
+				parent = host
+			}
+		}
+		current = parent
+	}
+
+	// Events outside the app, in the container of a portal, are never dispatched
+	if current == nil || len(path) == 0 {
+		return nil
+	}
+
+	delegated := NewDOMEvent(event)
+	var errs []error
+	if capture {
+		for index := len(path) - 1; index >= 0 && !delegated.stopped; index-- {
+			errs = d.call(path[index], delegated, true, errs)
+		}
+
+		// The native listener of events that do not bubble also handles the target
+		if !dom.Bubbles(event.Type()) && !delegated.stopped {
+			errs = d.call(path[0], delegated, false, errs)
+		}
+	} else {
+		for index := 0; index < len(path) && !delegated.stopped; index++ {
+			errs = d.call(path[index], delegated, false, errs)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// call calls the listener of the element for the event in the given phase, if any. The error of the listener
+// is appended to errs.
+func (d *Delegator) call(element dom.Element, event *DOMEvent, capture bool, errs []error) []error {
+	delegated, ok := d.elements[elementID(element)]
+	if !ok {
+		return errs
+	}
+
+	eventType := event.Type()
+	listener, ok := delegated.listeners[eventType]
+	if !ok || listener.Capture != capture || (listener.Once && delegated.fired[eventType]) {
+		return errs
+	}
+
+	if listener.Once {
+		delegated.fired[eventType] = true
+	}
+
+	event.currentTarget = element
+	event.passive = listener.Passive
+	if err := listener.Func(event); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+func elementID(element dom.Element) string {
+	id, _ := element.GetProperty(delegatedIDProperty).(string)
+	return id
+}
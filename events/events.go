@@ -1,53 +1,191 @@
-//go:build js && wasm
-
 package events
 
 import (
-	"syscall/js"
+	"github.com/minivera/go-lander/dom"
 )
 
 // EventListenerFunc is the type definition for a DOM event listener in javascript. Use this type
 // to validate the event listeners passed as props to DOM elements.
 type EventListenerFunc func(*DOMEvent) error
 
-// EventListener is the concrete definition of a DOM event listener. It is used to keep track of the
-// js function associated to the DOM element, and its underlying listener function. This allows the
-// patches to remove the listeners safely and revoke the function to avoid any memory leaks.
+// EventListener is the concrete definition of a DOM event listener. It associates the listener function
+// to the name of the event it listens to, with the options it was created with. Listeners are not added on
+// the DOM element directly, they are registered in the Delegator of the app.
 type EventListener struct {
-	Name    string
-	Func    EventListenerFunc
-	Wrapper js.Func
+	Name string
+	Func EventListenerFunc
+
+	// Capture calls the listener in the capture phase, before the listeners of the descendants.
+	Capture bool
+	// Passive promises the listener never prevents the default behavior of the event, PreventDefault is
+	// ignored.
+	Passive bool
+	// Once calls the listener at most once. The listener is armed again if it is removed from the element
+	// on a render, then added back.
+	Once bool
+}
+
+// ListenerOption configures an event listener created with Listener.
+type ListenerOption func(listener *EventListener)
+
+// Capture calls the listener in the capture phase, from the root down to the target, before the listeners
+// of the descendants of the element. Events that do not bubble, such as focus, can be listened to on the
+// ancestors of their target this way.
+func Capture() ListenerOption {
+	return func(listener *EventListener) {
+		listener.Capture = true
+	}
+}
+
+// Passive marks the listener as never preventing the default behavior of the event, PreventDefault is then
+// ignored. Scroll, wheel and touch listeners should be passive so the browser does not wait for them.
+func Passive() ListenerOption {
+	return func(listener *EventListener) {
+		listener.Passive = true
+	}
+}
+
+// Once calls the listener at most once for the element. The listener is armed again if it is removed from
+// the attributes of the element on a render, then added back.
+func Once() ListenerOption {
+	return func(listener *EventListener) {
+		listener.Once = true
+	}
+}
+
+// ListenerFuncs is the set of the function types accepted as event listeners, see ListenerFunc.
+type ListenerFuncs interface {
+	EventListenerFunc | func(*DOMEvent) error | func(*MouseEvent) error | func(*KeyboardEvent) error |
+		func(*InputEvent) error | func(*FocusEvent) error | func(*SubmitEvent) error | func(*DragEvent) error
+}
+
+// Listener creates an event listener with the given options, which can be used as the value of an event in
+// the attributes of an HTML node.
+//
+//	lander.Html("div", nodes.Attributes{
+//		"scroll": events.Listener(onScroll, events.Passive()),
+//	}, children)
+func Listener[T ListenerFuncs](listener T, options ...ListenerOption) *EventListener {
+	converted, _ := ListenerFunc(listener)
+	created := &EventListener{
+		Func: converted,
+	}
+
+	for _, option := range options {
+		option(created)
+	}
+
+	return created
 }
 
 // DOMEvent is the base struct that contains the data for a DOM event triggered on the client.
-// it contains the reference to the `this` object referencing the DOM node and the definition for the
-// DOM event as a js.Value.
+// it contains the reference to the event dispatched by the DOM implementation, which can be used
+// to access the target of the event.
 type DOMEvent struct {
-	browserEvent js.Value
-	this         js.Value
+	event         dom.Event
+	currentTarget dom.Node
+	passive       bool
+	stopped       bool
 }
 
 // NewDOMEvent generates a new DOM event to be passed to an event listener.
-func NewDOMEvent(browserEvent, this js.Value) *DOMEvent {
+func NewDOMEvent(event dom.Event) *DOMEvent {
 	return &DOMEvent{
-		browserEvent: browserEvent,
-		this:         this,
+		event:         event,
+		currentTarget: event.CurrentTarget(),
+	}
+}
+
+// Event returns the underlying DOM event, as given by the DOM implementation.
+func (e *DOMEvent) Event() dom.Event {
+	return e.event
+}
+
+// Type returns the type of the event, such as "click".
+func (e *DOMEvent) Type() string {
+	return e.event.Type()
+}
+
+// Target returns the node the event was dispatched to.
+func (e *DOMEvent) Target() *Target {
+	return &Target{Node: e.event.Target()}
+}
+
+// CurrentTarget returns the element whose listener is currently being executed. Since listeners are
+// delegated to the root of the app, this is not the current target of the underlying DOM event.
+func (e *DOMEvent) CurrentTarget() *Target {
+	return &Target{Node: e.currentTarget}
+}
+
+// StopPropagation stops the event from reaching the listeners of the ancestors of the current target. The
+// listeners of the current target are still called.
+func (e *DOMEvent) StopPropagation() {
+	e.stopped = true
+	e.event.StopPropagation()
+}
+
+// StopImmediatePropagation stops the event from reaching the listeners of the ancestors of the current target,
+// like StopPropagation. Elements have a single listener per event type, so there are no other lander listeners
+// to skip on the current target. The underlying event stops the other native listeners of the root.
+func (e *DOMEvent) StopImmediatePropagation() {
+	e.stopped = true
+	e.event.StopImmediatePropagation()
+}
+
+// IsPropagationStopped returns true once StopPropagation or StopImmediatePropagation has been called on the
+// event.
+func (e *DOMEvent) IsPropagationStopped() bool {
+	return e.stopped
+}
+
+// Target is a node an event was dispatched to, with helpers to read the state of form elements.
+type Target struct {
+	dom.Node
+}
+
+// Element returns the target as an element, or nil if the target is not an element, such as a text node.
+func (t *Target) Element() dom.Element {
+	element, _ := t.Node.(dom.Element)
+	return element
+}
+
+// Value returns the value of the target, such as the text of an input, or an empty string if it has none.
+func (t *Target) Value() string {
+	if element := t.Element(); element != nil {
+		if value, ok := element.GetProperty("value").(string); ok {
+			return value
+		}
 	}
+
+	return ""
 }
 
-// JSEvent returns the browser event value which contains what would usually be the first argument
-// of an event listener.
-func (e *DOMEvent) JSEvent() js.Value {
-	return e.browserEvent
+// Checked returns true if the target is a checked checkbox or radio input.
+func (t *Target) Checked() bool {
+	if element := t.Element(); element != nil {
+		checked, _ := element.GetProperty("checked").(bool)
+		return checked
+	}
+
+	return false
 }
 
-// JSEventThis returns the value of the "this" variable for the Javascript event listener.
-func (e *DOMEvent) JSEventThis() js.Value {
-	return e.this
+// Files returns the files selected in the target, if it is a file input.
+func (t *Target) Files() []dom.File {
+	if element := t.Element(); element != nil {
+		files, _ := element.GetProperty("files").([]dom.File)
+		return files
+	}
+
+	return nil
 }
 
 // PreventDefault calls preventDefault() on the underlying DOM event. Is thread safe, but may only be used
-// in the same goroutine to avoid memory leaks.
+// in the same goroutine to avoid memory leaks. Ignored in passive listeners.
 func (e *DOMEvent) PreventDefault() {
-	e.browserEvent.Call("preventDefault")
+	if e.passive {
+		return
+	}
+
+	e.event.PreventDefault()
 }
@@ -0,0 +1,20 @@
+//go:build js && wasm
+
+package events
+
+import (
+	"syscall/js"
+
+	"github.com/minivera/go-lander/dom"
+)
+
+// JSEvent returns the browser event value which contains what would usually be the first argument
+// of an event listener.
+func (e *DOMEvent) JSEvent() js.Value {
+	return dom.JSValue(e.event)
+}
+
+// JSEventThis returns the value of the "this" variable for the Javascript event listener.
+func (e *DOMEvent) JSEventThis() js.Value {
+	return dom.JSValue(e.currentTarget)
+}
@@ -0,0 +1,231 @@
+package events
+
+import (
+	"github.com/minivera/go-lander/dom"
+)
+
+// Modifiers is the state of the modifier keys when a mouse or keyboard event was dispatched.
+type Modifiers struct {
+	AltKey   bool
+	CtrlKey  bool
+	MetaKey  bool
+	ShiftKey bool
+}
+
+// MouseEvent is a DOM event triggered by a pointing device, such as "click" or "mousemove".
+type MouseEvent struct {
+	*DOMEvent
+	Modifiers
+
+	// ClientX and ClientY are the coordinates of the pointer relative to the viewport.
+	ClientX, ClientY float64
+	// PageX and PageY are the coordinates of the pointer relative to the whole document.
+	PageX, PageY float64
+	// OffsetX and OffsetY are the coordinates of the pointer relative to the target.
+	OffsetX, OffsetY float64
+	// Button is the button that changed state, 0 being the main button.
+	Button int
+	// Buttons is the bitmask of the buttons pressed when the event was dispatched.
+	Buttons int
+	// RelatedTarget is the secondary target of the event, such as the node left in "mouseover", if any.
+	RelatedTarget dom.Node
+}
+
+// NewMouseEvent reads the mouse event from the properties of the given DOM event.
+func NewMouseEvent(event *DOMEvent) *MouseEvent {
+	return &MouseEvent{
+		DOMEvent:      event,
+		Modifiers:     event.modifiers(),
+		ClientX:       event.numberProperty("clientX"),
+		ClientY:       event.numberProperty("clientY"),
+		PageX:         event.numberProperty("pageX"),
+		PageY:         event.numberProperty("pageY"),
+		OffsetX:       event.numberProperty("offsetX"),
+		OffsetY:       event.numberProperty("offsetY"),
+		Button:        int(event.numberProperty("button")),
+		Buttons:       int(event.numberProperty("buttons")),
+		RelatedTarget: event.nodeProperty("relatedTarget"),
+	}
+}
+
+// KeyboardEvent is a DOM event triggered by a key, such as "keydown".
+type KeyboardEvent struct {
+	*DOMEvent
+	Modifiers
+
+	// Key is the value of the key, such as "a" or "Enter".
+	Key string
+	// Code is the physical key on the keyboard, such as "KeyA", regardless of the layout.
+	Code string
+	// Repeat is true if the key is held down and the event is repeated.
+	Repeat bool
+	// IsComposing is true if the event was dispatched while composing text, such as with an IME.
+	IsComposing bool
+}
+
+// NewKeyboardEvent reads the keyboard event from the properties of the given DOM event.
+func NewKeyboardEvent(event *DOMEvent) *KeyboardEvent {
+	return &KeyboardEvent{
+		DOMEvent:    event,
+		Modifiers:   event.modifiers(),
+		Key:         event.stringProperty("key"),
+		Code:        event.stringProperty("code"),
+		Repeat:      event.boolProperty("repeat"),
+		IsComposing: event.boolProperty("isComposing"),
+	}
+}
+
+// InputEvent is a DOM event triggered when the value of an editable element changes, such as "input".
+// Use Target().Value() to read the new value.
+type InputEvent struct {
+	*DOMEvent
+
+	// Data is the inserted text, if any.
+	Data string
+	// InputType is the type of change, such as "insertText" or "deleteContentBackward".
+	InputType string
+	// IsComposing is true if the event was dispatched while composing text, such as with an IME.
+	IsComposing bool
+}
+
+// NewInputEvent reads the input event from the properties of the given DOM event.
+func NewInputEvent(event *DOMEvent) *InputEvent {
+	return &InputEvent{
+		DOMEvent:    event,
+		Data:        event.stringProperty("data"),
+		InputType:   event.stringProperty("inputType"),
+		IsComposing: event.boolProperty("isComposing"),
+	}
+}
+
+// FocusEvent is a DOM event triggered when an element gains or loses focus, such as "focus" or "blur".
+type FocusEvent struct {
+	*DOMEvent
+
+	// RelatedTarget is the node losing the focus on "focus", or gaining it on "blur", if any.
+	RelatedTarget dom.Node
+}
+
+// NewFocusEvent reads the focus event from the properties of the given DOM event.
+func NewFocusEvent(event *DOMEvent) *FocusEvent {
+	return &FocusEvent{
+		DOMEvent:      event,
+		RelatedTarget: event.nodeProperty("relatedTarget"),
+	}
+}
+
+// SubmitEvent is the DOM event triggered when a form is submitted.
+type SubmitEvent struct {
+	*DOMEvent
+
+	// Submitter is the element that submitted the form, such as a submit button, if any.
+	Submitter dom.Element
+}
+
+// NewSubmitEvent reads the submit event from the properties of the given DOM event.
+func NewSubmitEvent(event *DOMEvent) *SubmitEvent {
+	submitter, _ := event.nodeProperty("submitter").(dom.Element)
+
+	return &SubmitEvent{
+		DOMEvent:  event,
+		Submitter: submitter,
+	}
+}
+
+// DragEvent is a DOM event triggered during a drag and drop operation, such as "dragstart" or "drop".
+type DragEvent struct {
+	MouseEvent
+
+	// DataTransfer is the data being dragged, if any.
+	DataTransfer *dom.DataTransfer
+}
+
+// NewDragEvent reads the drag event from the properties of the given DOM event.
+func NewDragEvent(event *DOMEvent) *DragEvent {
+	dataTransfer, _ := event.event.GetProperty("dataTransfer").(*dom.DataTransfer)
+
+	return &DragEvent{
+		MouseEvent:   *NewMouseEvent(event),
+		DataTransfer: dataTransfer,
+	}
+}
+
+// ListenerFunc converts the given value to an event listener function. On top of EventListenerFunc, the
+// value can be a function taking any of the typed events, such as `func(*events.MouseEvent) error`, the
+// typed event is then read from the DOM event before calling it. Returns false if the value is not a
+// supported event listener.
+func ListenerFunc(value interface{}) (EventListenerFunc, bool) {
+	switch casted := value.(type) {
+	case EventListenerFunc:
+		return casted, true
+	case func(*DOMEvent) error:
+		return casted, true
+	case func(*MouseEvent) error:
+		return func(event *DOMEvent) error {
+			return casted(NewMouseEvent(event))
+		}, true
+	case func(*KeyboardEvent) error:
+		return func(event *DOMEvent) error {
+			return casted(NewKeyboardEvent(event))
+		}, true
+	case func(*InputEvent) error:
+		return func(event *DOMEvent) error {
+			return casted(NewInputEvent(event))
+		}, true
+	case func(*FocusEvent) error:
+		return func(event *DOMEvent) error {
+			return casted(NewFocusEvent(event))
+		}, true
+	case func(*SubmitEvent) error:
+		return func(event *DOMEvent) error {
+			return casted(NewSubmitEvent(event))
+		}, true
+	case func(*DragEvent) error:
+		return func(event *DOMEvent) error {
+			return casted(NewDragEvent(event))
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+func (e *DOMEvent) modifiers() Modifiers {
+	return Modifiers{
+		AltKey:   e.boolProperty("altKey"),
+		CtrlKey:  e.boolProperty("ctrlKey"),
+		MetaKey:  e.boolProperty("metaKey"),
+		ShiftKey: e.boolProperty("shiftKey"),
+	}
+}
+
+func (e *DOMEvent) stringProperty(name string) string {
+	value, _ := e.event.GetProperty(name).(string)
+	return value
+}
+
+func (e *DOMEvent) boolProperty(name string) bool {
+	value, _ := e.event.GetProperty(name).(bool)
+	return value
+}
+
+// numberProperty returns the number under the given name, the JS implementation returns all numbers as
+// float64 while the in-memory events may use any numeric type.
+func (e *DOMEvent) numberProperty(name string) float64 {
+	switch value := e.event.GetProperty(name).(type) {
+	case float64:
+		return value
+	case float32:
+		return float64(value)
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	default:
+		return 0
+	}
+}
+
+func (e *DOMEvent) nodeProperty(name string) dom.Node {
+	value, _ := e.event.GetProperty(name).(dom.Node)
+	return value
+}
@@ -0,0 +1,391 @@
+package lander_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/nodes"
+)
+
+func TestEvents_Bubbling(t *testing.T) {
+	document, app := setupDocument(t)
+
+	var calls []string
+	stop := false
+	record := func(name string) events.EventListenerFunc {
+		return func(event *events.DOMEvent) error {
+			calls = append(calls, name)
+			assert.True(t, event.Target().IsSameNode(app.QuerySelector("button")))
+			assert.Equal(t, name, event.CurrentTarget().Element().TagName())
+			if stop && name == "section" {
+				event.StopPropagation()
+			}
+			return nil
+		}
+	}
+
+	_, err := lander.RenderIntoDocument(document, lander.Component(
+		func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			return lander.Html("div", nodes.Attributes{"click": record("div")}, nodes.Children{
+				lander.Html("section", nodes.Attributes{"click": record("section")}, nodes.Children{
+					lander.Html("p", nodes.Attributes{}, nodes.Children{
+						lander.Html("button", nodes.Attributes{"click": record("button")}, nodes.Children{
+							lander.Text("click"),
+						}),
+					}),
+				}),
+			})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+
+	// Listeners of the ancestors are called in order, elements without listeners are skipped
+	app.QuerySelector("button").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, []string{"button", "section", "div"}, calls)
+
+	// Stopping the propagation keeps the event from reaching the ancestors
+	calls = nil
+	stop = true
+	event := app.QuerySelector("button").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, []string{"button", "section"}, calls)
+	assert.True(t, event.PropagationStopped)
+}
+
+func TestEvents_NonBubbling(t *testing.T) {
+	document, app := setupDocument(t)
+
+	var calls []string
+	_, err := lander.RenderIntoDocument(document, lander.Component(
+		func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			return lander.Html("form", nodes.Attributes{
+				"focus": func(*events.DOMEvent) error {
+					calls = append(calls, "form")
+					return nil
+				},
+			}, nodes.Children{
+				lander.Html("input", nodes.Attributes{
+					"focus": func(*events.DOMEvent) error {
+						calls = append(calls, "input")
+						return nil
+					},
+				}, nodes.Children{}),
+			})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+
+	// Focus does not bubble, only the target is called
+	app.QuerySelector("input").(*dom.MemoryElement).Dispatch("focus")
+	assert.Equal(t, []string{"input"}, calls)
+}
+
+func TestEvents_ChangingListeners(t *testing.T) {
+	document, app := setupDocument(t)
+
+	count := 0
+	show := false
+	_, err := lander.RenderIntoDocument(document, lander.Component(
+		func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			children := nodes.Children{
+				lander.Html("button", nodes.Attributes{
+					"id": "toggle",
+					"click": func(*events.DOMEvent) error {
+						return ctx.UpdateWith(func() {
+							show = !show
+						})
+					},
+				}, nodes.Children{}),
+			}
+			if show {
+				children = append(children, lander.Html("button", nodes.Attributes{
+					"id": "inserted",
+					"click": func(*events.DOMEvent) error {
+						count++
+						return nil
+					},
+				}, nodes.Children{}))
+			}
+
+			return lander.Html("div", nodes.Attributes{}, children)
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+
+	// Inserted elements get their listeners
+	app.QuerySelector("#toggle").(*dom.MemoryElement).Dispatch("click")
+	inserted := app.QuerySelector("#inserted").(*dom.MemoryElement)
+	inserted.Dispatch("click")
+	assert.Equal(t, 1, count)
+
+	// Removed elements lose their listeners, even if the event reaches the root
+	app.QuerySelector("#toggle").(*dom.MemoryElement).Dispatch("click")
+	assert.Nil(t, app.QuerySelector("#inserted"))
+	app.AppendChild(inserted)
+	inserted.Dispatch("click")
+	assert.Equal(t, 1, count)
+
+	// Renders never add native listeners
+	assert.Equal(t, 1, app.ListenerCount("click"))
+	assert.Equal(t, 0, inserted.ListenerCount("click"))
+}
+
+func TestEvents_Typed(t *testing.T) {
+	document, app := setupDocument(t)
+
+	var received []interface{}
+	_, err := lander.RenderIntoDocument(document, lander.Component(
+		func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			return lander.Html("form", nodes.Attributes{
+				"submit": func(event *events.SubmitEvent) error {
+					event.PreventDefault()
+					received = append(received, event.Submitter.TagName())
+					return nil
+				},
+			}, nodes.Children{
+				lander.Html("input", nodes.Attributes{
+					"keydown": func(event *events.KeyboardEvent) error {
+						received = append(received, event.Key, event.Code, event.ShiftKey, event.CtrlKey)
+						return nil
+					},
+					"input": func(event *events.InputEvent) error {
+						received = append(received, event.Data, event.Target().Value())
+						return nil
+					},
+					"change": func(event *events.DOMEvent) error {
+						received = append(received, event.Target().Checked(), event.Target().Files())
+						return nil
+					},
+				}, nodes.Children{}),
+				lander.Html("button", nodes.Attributes{
+					"click": func(event *events.MouseEvent) error {
+						received = append(received, event.ClientX, event.Button, event.AltKey)
+						return nil
+					},
+					"drop": func(event *events.DragEvent) error {
+						received = append(received, event.DataTransfer.Data["text/plain"], event.ClientY)
+						return nil
+					},
+					"focus": func(event *events.FocusEvent) error {
+						received = append(received, event.RelatedTarget)
+						return nil
+					},
+				}, nodes.Children{}),
+			})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+
+	input := app.QuerySelector("input").(*dom.MemoryElement)
+	button := app.QuerySelector("button").(*dom.MemoryElement)
+
+	input.DispatchWith("keydown", map[string]interface{}{"key": "A", "code": "KeyA", "shiftKey": true})
+	assert.Equal(t, []interface{}{"A", "KeyA", true, false}, received)
+
+	received = nil
+	input.SetProperty("value", "hello")
+	input.DispatchWith("input", map[string]interface{}{"data": "o", "inputType": "insertText"})
+	assert.Equal(t, []interface{}{"o", "hello"}, received)
+
+	received = nil
+	files := []dom.File{{Name: "notes.txt", Type: "text/plain", Size: 12}}
+	input.SetProperty("checked", true)
+	input.SetProperty("files", files)
+	input.Dispatch("change")
+	assert.Equal(t, []interface{}{true, files}, received)
+
+	received = nil
+	button.DispatchWith("click", map[string]interface{}{"clientX": 12.5, "button": 2, "altKey": true})
+	assert.Equal(t, []interface{}{12.5, 2, true}, received)
+
+	received = nil
+	button.DispatchWith("drop", map[string]interface{}{
+		"clientY":      4,
+		"dataTransfer": &dom.DataTransfer{Types: []string{"text/plain"}, Data: map[string]string{"text/plain": "dropped"}},
+	})
+	assert.Equal(t, []interface{}{"dropped", 4.0}, received)
+
+	received = nil
+	button.DispatchWith("focus", map[string]interface{}{"relatedTarget": input})
+	require.Len(t, received, 1)
+	assert.True(t, input.IsSameNode(received[0].(dom.Node)))
+
+	received = nil
+	event := app.QuerySelector("form").(*dom.MemoryElement).DispatchWith("submit", map[string]interface{}{
+		"submitter": button,
+	})
+	assert.Equal(t, []interface{}{"button"}, received)
+	assert.True(t, event.DefaultPrevented)
+}
+
+func TestEvents_StopImmediatePropagation(t *testing.T) {
+	document, app := setupDocument(t)
+
+	var calls []string
+	_, err := lander.RenderIntoDocument(document, lander.Component(
+		func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			return lander.Html("div", nodes.Attributes{
+				"click": func(*events.DOMEvent) error {
+					calls = append(calls, "div")
+					return nil
+				},
+			}, nodes.Children{
+				lander.Html("button", nodes.Attributes{
+					"click": func(event *events.MouseEvent) error {
+						calls = append(calls, "button")
+						event.StopImmediatePropagation()
+						return nil
+					},
+				}, nodes.Children{}),
+			})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+
+	event := app.QuerySelector("button").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, []string{"button"}, calls)
+	assert.True(t, event.ImmediatePropagationStopped)
+}
+
+func TestEvents_ListenerOptions(t *testing.T) {
+	document, app := setupDocument(t)
+
+	var calls []string
+	record := func(name string, prevent bool) events.EventListenerFunc {
+		return func(event *events.DOMEvent) error {
+			calls = append(calls, name)
+			if prevent {
+				event.PreventDefault()
+			}
+			return nil
+		}
+	}
+
+	capture := true
+	passive := true
+	once := true
+	var update func(func()) error
+	_, err := lander.RenderIntoDocument(document, lander.Component(
+		func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			update = ctx.UpdateWith
+			divOptions := []events.ListenerOption{}
+			if capture {
+				divOptions = append(divOptions, events.Capture())
+			}
+			scrollOptions := []events.ListenerOption{}
+			if passive {
+				scrollOptions = append(scrollOptions, events.Passive())
+			}
+			attributes := nodes.Attributes{
+				"click":  events.Listener(record("button", false)),
+				"scroll": events.Listener(record("scroll", true), scrollOptions...),
+			}
+			if once {
+				attributes["keydown"] = events.Listener(record("once", false), events.Once())
+			}
+
+			return lander.Html("div", nodes.Attributes{
+				"click": events.Listener(record("div", false), divOptions...),
+				"focus": events.Listener(record("div focus", false), events.Capture()),
+			}, nodes.Children{
+				lander.Html("button", attributes, nodes.Children{}),
+			})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+
+	button := app.QuerySelector("button").(*dom.MemoryElement)
+
+	// Capture listeners of the ancestors are called before the target
+	button.Dispatch("click")
+	assert.Equal(t, []string{"div", "button"}, calls)
+
+	// Capture listeners of the ancestors catch events that do not bubble
+	calls = nil
+	button.Dispatch("focus")
+	assert.Equal(t, []string{"div focus"}, calls)
+
+	// Passive listeners cannot prevent the default behavior, the native listener is passive too
+	calls = nil
+	event := button.Dispatch("scroll")
+	assert.Equal(t, []string{"scroll"}, calls)
+	assert.False(t, event.DefaultPrevented)
+	assert.Equal(t, []dom.ListenerOptions{{Capture: true, Passive: true}}, app.ListenerOptions("scroll"))
+
+	// Once listeners are only called once
+	calls = nil
+	button.Dispatch("keydown")
+	button.Dispatch("keydown")
+	assert.Equal(t, []string{"once"}, calls)
+
+	// Changing the options between renders updates the listeners
+	require.NoError(t, update(func() {
+		capture = false
+		passive = false
+	}))
+	calls = nil
+	button.Dispatch("click")
+	assert.Equal(t, []string{"button", "div"}, calls)
+
+	calls = nil
+	event = button.Dispatch("scroll")
+	assert.Equal(t, []string{"scroll"}, calls)
+	assert.True(t, event.DefaultPrevented)
+	assert.Equal(t, []dom.ListenerOptions{{Capture: true}}, app.ListenerOptions("scroll"))
+
+	// Once listeners are armed again once removed and added back
+	require.NoError(t, update(func() {
+		once = false
+	}))
+	require.NoError(t, update(func() {
+		once = true
+	}))
+	calls = nil
+	button.Dispatch("keydown")
+	button.Dispatch("keydown")
+	assert.Equal(t, []string{"once"}, calls)
+}
+
+func TestEvents_ListenerError(t *testing.T) {
+	document, app := setupDocument(t)
+
+	failure := errors.New("listener failed")
+	var reported []error
+	_, err := lander.RenderIntoDocument(document, lander.Component(
+		func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			return lander.Html("button", nodes.Attributes{
+				"click": func(*events.DOMEvent) error {
+					return failure
+				},
+			}, nodes.Children{})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app", lander.WithErrorHandler(func(err error) {
+		reported = append(reported, err)
+	}))
+	require.NoError(t, err)
+
+	// Listeners have no caller to return their error to, it is sent to the error handler
+	app.QuerySelector("button").(*dom.MemoryElement).Dispatch("click")
+	require.Len(t, reported, 1)
+	assert.ErrorIs(t, reported[0], failure)
+}
@@ -76,22 +76,35 @@ func (a *fetchApp) todosList(_ context.Context, _ nodes.Props, _ nodes.Children)
 
 func (a *fetchApp) render(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
 	ctx.OnMount(func() error {
-		// Simulate some loading
-		time.Sleep(2 * time.Second)
-
-		resp, err := http.Get("https://dummyjson.com/todos")
-		if err != nil {
-			return err
-		}
-
-		err = json.NewDecoder(resp.Body).Decode(&a.loadedTodos)
-		if err != nil {
-			return err
-		}
-
-		a.loaded = true
-
-		return a.env.Update()
+		// Load in a goroutine, mount listeners run in the render loop and would block the app
+		go func() {
+			// Simulate some loading
+			time.Sleep(2 * time.Second)
+
+			resp, err := http.Get("https://dummyjson.com/todos")
+			if err != nil {
+				fmt.Printf("failed to fetch: %s\n", err)
+				return
+			}
+
+			var loaded todos
+			err = json.NewDecoder(resp.Body).Decode(&loaded)
+			if err != nil {
+				fmt.Printf("failed to decode: %s\n", err)
+				return
+			}
+
+			// Change the state in the render loop so it never races with a render
+			err = a.env.UpdateWith(func() {
+				a.loadedTodos = loaded
+				a.loaded = true
+			})
+			if err != nil {
+				fmt.Printf("failed to update: %s\n", err)
+			}
+		}()
+
+		return nil
 	})
 
 	var content nodes.Node = lander.Html("marquee", nodes.Attributes{}, nodes.Children{
@@ -24,30 +24,39 @@ func fetchApp(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child
 	currentTodo, setTodo, _ := hooks.UseState[*todo](ctx, nil)
 
 	hooks.UseEffect(ctx, func() (func() error, error) {
-		// Simulate some loading
-		time.Sleep(2 * time.Second)
+		// Load in a goroutine, effects run in the render loop and would block the app
+		go func() {
+			// Simulate some loading
+			time.Sleep(2 * time.Second)
 
-		resp, err := http.Get("https://dummyjson.com/todos/1")
-		if err != nil {
-			return nil, err
-		}
+			resp, err := http.Get("https://dummyjson.com/todos/1")
+			if err != nil {
+				fmt.Printf("failed to fetch: %s\n", err)
+				return
+			}
 
-		loadedTodo := &todo{}
-		err = json.NewDecoder(resp.Body).Decode(loadedTodo)
-		if err != nil {
-			return nil, err
-		}
+			loadedTodo := &todo{}
+			err = json.NewDecoder(resp.Body).Decode(loadedTodo)
+			if err != nil {
+				fmt.Printf("failed to decode: %s\n", err)
+				return
+			}
 
-		err = setTodo(func(_ *todo) *todo {
-			return loadedTodo
-		})
-		if err != nil {
-			return nil, err
-		}
+			// Setters are safe to call from a goroutine, both changes are rendered together
+			err = setTodo(func(_ *todo) *todo {
+				return loadedTodo
+			})
+			if err == nil {
+				err = setLoading(func(_ bool) bool {
+					return false
+				})
+			}
+			if err != nil {
+				fmt.Printf("failed to update: %s\n", err)
+			}
+		}()
 
-		return nil, setLoading(func(_ bool) bool {
-			return false
-		})
+		return nil, nil
 	}, []interface{}{})
 
 	content := lander.Html("marquee", nodes.Attributes{}, nodes.Children{
@@ -30,8 +30,9 @@ func (f *loginForm) render(_ context.Context, _ nodes.Props, _ nodes.Children) n
 			lander.Html("input", nodes.Attributes{
 				"name":        "username",
 				"placeholder": "Enter Username",
-				"change": func(event *events.DOMEvent) error {
-					f.username = event.JSEvent().Get("target").Get("value").String()
+				"value":       f.username,
+				"input": func(event *events.InputEvent) error {
+					f.username = event.Target().Value()
 					return f.env.Update()
 				},
 			}, nodes.Children{}),
@@ -44,8 +45,9 @@ func (f *loginForm) render(_ context.Context, _ nodes.Props, _ nodes.Children) n
 				"name":        "password",
 				"placeholder": "Enter Password",
 				"type":        "password",
-				"change": func(event *events.DOMEvent) error {
-					f.password = event.JSEvent().Get("target").Get("value").String()
+				"value":       f.password,
+				"input": func(event *events.InputEvent) error {
+					f.password = event.Target().Value()
 					return f.env.Update()
 				},
 			}, nodes.Children{}),
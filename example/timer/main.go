@@ -22,11 +22,13 @@ type timerApp struct {
 func (a *timerApp) runTimer() {
 	if a.run {
 		js.Global().Call("setTimeout", js.FuncOf(func(this js.Value, args []js.Value) any {
-			// Track with a time and not a pure floats since renders are synchronous. The time between
+			// Track with a time and not a pure floats since renders are scheduled. The time between
 			// timeouts and the real time might be different.
-			a.time += time.Now().Sub(a.last).Seconds()
-			a.last = time.Now()
-			if err := a.env.Update(); err != nil {
+			now := time.Now()
+			if err := a.env.UpdateWith(func() {
+				a.time += now.Sub(a.last).Seconds()
+				a.last = now
+			}); err != nil {
 				panic("something went wrong with the time")
 			}
 
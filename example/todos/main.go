@@ -26,7 +26,7 @@ func (a *addTodoForm) render(_ context.Context, props addTodoFormProps, _ nodes.
 		lander.Html("input", nodes.Attributes{
 			"value": a.value,
 			"change": func(event *events.DOMEvent) error {
-				a.value = event.JSEvent().Get("target").Get("value").String()
+				a.value = event.Target().Value()
 				return a.env.Update()
 			},
 		}, nodes.Children{}).Style("margin-right: 1rem;"),
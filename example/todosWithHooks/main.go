@@ -22,8 +22,9 @@ func addTodoForm(ctx context.Context, props addTodoFormProps, _ nodes.Children)
 	return lander.Html("div", nodes.Attributes{}, nodes.Children{
 		lander.Html("input", nodes.Attributes{
 			"value": value,
-			"change": func(event *events.DOMEvent) error {
-				value = event.JSEvent().Get("target").Get("value").String()
+			// The input is controlled, updating on every keystroke keeps the caret in place
+			"input": func(event *events.InputEvent) error {
+				value = event.Target().Value()
 				return setValue(func(_ string) string {
 					return value
 				})
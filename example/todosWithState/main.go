@@ -42,7 +42,7 @@ func addTodoForm(ctx context.Context, props addTodoFormProps, _ nodes.Children)
 		lander.Html("input", nodes.Attributes{
 			"value": currentState.currentAdd,
 			"change": func(event *events.DOMEvent) error {
-				value := event.JSEvent().Get("target").Get("value").String()
+				value := event.Target().Value()
 				return store.SetState(ctx, func(currentState appState) appState {
 					return appState{
 						todos:      currentState.todos,
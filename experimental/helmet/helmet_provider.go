@@ -5,6 +5,7 @@ import (
 	"syscall/js"
 
 	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
 	"github.com/minivera/go-lander/nodes"
 )
 
@@ -45,11 +46,19 @@ func Provider(ctx context.Context, _ nodes.Props, children nodes.Children) nodes
 				}
 			}
 
-			element := nodes.NewHTMLElement(document, def.node)
+			element := nodes.NewHTMLElement(dom.JSDocument(), def.node)
+			innerHTML := ""
 			for _, child := range def.node.Children {
-				element.Set("innerHTML", element.Get("innerHTML").String()+child.ToString())
+				// Scripts and styles are not parsed as HTML, their text must not be escaped
+				if text, ok := child.(*nodes.TextNode); ok && (def.tag == "script" || def.tag == "style") {
+					innerHTML += text.Text
+					continue
+				}
+
+				innerHTML += child.ToString()
 			}
-			head.Call("appendChild", element)
+			element.SetInnerHTML(innerHTML)
+			head.Call("appendChild", dom.JSValue(element))
 		}
 
 		return nil
@@ -6,144 +6,217 @@ import (
 
 	"github.com/minivera/go-lander/context"
 	"github.com/minivera/go-lander/internal"
+	"github.com/minivera/go-lander/nodes"
 )
 
-func useInternalMemo[T any](ctx context.Context, defaultValue T,
-	deps []interface{}) (bool, T, func(func(T) T) error, func() T) {
+// useInternalMemo returns the state stored in the next hook slot of the component currently rendering. The
+// state is initialized with the result of init when the slot is created, and again every time the deps
+// change. Returns true if the state was initialized during this render.
+func useInternalMemo[T any](ctx context.Context, kind string, init func() T,
+	deps []interface{}) (bool, *nodes.FuncNode, *hookState) {
 
-	if !ctx.HasValue("lander_states") || !ctx.HasValue("lander_active_state") {
-		panic("hooks were used outside of a hook provider, make sure to wrap your entire app in a `lander.Component(hooks.Provider)`")
+	owner, ok := ctx.Component().(*nodes.FuncNode)
+	if !ok {
+		panic("hooks were used outside of a component, hooks can only be called while a component is rendering")
 	}
 
-	internal.Debugf("attempting to setup state for %v\n", defaultValue)
-	activeState, activeStateOk := ctx.GetValue("lander_active_state").(*stateChain)
-	states, statesOk := ctx.GetValue("lander_states").(*stateChain)
-	changed := false
-
-	if states == nil || !statesOk {
-		changed = true
-		states = &stateChain{
-			mounted: false,
-			state:   defaultValue,
-			deps:    deps,
-			next:    nil,
-		}
-		ctx.SetValue("lander_states", states)
-		internal.Debugf("states were empty, creating new empty states to is %T, %v\n", states, states)
-		activeState = states
-		activeStateOk = true
-	}
-
-	internal.Debugf("fetched active state is %T, %v\n", activeState, activeState)
-	var realActiveState *stateChain
-	if activeState == nil || !activeStateOk {
-		internal.Debugf("Creating new active state %v\n", stateChain{
-			mounted: false,
-			state:   defaultValue,
-			deps:    deps,
-			next:    nil,
-		})
-		changed = true
-		currentState := states
-		for currentState.next != nil {
-			currentState = currentState.next
+	slot, created := owner.NextSlot(kind)
+	if created {
+		internal.Debugf("creating new %s state\n", kind)
+		slot.Value = &hookState{
+			state: init(),
+			deps:  deps,
 		}
 
-		realActiveState = &stateChain{
-			mounted: false,
-			state:   defaultValue,
-			deps:    deps,
-			next:    nil,
-		}
-		currentState.next = realActiveState
-	} else {
-		internal.Debugf("Using existing active state %v, %t\n", activeState, activeState == nil)
-		realActiveState = activeState
+		return true, owner, slot.Value.(*hookState)
 	}
 
+	realActiveState := slot.Value.(*hookState)
 	internal.Debugf("current active state is %T, %v\n", realActiveState, realActiveState)
-	if realActiveState.mounted && !reflect.DeepEqual(realActiveState.deps, deps) {
-		changed = true
-		realActiveState.state = defaultValue
-	}
-
-	ctx.OnMount(func() error {
-		realActiveState.mounted = true
-		return nil
-	})
+	if !reflect.DeepEqual(realActiveState.deps, deps) {
+		realActiveState.state = init()
+		realActiveState.deps = deps
 
-	ctx.OnUnmount(func() error {
-		fmt.Println("Unmounting states")
-		// On unmount, remove this state from the chain, so it is not reused in the future
-		if ctx.HasValue("lander_states") {
-			states := ctx.GetValue("lander_states").(*stateChain)
-
-			currentState := states
-			for currentState.next != nil && currentState.next != realActiveState {
-				currentState = currentState.next
-			}
-
-			if currentState.next == realActiveState {
-				currentState.next = realActiveState.next
-			}
-		}
-		return nil
-	})
+		return true, owner, realActiveState
+	}
 
-	internal.Debugf("setting active state to %T, %v\n", realActiveState.next, realActiveState.next)
-	ctx.SetValue("lander_active_state", realActiveState.next)
-	return changed, realActiveState.state.(T), func(setter func(val T) T) error {
-			realActiveState.state = setter(realActiveState.state.(T))
-			return ctx.Update()
-		}, func() T {
-			return realActiveState.state.(T)
-		}
+	return false, owner, realActiveState
 }
 
 // UseState hooks into the context to provide some updatable state to a component. This state can be updated
 // with the second parameter. Note that due to how Golang shares closure variables by reference, any state
 // variable that is not a pointer will not be updated inside the event listeners. The third return value
 // can be used to always get the most up-to-date state value.
+//
+// The setter is safe to call from any goroutine, the setter function is executed in the render loop right
+// before the next render. The getter will only return the new value once that render has started. Setting
+// the state only renders the component using the hook and its descendants again, not the whole app.
 func UseState[T any](ctx context.Context, defaultValue T) (T, func(func(val T) T) error, func() T) {
-	_, state, stateSetter, stateGetter := useInternalMemo[T](ctx, defaultValue, nil)
-	return state, stateSetter, stateGetter
+	_, owner, state := useInternalMemo[T](ctx, "UseState", func() T {
+		return defaultValue
+	}, nil)
+
+	return state.state.(T), func(setter func(val T) T) error {
+			// Only the component owning the state needs to render again
+			return ctx.UpdateComponent(owner, func() {
+				state.state = setter(state.state.(T))
+			})
+		}, func() T {
+			return state.state.(T)
+		}
+}
+
+// UseReducer hooks into the context to provide some state updated through actions. Dispatching an action,
+// using the second return value, calls the reducer with the current state and the action, its result is
+// the new state. The third return value can be used to always get the most up-to-date state value.
+//
+// Like the setter of UseState, dispatch is safe to call from any goroutine and only renders the component
+// using the hook and its descendants again. The reducer is executed in the render loop, right before the
+// next render, in the order the actions were dispatched.
+func UseReducer[S any, A any](ctx context.Context, reducer func(state S, action A) S,
+	initialState S) (S, func(action A) error, func() S) {
+
+	_, owner, state := useInternalMemo[S](ctx, "UseReducer", func() S {
+		return initialState
+	}, nil)
+
+	return state.state.(S), func(action A) error {
+			return ctx.UpdateComponent(owner, func() {
+				state.state = reducer(state.state.(S), action)
+			})
+		}, func() S {
+			return state.state.(S)
+		}
+}
+
+// UseMemo returns the result of the compute function, which is only called on the first render and on any
+// subsequent render where the dependencies given changed. It uses `reflect.DeepEqual` internally to check
+// if the dependencies changed. Use UseMemo to avoid repeating expensive computations on every render.
+func UseMemo[T any](ctx context.Context, compute func() T, deps []interface{}) T {
+	_, _, state := useInternalMemo[T](ctx, "UseMemo", compute, deps)
+	return state.state.(T)
+}
+
+// UseCallback returns the callback given on the first render, and on any subsequent render where the
+// dependencies given changed. The returned callback is the same function value until the dependencies
+// change, which makes it safe to compare or to keep in long-lived listeners.
+func UseCallback[T any](ctx context.Context, callback T, deps []interface{}) T {
+	_, _, state := useInternalMemo[T](ctx, "UseCallback", func() T {
+		return callback
+	}, deps)
+
+	return state.state.(T)
+}
+
+// Ref is a mutable box kept for the whole life of a component. Changing Current does not trigger a render.
+type Ref[T any] struct {
+	Current T
+}
+
+// UseRef returns the ref of the component, created with the initial value on the first render. The same ref
+// is returned on every render, use it to keep values between renders without triggering updates.
+func UseRef[T any](ctx context.Context, initialValue T) *Ref[T] {
+	_, _, state := useInternalMemo[*Ref[T]](ctx, "UseRef", func() *Ref[T] {
+		return &Ref[T]{
+			Current: initialValue,
+		}
+	}, nil)
+
+	return state.state.(*Ref[T])
+}
+
+// UseElementRef returns the element ref of the component, created on the first render. The same ref is
+// returned on every render, give it to an HTML node through the "ref" attribute to access its DOM element
+// from effects and event listeners. The element is set before the effects run, and cleared once the node
+// is removed.
+//
+//	input := hooks.UseElementRef(ctx)
+//	hooks.UseEffect(ctx, func() (func() error, error) {
+//		dom.JSValue(input.Current).Call("focus")
+//		return nil, nil
+//	}, []interface{}{})
+//
+//	return lander.Html("input", nodes.Attributes{"ref": input}, nodes.Children{})
+func UseElementRef(ctx context.Context) *nodes.Ref {
+	_, _, state := useInternalMemo[*nodes.Ref](ctx, "UseElementRef", func() *nodes.Ref {
+		return &nodes.Ref{}
+	}, nil)
+
+	return state.state.(*nodes.Ref)
 }
 
 type effectState struct {
-	effect  func() (func() error, error)
+	deps    []interface{}
 	cleanup func() error
 }
 
+// runCleanup executes the cleanup of the last effect, if any. The cleanup is only ever executed once.
+func (s *effectState) runCleanup() error {
+	cleanup := s.cleanup
+	s.cleanup = nil
+	if cleanup == nil {
+		return nil
+	}
+
+	return cleanup()
+}
+
 // UseEffect calls the effect function on mount and on every subsequent renders, provided the dependencies
 // given change. It uses `reflect.DeepEqual` internally to check if dependency changes.
 //
-// The effect must return a function and an error. This returned function is the cleanup function and will
-// be executed when the component unmount. The cleanup is not executed when the effect is executed due to
-// the dependencies changing, you must cleanup your hooks manually if you leak memory between executions.
+// The effect must return a function and an error. This returned function is the cleanup function, it is
+// executed before the effect runs again due to the dependencies changing, and once when the component
+// unmounts. If the cleanup returns an error, the effect is not executed again and the error is returned.
+//
+// Effects run once the render applied its changes to the DOM, after any layout effect. Effects of children run
+// before the effects of their parents, cleanups on unmount run parents first. Use UseLayoutEffect for effects
+// that must run before the browser paints.
 func UseEffect(ctx context.Context, effect func() (func() error, error), deps []interface{}) {
-	state := &effectState{
-		effect: effect,
-		cleanup: func() error {
+	useEffect(ctx, "UseEffect", ctx.OnRender, effect, deps)
+}
+
+// UseLayoutEffect calls the effect function on mount and on every subsequent renders where the dependencies
+// given changed, like UseEffect. Contrary to UseEffect, the effect is called synchronously once the render
+// applied its changes to the DOM, before the browser can paint them. Use it to measure or change the DOM
+// without flickering. Errors returned by the effect or its cleanup are returned by the render.
+func UseLayoutEffect(ctx context.Context, effect func() (func() error, error), deps []interface{}) {
+	useEffect(ctx, "UseLayoutEffect", ctx.OnLayout, effect, deps)
+}
+
+func useEffect(ctx context.Context, kind string, listen func(func() error),
+	effect func() (func() error, error), deps []interface{}) {
+
+	// The effect state lives as long as the component, the dependencies are compared here so the cleanup
+	// of the previous effect is not lost when they change
+	created, _, memoized := useInternalMemo[*effectState](ctx, kind, func() *effectState {
+		return &effectState{
+			deps: deps,
+		}
+	}, nil)
+	state := memoized.state.(*effectState)
+
+	changed := created || !reflect.DeepEqual(state.deps, deps)
+	state.deps = deps
+
+	listen(func() error {
+		if !changed {
 			return nil
-		},
-	}
-	changed, memoizedEffect, _, _ := useInternalMemo[*effectState](ctx, state, deps)
-
-	ctx.OnRender(func() error {
-		if changed {
-			receivedCleanup, err := memoizedEffect.effect()
-			if receivedCleanup != nil {
-				state.cleanup = receivedCleanup
-			}
-			return err
 		}
 
-		return nil
+		err := state.runCleanup()
+		if err != nil {
+			return fmt.Errorf("error in %s cleanup. %w", kind, err)
+		}
+
+		cleanup, err := effect()
+		state.cleanup = cleanup
+		return err
 	})
 
 	ctx.OnUnmount(func() error {
-		if memoizedEffect.cleanup != nil {
-			return memoizedEffect.cleanup()
+		err := state.runCleanup()
+		if err != nil {
+			return fmt.Errorf("error in %s cleanup. %w", kind, err)
 		}
 
 		return nil
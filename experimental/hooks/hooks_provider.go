@@ -5,28 +5,14 @@ import (
 	"github.com/minivera/go-lander/nodes"
 )
 
-type stateChain struct {
-	mounted bool
-	state   interface{}
-	deps    []interface{}
-
-	next *stateChain
+// hookState is the state of a single hook, stored in the value of a hook slot of the component.
+type hookState struct {
+	state interface{}
+	deps  []interface{}
 }
 
-// Provider provides the context for hooks to work properly. This Provider must be added as the first
-// component of the app. It takes care of setting up and tracking the states on every render. Returns
-// a fragment node, which allows passing more than one child.
-func Provider(context context.Context, _ nodes.Props, children nodes.Children) nodes.Child {
-	if !context.HasValue("lander_states") {
-		context.SetValue("lander_states", nil)
-	}
-
-	states, ok := context.GetValue("lander_states").(*stateChain)
-	if ok {
-		context.SetValue("lander_active_state", states)
-	} else {
-		context.SetValue("lander_active_state", nil)
-	}
-
+// Provider was used to set up the storage of the hooks states. The states are now stored on each component
+// using hooks, the Provider is kept for compatibility and only returns a fragment of its children.
+func Provider(_ context.Context, _ nodes.Props, children nodes.Children) nodes.Child {
 	return nodes.NewFragmentNode(children)
 }
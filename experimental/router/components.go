@@ -67,12 +67,11 @@ func (r *Router) Switch(ctx context.Context, props SwitchProps, children nodes.C
 		panic("Router.Switch will not render any children, but a non-zero number of children were given.")
 	}
 
-	if !ctx.HasValue("lander_routing_url") {
+	pathname := r.urlContext.Use(ctx)
+	if pathname == "" {
 		panic("routing components were used outside of a router provider, make sure to wrap your entire app in a `lander.Component(router.Provider)`")
 	}
 
-	pathname := ctx.GetValue("lander_routing_url").(string)
-
 	routeDefs := props.Routes
 
 	for _, definition := range routeDefs {
@@ -125,10 +124,10 @@ func (r *Router) Route(ctx context.Context, props RouteProps, children nodes.Chi
 		panic("Router.Route will not render any children, but a non-zero number of children were given.")
 	}
 
-	if !ctx.HasValue("lander_routing_url") {
+	pathname := r.urlContext.Use(ctx)
+	if pathname == "" {
 		panic("routing components were used outside of a router provider, make sure to wrap your entire app in a `lander.Component(router.Provider)`")
 	}
-	pathname := ctx.GetValue("lander_routing_url").(string)
 	internal.Debugf("Current pathname is %s\n", pathname)
 
 	route := props.Route
@@ -13,17 +13,23 @@ import (
 type Router struct {
 	currentURL string
 
+	// urlContext provides the current URL to the routing components inside the router's provider
+	urlContext *context.ScopedContext[string]
+
 	handleHistoryFunc js.Func
 }
 
 // NewRouter generates a valid router pointer with all properties set.
 func NewRouter() *Router {
-	return &Router{}
+	return &Router{
+		urlContext: context.CreateContext[string](""),
+	}
 }
 
 // Provider provides the context and values for the router to work properly. It must be added as one of
 // the first component of the tree and all subsequent router components or logic must happen in a descendant
-// of the provider. The provider also listens to the popstate events to update the application if the user
+// of the provider. The URL is scoped to the provider's descendants, many routers can be used in the same app.
+// The provider also listens to the popstate events to update the application if the user
 // uses the back or forward buttons. Returns a fragment node, which allows passing more than one child.
 func (r *Router) Provider(ctx context.Context, _ nodes.Props, children nodes.Children) nodes.Child {
 	g := js.Global()
@@ -34,7 +40,7 @@ func (r *Router) Provider(ctx context.Context, _ nodes.Props, children nodes.Chi
 	if r.currentURL == "" {
 		r.currentURL = g.Get("window").Get("location").Call("toString").String()
 	}
-	ctx.SetValue("lander_routing_url", r.currentURL)
+	r.urlContext.Provide(ctx, r.currentURL)
 
 	ctx.OnMount(func() error {
 		g := js.Global()
@@ -44,9 +50,13 @@ func (r *Router) Provider(ctx context.Context, _ nodes.Props, children nodes.Chi
 
 		r.handleHistoryFunc = js.FuncOf(func(this js.Value, args []js.Value) any {
 			pathname := g.Get("window").Get("location").Call("toString").String()
-			r.currentURL = pathname
+			if err := ctx.UpdateWith(func() {
+				r.currentURL = pathname
+			}); err != nil {
+				ctx.ReportError(fmt.Errorf("error while updating the route: %w", err))
+			}
 
-			return ctx.Update()
+			return nil
 		})
 
 		g.Get("window").Call("addEventListener", "popstate", r.handleHistoryFunc)
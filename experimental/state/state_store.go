@@ -47,7 +47,11 @@ func (s *Store[T]) Consumer(_ context.Context, props ConsumerProps[T], children
 // a function as its setter parameter to ensure the value is updated with the latest version of the
 // state. This does not merge the old and new state together, the new state is expected to include
 // the new and old state merged.
+//
+// SetState is safe to call from any goroutine, the setter is executed in the render loop right before the
+// next render.
 func (s *Store[T]) SetState(ctx context.Context, setter func(value T) T) error {
-	s.state = setter(s.state)
-	return ctx.Update()
+	return ctx.UpdateWith(func() {
+		s.state = setter(s.state)
+	})
 }
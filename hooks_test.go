@@ -0,0 +1,556 @@
+package lander_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/experimental/hooks"
+	"github.com/minivera/go-lander/nodes"
+)
+
+type namedCounterProps struct {
+	name string
+}
+
+func namedCounter(ctx context.Context, props namedCounterProps, _ nodes.Children) nodes.Child {
+	count, setCount, _ := hooks.UseState[int](ctx, 0)
+
+	return lander.Html("button", nodes.Attributes{
+		"id": props.name,
+		"click": func(*events.DOMEvent) error {
+			return setCount(func(value int) int {
+				return value + 1
+			})
+		},
+	}, nodes.Children{
+		lander.Text(fmt.Sprintf("%s: %d", props.name, count)),
+	})
+}
+
+func TestHooks_ConditionalSibling(t *testing.T) {
+	document, app := setupDocument(t)
+
+	visible := false
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		var first nodes.Child
+		if visible {
+			first = lander.Component(namedCounter, namedCounterProps{name: "first"}, nodes.Children{})
+		}
+
+		return lander.Html("div", nodes.Attributes{}, nodes.Children{
+			lander.Html("section", nodes.Attributes{}, nodes.Children{first}),
+			lander.Html("div", nodes.Attributes{}, nodes.Children{
+				lander.Component(namedCounter, namedCounterProps{name: "second"}, nodes.Children{}),
+			}),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	document.QuerySelector("#second").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, `<div><section></section><div><button id="second">second: 1</button></div></div>`, app.InnerHTML())
+
+	// Showing a sibling using hooks does not shift the state of the other component
+	visible = true
+	require.NoError(t, env.Update())
+	assert.Equal(
+		t,
+		`<div><section><button id="first">first: 0</button></section><div><button id="second">second: 1</button></div></div>`,
+		app.InnerHTML(),
+	)
+
+	visible = false
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<div><section></section><div><button id="second">second: 1</button></div></div>`, app.InnerHTML())
+}
+
+func TestHooks_KeyedComponents(t *testing.T) {
+	document, app := setupDocument(t)
+
+	names := []string{"a", "b", "c"}
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		children := nodes.Children{}
+		for _, name := range names {
+			children = append(children, lander.Component(
+				namedCounter,
+				namedCounterProps{name: name},
+				nodes.Children{},
+				lander.WithKey(name),
+			))
+		}
+
+		return lander.Html("div", nodes.Attributes{}, children)
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	document.QuerySelector("#b").(*dom.MemoryElement).Dispatch("click")
+
+	// The state follows the component when it moves
+	names = []string{"b", "c"}
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<div><button id="b">b: 1</button><button id="c">c: 0</button></div>`, app.InnerHTML())
+}
+
+func TestHooks_UnmountDiscardsState(t *testing.T) {
+	document, app := setupDocument(t)
+
+	visible := true
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		if !visible {
+			return lander.Html("div", nodes.Attributes{}, nodes.Children{})
+		}
+
+		return lander.Html("div", nodes.Attributes{}, nodes.Children{
+			lander.Component(namedCounter, namedCounterProps{name: "counter"}, nodes.Children{}),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	document.QuerySelector("#counter").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, `<div><button id="counter">counter: 1</button></div>`, app.InnerHTML())
+
+	visible = false
+	require.NoError(t, env.Update())
+	visible = true
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<div><button id="counter">counter: 0</button></div>`, app.InnerHTML())
+}
+
+func namedLabel(ctx context.Context, props namedCounterProps, _ nodes.Children) nodes.Child {
+	label, _, _ := hooks.UseState[string](ctx, "label")
+
+	return lander.Html("span", nodes.Attributes{"id": props.name}, nodes.Children{
+		lander.Text(label),
+	})
+}
+
+func TestHooks_ComponentSwap(t *testing.T) {
+	document, app := setupDocument(t)
+
+	counter := true
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		// Both components use the same props at the same position, but hooks of different types
+		component := lander.Component(namedLabel, namedCounterProps{name: "swapped"}, nodes.Children{})
+		if counter {
+			component = lander.Component(namedCounter, namedCounterProps{name: "swapped"}, nodes.Children{})
+		}
+
+		return lander.Html("div", nodes.Attributes{}, nodes.Children{component})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(hooks.Provider, nodes.Props{}, nodes.Children{
+		lander.Component(root, nodes.Props{}, nodes.Children{}),
+	}), "#app")
+	require.NoError(t, err)
+
+	document.QuerySelector("#swapped").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, `<div><button id="swapped">swapped: 1</button></div>`, app.InnerHTML())
+
+	// The other component is mounted with its own hook slots, the old one is unmounted
+	counter = false
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<div><span id="swapped">label</span></div>`, app.InnerHTML())
+
+	counter = true
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<div><button id="swapped">swapped: 0</button></div>`, app.InnerHTML())
+}
+
+type counterAction struct {
+	add int
+}
+
+func TestHooks_UseReducer(t *testing.T) {
+	document, app := setupDocument(t)
+
+	var actions []int
+	root := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		count, dispatch, _ := hooks.UseReducer(ctx, func(state int, action counterAction) int {
+			actions = append(actions, action.add)
+			return state + action.add
+		}, 10)
+
+		return lander.Html("button", nodes.Attributes{
+			"click": func(*events.DOMEvent) error {
+				if err := dispatch(counterAction{add: 1}); err != nil {
+					return err
+				}
+				return dispatch(counterAction{add: 5})
+			},
+		}, nodes.Children{
+			lander.Text(fmt.Sprintf("%d", count)),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, `<button>10</button>`, app.InnerHTML())
+
+	// Both actions are reduced in order, then rendered once
+	app.QuerySelector("button").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, `<button>16</button>`, app.InnerHTML())
+	assert.Equal(t, []int{1, 5}, actions)
+	assert.Equal(t, 2, env.RenderCount())
+}
+
+func TestHooks_UseMemo(t *testing.T) {
+	document, app := setupDocument(t)
+
+	computed := 0
+	input := 2
+	root := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		current := input
+		squared := hooks.UseMemo(ctx, func() int {
+			computed++
+			return current * current
+		}, []interface{}{current})
+
+		return lander.Text(fmt.Sprintf("%d", squared))
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, `4`, app.InnerHTML())
+	assert.Equal(t, 1, computed)
+
+	// Rendering with the same dependencies reuses the value
+	require.NoError(t, env.Update())
+	assert.Equal(t, `4`, app.InnerHTML())
+	assert.Equal(t, 1, computed)
+
+	input = 3
+	require.NoError(t, env.Update())
+	assert.Equal(t, `9`, app.InnerHTML())
+	assert.Equal(t, 2, computed)
+}
+
+func TestHooks_UseCallback(t *testing.T) {
+	document, _ := setupDocument(t)
+
+	renders := 0
+	dependency := "first"
+	var callback func() int
+	root := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		renders++
+		render := renders
+		callback = hooks.UseCallback(ctx, func() int {
+			return render
+		}, []interface{}{dependency})
+
+		return lander.Html("div", nodes.Attributes{}, nodes.Children{})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, 1, callback())
+
+	// The callback of the first render is kept until the dependencies change
+	require.NoError(t, env.Update())
+	assert.Equal(t, 1, callback())
+
+	dependency = "second"
+	require.NoError(t, env.Update())
+	assert.Equal(t, 3, callback())
+}
+
+func TestHooks_UseRef(t *testing.T) {
+	document, app := setupDocument(t)
+
+	var refs []*hooks.Ref[int]
+	root := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		ref := hooks.UseRef(ctx, 5)
+		refs = append(refs, ref)
+
+		return lander.Html("button", nodes.Attributes{
+			"click": func(*events.DOMEvent) error {
+				ref.Current++
+				return nil
+			},
+		}, nodes.Children{
+			lander.Text(fmt.Sprintf("%d", ref.Current)),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	// Changing the ref does not render the component again
+	app.QuerySelector("button").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, 6, refs[0].Current)
+	assert.Equal(t, `<button>5</button>`, app.InnerHTML())
+	assert.Equal(t, 1, env.RenderCount())
+
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<button>6</button>`, app.InnerHTML())
+	require.Len(t, refs, 2)
+	assert.Same(t, refs[0], refs[1])
+}
+
+func TestHooks_UseLayoutEffect(t *testing.T) {
+	document, app := setupDocument(t)
+
+	label := "first"
+	var seen []string
+	root := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		hooks.UseLayoutEffect(ctx, func() (func() error, error) {
+			seen = append(seen, app.InnerHTML())
+			return nil, nil
+		}, []interface{}{label})
+
+		return lander.Html("p", nodes.Attributes{}, nodes.Children{
+			lander.Text(label),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	// The effect ran before the render returned, with the DOM already patched
+	assert.Equal(t, []string{`<p>first</p>`}, seen)
+
+	require.NoError(t, env.Update())
+	assert.Equal(t, []string{`<p>first</p>`}, seen)
+
+	label = "second"
+	require.NoError(t, env.Update())
+	assert.Equal(t, []string{`<p>first</p>`, `<p>second</p>`}, seen)
+}
+
+func TestHooks_UseLayoutEffectError(t *testing.T) {
+	document, _ := setupDocument(t)
+
+	fail := false
+	root := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		hooks.UseLayoutEffect(ctx, func() (func() error, error) {
+			if fail {
+				return nil, fmt.Errorf("layout failed")
+			}
+			return nil, nil
+		}, []interface{}{fail})
+
+		return lander.Html("div", nodes.Attributes{}, nodes.Children{})
+	}
+
+	env, err := lander.RenderIntoDocument(
+		document,
+		lander.Component(root, nodes.Props{}, nodes.Children{}),
+		"#app",
+		lander.WithScheduler(lander.ManualScheduler),
+	)
+	require.NoError(t, err)
+
+	fail = true
+	require.NoError(t, env.Update())
+	assert.ErrorContains(t, env.Flush(), "layout failed")
+}
+
+// receivedEffects returns the messages sent by effects so far. Effects run before the render returns, the
+// messages are already in the channel.
+func receivedEffects(messages <-chan string) []string {
+	var received []string
+	for {
+		select {
+		case message := <-messages:
+			received = append(received, message)
+		default:
+			return received
+		}
+	}
+}
+
+type effectProps struct {
+	name     string
+	value    int
+	messages chan string
+}
+
+func effectComponent(ctx context.Context, props effectProps, children nodes.Children) nodes.Child {
+	hooks.UseEffect(ctx, func() (func() error, error) {
+		props.messages <- fmt.Sprintf("effect %s %d", props.name, props.value)
+		return func() error {
+			props.messages <- fmt.Sprintf("cleanup %s %d", props.name, props.value)
+			return nil
+		}, nil
+	}, []interface{}{props.value})
+
+	return lander.Html("div", nodes.Attributes{}, children)
+}
+
+func TestHooks_UseEffectCleanup(t *testing.T) {
+	document, _ := setupDocument(t)
+
+	messages := make(chan string, 10)
+	value := 1
+	visible := true
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		if !visible {
+			return lander.Html("section", nodes.Attributes{}, nodes.Children{})
+		}
+
+		return lander.Html("section", nodes.Attributes{}, nodes.Children{
+			lander.Component(effectComponent, effectProps{name: "parent", value: value, messages: messages}, nodes.Children{
+				lander.Component(effectComponent, effectProps{name: "child", value: value, messages: messages}, nodes.Children{}),
+			}),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	// Effects of children run before the ones of their parents
+	assert.Equal(t, []string{"effect child 1", "effect parent 1"}, receivedEffects(messages))
+
+	// The previous cleanup runs before the effect when the dependencies change
+	value = 2
+	require.NoError(t, env.Update())
+	assert.Equal(
+		t,
+		[]string{"cleanup child 1", "effect child 2", "cleanup parent 1", "effect parent 2"},
+		receivedEffects(messages),
+	)
+
+	// The cleanup of the component and its descendants runs once on unmount, parents first
+	visible = false
+	require.NoError(t, env.Update())
+	assert.Equal(t, []string{"cleanup parent 2", "cleanup child 2"}, receivedEffects(messages))
+
+	require.NoError(t, env.Update())
+	assert.Empty(t, receivedEffects(messages))
+
+	visible = true
+	require.NoError(t, env.Update())
+	assert.Equal(t, []string{"effect child 2", "effect parent 2"}, receivedEffects(messages))
+}
+
+func TestHooks_UseEffectUnchangedDependencies(t *testing.T) {
+	document, _ := setupDocument(t)
+
+	messages := make(chan string, 10)
+	value := 1
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Html("section", nodes.Attributes{}, nodes.Children{
+			lander.Component(effectComponent, effectProps{name: "first", value: 1, messages: messages}, nodes.Children{}),
+			lander.Component(effectComponent, effectProps{name: "second", value: value, messages: messages}, nodes.Children{}),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"effect first 1", "effect second 1"}, receivedEffects(messages))
+
+	// Only the effect with changed dependencies runs again
+	value = 2
+	require.NoError(t, env.Update())
+	assert.Equal(t, []string{"cleanup second 1", "effect second 2"}, receivedEffects(messages))
+}
+
+func TestHooks_UseLayoutEffectOrder(t *testing.T) {
+	document, _ := setupDocument(t)
+
+	var order []string
+	layoutComponent := func(ctx context.Context, props labelProps, children nodes.Children) nodes.Child {
+		hooks.UseLayoutEffect(ctx, func() (func() error, error) {
+			order = append(order, props.label)
+			return nil, nil
+		}, []interface{}{})
+
+		return lander.Html("div", nodes.Attributes{}, children)
+	}
+
+	_, err := lander.RenderIntoDocument(document, lander.Component(layoutComponent, labelProps{label: "parent"}, nodes.Children{
+		lander.Component(layoutComponent, labelProps{label: "first"}, nodes.Children{
+			lander.Component(layoutComponent, labelProps{label: "nested"}, nodes.Children{}),
+		}),
+		lander.Component(layoutComponent, labelProps{label: "second"}, nodes.Children{}),
+	}), "#app")
+	require.NoError(t, err)
+	// Children run their layout effects before their parents
+	assert.Equal(t, []string{"nested", "first", "second", "parent"}, order)
+}
+
+func TestHooks_UseLayoutEffectCleanupError(t *testing.T) {
+	document, _ := setupDocument(t)
+
+	value := 1
+	var effects []int
+	root := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		current := value
+		hooks.UseLayoutEffect(ctx, func() (func() error, error) {
+			effects = append(effects, current)
+			return func() error {
+				return fmt.Errorf("cleanup %d failed", current)
+			}, nil
+		}, []interface{}{current})
+
+		return lander.Html("div", nodes.Attributes{}, nodes.Children{})
+	}
+
+	env, err := lander.RenderIntoDocument(
+		document,
+		lander.Component(root, nodes.Props{}, nodes.Children{}),
+		"#app",
+		lander.WithScheduler(lander.ManualScheduler),
+	)
+	require.NoError(t, err)
+
+	// The error of the cleanup is returned and the new effect is not executed
+	value = 2
+	require.NoError(t, env.Update())
+	assert.ErrorContains(t, env.Flush(), "cleanup 1 failed")
+	assert.Equal(t, []int{1}, effects)
+}
+
+func TestHooks_UseElementRef(t *testing.T) {
+	document, app := setupDocument(t)
+
+	tag := "input"
+	show := true
+	var refs []*nodes.Ref
+	var seen []dom.Element
+	root := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		ref := hooks.UseElementRef(ctx)
+		refs = append(refs, ref)
+
+		hooks.UseEffect(ctx, func() (func() error, error) {
+			seen = append(seen, ref.Current)
+			return nil, nil
+		}, []interface{}{tag, show})
+
+		children := nodes.Children{}
+		if show {
+			children = append(children, lander.Html(tag, nodes.Attributes{"ref": ref}, nodes.Children{}))
+		}
+
+		return lander.Html("div", nodes.Attributes{}, children)
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	// The element is set before the effects run
+	require.Len(t, seen, 1)
+	assert.True(t, seen[0].IsSameNode(app.QuerySelector("input")))
+
+	// Replaced elements are kept current
+	tag = "textarea"
+	require.NoError(t, env.Update())
+	require.Len(t, seen, 2)
+	assert.True(t, seen[1].IsSameNode(app.QuerySelector("textarea")))
+
+	// Removed elements are cleared
+	show = false
+	require.NoError(t, env.Update())
+	require.Len(t, seen, 3)
+	assert.Nil(t, seen[2])
+	assert.Same(t, refs[0], refs[2])
+}
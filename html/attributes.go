@@ -0,0 +1,1541 @@
+// Code generated by go run ./internal/gen; DO NOT EDIT.
+// Source: https://html.spec.whatwg.org/multipage/indices.html
+
+package html
+
+import (
+	"strings"
+)
+
+// AccessKey sets the global accesskey attribute, keyboard shortcut to activate or focus element.
+func AccessKey(values ...string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "accesskey", value: strings.Join(values, " ")}}
+}
+
+// AutoCapitalize sets the global autocapitalize attribute, recommended autocapitalization behavior (for
+// supported input methods).
+func AutoCapitalize(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "autocapitalize", value: value}}
+}
+
+// AutoCorrect sets the global autocorrect attribute, recommended autocorrection behavior (for supported input
+// methods).
+func AutoCorrect(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "autocorrect", value: value}}
+}
+
+// AutoFocus sets the global autofocus attribute, automatically focus the element when the page is loaded.
+func AutoFocus(value bool) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "autofocus", value: value}}
+}
+
+// Class sets the global class attribute, classes to which the element belongs.
+func Class(values ...string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "class", value: strings.Join(values, " ")}}
+}
+
+// ContentEditable sets the global contenteditable attribute, whether the element is editable.
+func ContentEditable(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "contenteditable", value: value}}
+}
+
+// Dir sets the global dir attribute, the text directionality of the element.
+func Dir(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "dir", value: value}}
+}
+
+// Draggable sets the global draggable attribute, whether the element is draggable.
+func Draggable(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "draggable", value: value}}
+}
+
+// EnterKeyHint sets the global enterkeyhint attribute, hint for selecting an enter key action.
+func EnterKeyHint(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "enterkeyhint", value: value}}
+}
+
+// Hidden sets the global hidden attribute, whether the element is relevant.
+func Hidden(value bool) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "hidden", value: value}}
+}
+
+// ID sets the global id attribute, the element's ID.
+func ID(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "id", value: value}}
+}
+
+// Inert sets the global inert attribute, whether the element is inert.
+func Inert(value bool) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "inert", value: value}}
+}
+
+// InputMode sets the global inputmode attribute, hint for selecting an input modality.
+func InputMode(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "inputmode", value: value}}
+}
+
+// Is sets the global is attribute, creates a customized built-in element.
+func Is(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "is", value: value}}
+}
+
+// ItemID sets the global itemid attribute, global identifier for a microdata item.
+func ItemID(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "itemid", value: value}}
+}
+
+// ItemProp sets the global itemprop attribute, property names of a microdata item.
+func ItemProp(values ...string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "itemprop", value: strings.Join(values, " ")}}
+}
+
+// ItemRef sets the global itemref attribute, referenced elements.
+func ItemRef(values ...string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "itemref", value: strings.Join(values, " ")}}
+}
+
+// ItemScope sets the global itemscope attribute, introduces a microdata item.
+func ItemScope(value bool) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "itemscope", value: value}}
+}
+
+// ItemType sets the global itemtype attribute, item types of a microdata item.
+func ItemType(values ...string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "itemtype", value: strings.Join(values, " ")}}
+}
+
+// Lang sets the global lang attribute, language of the element.
+func Lang(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "lang", value: value}}
+}
+
+// Nonce sets the global nonce attribute, cryptographic nonce used in Content Security Policy checks.
+func Nonce(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "nonce", value: value}}
+}
+
+// Popover sets the global popover attribute, makes the element a popover element.
+func Popover(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "popover", value: value}}
+}
+
+// SlotAttr sets the global slot attribute, the element's desired slot.
+func SlotAttr(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "slot", value: value}}
+}
+
+// SpellCheck sets the global spellcheck attribute, whether the element is to have its spelling and grammar
+// checked.
+func SpellCheck(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "spellcheck", value: value}}
+}
+
+// StyleAttr sets the global style attribute, presentational and formatting instructions.
+func StyleAttr(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "style", value: value}}
+}
+
+// TabIndex sets the global tabindex attribute, whether the element is focusable and sequentially focusable,
+// and the relative order of the element for the purposes of sequential focus navigation.
+func TabIndex(value int) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "tabindex", value: value}}
+}
+
+// TitleAttr sets the global title attribute, advisory information for the element.
+func TitleAttr(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "title", value: value}}
+}
+
+// Translate sets the global translate attribute, whether the element is to be translated when the page is
+// localized.
+func Translate(value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "translate", value: value}}
+}
+
+// AbbrAttribute is the abbr attribute, accepted by the th elements.
+type AbbrAttribute struct {
+	attribute
+}
+
+// AbbrAttr sets the abbr attribute, alternative label to use for the header cell when referencing the cell in
+// other contexts.
+func AbbrAttr(value string) AbbrAttribute {
+	return AbbrAttribute{attribute{name: "abbr", value: value}}
+}
+
+func (AbbrAttribute) isThOption() {}
+
+// AcceptAttribute is the accept attribute, accepted by the input elements.
+type AcceptAttribute struct {
+	attribute
+}
+
+// Accept sets the accept attribute, hint for expected file type in file upload controls.
+func Accept(value string) AcceptAttribute {
+	return AcceptAttribute{attribute{name: "accept", value: value}}
+}
+
+func (AcceptAttribute) isInputOption() {}
+
+// AcceptCharsetAttribute is the accept-charset attribute, accepted by the form elements.
+type AcceptCharsetAttribute struct {
+	attribute
+}
+
+// AcceptCharset sets the accept-charset attribute, character encodings to use for form submission.
+func AcceptCharset(value string) AcceptCharsetAttribute {
+	return AcceptCharsetAttribute{attribute{name: "accept-charset", value: value}}
+}
+
+func (AcceptCharsetAttribute) isFormOption() {}
+
+// ActionAttribute is the action attribute, accepted by the form elements.
+type ActionAttribute struct {
+	attribute
+}
+
+// Action sets the action attribute, uRL to use for form submission.
+func Action(value string) ActionAttribute {
+	return ActionAttribute{attribute{name: "action", value: value}}
+}
+
+func (ActionAttribute) isFormOption() {}
+
+// AllowAttribute is the allow attribute, accepted by the iframe elements.
+type AllowAttribute struct {
+	attribute
+}
+
+// Allow sets the allow attribute, permissions policy to be applied to the iframe's contents.
+func Allow(value string) AllowAttribute {
+	return AllowAttribute{attribute{name: "allow", value: value}}
+}
+
+func (AllowAttribute) isIframeOption() {}
+
+// AllowFullscreenAttribute is the allowfullscreen attribute, accepted by the iframe elements.
+type AllowFullscreenAttribute struct {
+	attribute
+}
+
+// AllowFullscreen sets the allowfullscreen attribute, whether to allow the iframe's contents to use
+// requestFullscreen().
+func AllowFullscreen(value bool) AllowFullscreenAttribute {
+	return AllowFullscreenAttribute{attribute{name: "allowfullscreen", value: value}}
+}
+
+func (AllowFullscreenAttribute) isIframeOption() {}
+
+// AltAttribute is the alt attribute, accepted by the area, img and input elements.
+type AltAttribute struct {
+	attribute
+}
+
+// Alt sets the alt attribute, replacement text for use when images are not available.
+func Alt(value string) AltAttribute {
+	return AltAttribute{attribute{name: "alt", value: value}}
+}
+
+func (AltAttribute) isAreaOption()  {}
+func (AltAttribute) isImgOption()   {}
+func (AltAttribute) isInputOption() {}
+
+// AsAttribute is the as attribute, accepted by the link elements.
+type AsAttribute struct {
+	attribute
+}
+
+// As sets the as attribute, potential destination for a preload request.
+func As(value string) AsAttribute {
+	return AsAttribute{attribute{name: "as", value: value}}
+}
+
+func (AsAttribute) isLinkOption() {}
+
+// AsyncAttribute is the async attribute, accepted by the script elements.
+type AsyncAttribute struct {
+	attribute
+}
+
+// Async sets the async attribute, execute script when available, without blocking while fetching.
+func Async(value bool) AsyncAttribute {
+	return AsyncAttribute{attribute{name: "async", value: value}}
+}
+
+func (AsyncAttribute) isScriptOption() {}
+
+// AutoCompleteAttribute is the autocomplete attribute, accepted by the form, input, select and textarea
+// elements.
+type AutoCompleteAttribute struct {
+	attribute
+}
+
+// AutoComplete sets the autocomplete attribute, hint for form autofill feature.
+func AutoComplete(value string) AutoCompleteAttribute {
+	return AutoCompleteAttribute{attribute{name: "autocomplete", value: value}}
+}
+
+func (AutoCompleteAttribute) isFormOption()     {}
+func (AutoCompleteAttribute) isInputOption()    {}
+func (AutoCompleteAttribute) isSelectOption()   {}
+func (AutoCompleteAttribute) isTextareaOption() {}
+
+// AutoPlayAttribute is the autoplay attribute, accepted by the audio and video elements.
+type AutoPlayAttribute struct {
+	attribute
+}
+
+// AutoPlay sets the autoplay attribute, hint that the media resource can be started automatically when the
+// page is loaded.
+func AutoPlay(value bool) AutoPlayAttribute {
+	return AutoPlayAttribute{attribute{name: "autoplay", value: value}}
+}
+
+func (AutoPlayAttribute) isAudioOption() {}
+func (AutoPlayAttribute) isVideoOption() {}
+
+// BlockingAttribute is the blocking attribute, accepted by the link, script and style elements.
+type BlockingAttribute struct {
+	attribute
+}
+
+// Blocking sets the blocking attribute, whether the element is potentially render-blocking.
+func Blocking(values ...string) BlockingAttribute {
+	return BlockingAttribute{attribute{name: "blocking", value: strings.Join(values, " ")}}
+}
+
+func (BlockingAttribute) isLinkOption()   {}
+func (BlockingAttribute) isScriptOption() {}
+func (BlockingAttribute) isStyleOption()  {}
+
+// CharsetAttribute is the charset attribute, accepted by the meta elements.
+type CharsetAttribute struct {
+	attribute
+}
+
+// Charset sets the charset attribute, character encoding declaration.
+func Charset(value string) CharsetAttribute {
+	return CharsetAttribute{attribute{name: "charset", value: value}}
+}
+
+func (CharsetAttribute) isMetaOption() {}
+
+// CheckedAttribute is the checked attribute, accepted by the input elements.
+type CheckedAttribute struct {
+	attribute
+}
+
+// Checked sets the checked attribute, whether the control is checked.
+func Checked(value bool) CheckedAttribute {
+	return CheckedAttribute{attribute{name: "checked", value: value}}
+}
+
+func (CheckedAttribute) isInputOption() {}
+
+// CiteAttribute is the cite attribute, accepted by the blockquote, del, ins and q elements.
+type CiteAttribute struct {
+	attribute
+}
+
+// CiteAttr sets the cite attribute, link to the source of the quotation or more information about the edit.
+func CiteAttr(value string) CiteAttribute {
+	return CiteAttribute{attribute{name: "cite", value: value}}
+}
+
+func (CiteAttribute) isBlockquoteOption() {}
+func (CiteAttribute) isDelOption()        {}
+func (CiteAttribute) isInsOption()        {}
+func (CiteAttribute) isQOption()          {}
+
+// ColorAttribute is the color attribute, accepted by the link elements.
+type ColorAttribute struct {
+	attribute
+}
+
+// Color sets the color attribute, color to use when customizing a site's icon.
+func Color(value string) ColorAttribute {
+	return ColorAttribute{attribute{name: "color", value: value}}
+}
+
+func (ColorAttribute) isLinkOption() {}
+
+// ColsAttribute is the cols attribute, accepted by the textarea elements.
+type ColsAttribute struct {
+	attribute
+}
+
+// Cols sets the cols attribute, maximum number of characters per line.
+func Cols(value int) ColsAttribute {
+	return ColsAttribute{attribute{name: "cols", value: value}}
+}
+
+func (ColsAttribute) isTextareaOption() {}
+
+// ColSpanAttribute is the colspan attribute, accepted by the td and th elements.
+type ColSpanAttribute struct {
+	attribute
+}
+
+// ColSpan sets the colspan attribute, number of columns that the cell is to span.
+func ColSpan(value int) ColSpanAttribute {
+	return ColSpanAttribute{attribute{name: "colspan", value: value}}
+}
+
+func (ColSpanAttribute) isTdOption() {}
+func (ColSpanAttribute) isThOption() {}
+
+// ContentAttribute is the content attribute, accepted by the meta elements.
+type ContentAttribute struct {
+	attribute
+}
+
+// Content sets the content attribute, value of the element.
+func Content(value string) ContentAttribute {
+	return ContentAttribute{attribute{name: "content", value: value}}
+}
+
+func (ContentAttribute) isMetaOption() {}
+
+// ControlsAttribute is the controls attribute, accepted by the audio and video elements.
+type ControlsAttribute struct {
+	attribute
+}
+
+// Controls sets the controls attribute, show user agent controls.
+func Controls(value bool) ControlsAttribute {
+	return ControlsAttribute{attribute{name: "controls", value: value}}
+}
+
+func (ControlsAttribute) isAudioOption() {}
+func (ControlsAttribute) isVideoOption() {}
+
+// CoordsAttribute is the coords attribute, accepted by the area elements.
+type CoordsAttribute struct {
+	attribute
+}
+
+// Coords sets the coords attribute, coordinates for the shape to be created in an image map.
+func Coords(value string) CoordsAttribute {
+	return CoordsAttribute{attribute{name: "coords", value: value}}
+}
+
+func (CoordsAttribute) isAreaOption() {}
+
+// CrossOriginAttribute is the crossorigin attribute, accepted by the audio, img, link, script and video
+// elements.
+type CrossOriginAttribute struct {
+	attribute
+}
+
+// CrossOrigin sets the crossorigin attribute, how the element handles crossorigin requests.
+func CrossOrigin(value string) CrossOriginAttribute {
+	return CrossOriginAttribute{attribute{name: "crossorigin", value: value}}
+}
+
+func (CrossOriginAttribute) isAudioOption()  {}
+func (CrossOriginAttribute) isImgOption()    {}
+func (CrossOriginAttribute) isLinkOption()   {}
+func (CrossOriginAttribute) isScriptOption() {}
+func (CrossOriginAttribute) isVideoOption()  {}
+
+// DataAttribute is the data attribute, accepted by the object elements.
+type DataAttribute struct {
+	attribute
+}
+
+// DataAttr sets the data attribute, address of the resource.
+func DataAttr(value string) DataAttribute {
+	return DataAttribute{attribute{name: "data", value: value}}
+}
+
+func (DataAttribute) isObjectOption() {}
+
+// DateTimeAttribute is the datetime attribute, accepted by the del, ins and time elements.
+type DateTimeAttribute struct {
+	attribute
+}
+
+// DateTime sets the datetime attribute, date and (optionally) time of the change, or machine-readable value.
+func DateTime(value string) DateTimeAttribute {
+	return DateTimeAttribute{attribute{name: "datetime", value: value}}
+}
+
+func (DateTimeAttribute) isDelOption()  {}
+func (DateTimeAttribute) isInsOption()  {}
+func (DateTimeAttribute) isTimeOption() {}
+
+// DecodingAttribute is the decoding attribute, accepted by the img elements.
+type DecodingAttribute struct {
+	attribute
+}
+
+// Decoding sets the decoding attribute, decoding hint to use when processing this image for presentation.
+func Decoding(value string) DecodingAttribute {
+	return DecodingAttribute{attribute{name: "decoding", value: value}}
+}
+
+func (DecodingAttribute) isImgOption() {}
+
+// DefaultAttribute is the default attribute, accepted by the track elements.
+type DefaultAttribute struct {
+	attribute
+}
+
+// Default sets the default attribute, enable the track if no other text track is more suitable.
+func Default(value bool) DefaultAttribute {
+	return DefaultAttribute{attribute{name: "default", value: value}}
+}
+
+func (DefaultAttribute) isTrackOption() {}
+
+// DeferAttribute is the defer attribute, accepted by the script elements.
+type DeferAttribute struct {
+	attribute
+}
+
+// Defer sets the defer attribute, defer script execution.
+func Defer(value bool) DeferAttribute {
+	return DeferAttribute{attribute{name: "defer", value: value}}
+}
+
+func (DeferAttribute) isScriptOption() {}
+
+// DirNameAttribute is the dirname attribute, accepted by the input and textarea elements.
+type DirNameAttribute struct {
+	attribute
+}
+
+// DirName sets the dirname attribute, name of form control to use for sending the element's directionality in
+// form submission.
+func DirName(value string) DirNameAttribute {
+	return DirNameAttribute{attribute{name: "dirname", value: value}}
+}
+
+func (DirNameAttribute) isInputOption()    {}
+func (DirNameAttribute) isTextareaOption() {}
+
+// DisabledAttribute is the disabled attribute, accepted by the button, fieldset, input, link, optgroup,
+// option, select and textarea elements.
+type DisabledAttribute struct {
+	attribute
+}
+
+// Disabled sets the disabled attribute, whether the form control is disabled.
+func Disabled(value bool) DisabledAttribute {
+	return DisabledAttribute{attribute{name: "disabled", value: value}}
+}
+
+func (DisabledAttribute) isButtonOption()   {}
+func (DisabledAttribute) isFieldsetOption() {}
+func (DisabledAttribute) isInputOption()    {}
+func (DisabledAttribute) isLinkOption()     {}
+func (DisabledAttribute) isOptgroupOption() {}
+func (DisabledAttribute) isOptionOption()   {}
+func (DisabledAttribute) isSelectOption()   {}
+func (DisabledAttribute) isTextareaOption() {}
+
+// DownloadAttribute is the download attribute, accepted by the a and area elements.
+type DownloadAttribute struct {
+	attribute
+}
+
+// Download sets the download attribute, whether to download the resource instead of navigating to it, and its
+// filename if so.
+func Download(value string) DownloadAttribute {
+	return DownloadAttribute{attribute{name: "download", value: value}}
+}
+
+func (DownloadAttribute) isAOption()    {}
+func (DownloadAttribute) isAreaOption() {}
+
+// EncTypeAttribute is the enctype attribute, accepted by the form elements.
+type EncTypeAttribute struct {
+	attribute
+}
+
+// EncType sets the enctype attribute, entry list encoding type to use for form submission.
+func EncType(value string) EncTypeAttribute {
+	return EncTypeAttribute{attribute{name: "enctype", value: value}}
+}
+
+func (EncTypeAttribute) isFormOption() {}
+
+// FetchPriorityAttribute is the fetchpriority attribute, accepted by the img, link and script elements.
+type FetchPriorityAttribute struct {
+	attribute
+}
+
+// FetchPriority sets the fetchpriority attribute, sets the priority for fetches initiated by the element.
+func FetchPriority(value string) FetchPriorityAttribute {
+	return FetchPriorityAttribute{attribute{name: "fetchpriority", value: value}}
+}
+
+func (FetchPriorityAttribute) isImgOption()    {}
+func (FetchPriorityAttribute) isLinkOption()   {}
+func (FetchPriorityAttribute) isScriptOption() {}
+
+// ForAttribute is the for attribute, accepted by the label and output elements.
+type ForAttribute struct {
+	attribute
+}
+
+// For sets the for attribute, associate the label with form control, or specifies controls from which the
+// output was calculated.
+func For(values ...string) ForAttribute {
+	return ForAttribute{attribute{name: "for", value: strings.Join(values, " ")}}
+}
+
+func (ForAttribute) isLabelOption()  {}
+func (ForAttribute) isOutputOption() {}
+
+// FormAttribute is the form attribute, accepted by the button, fieldset, input, object, output, select and
+// textarea elements.
+type FormAttribute struct {
+	attribute
+}
+
+// FormAttr sets the form attribute, associates the element with a form element.
+func FormAttr(value string) FormAttribute {
+	return FormAttribute{attribute{name: "form", value: value}}
+}
+
+func (FormAttribute) isButtonOption()   {}
+func (FormAttribute) isFieldsetOption() {}
+func (FormAttribute) isInputOption()    {}
+func (FormAttribute) isObjectOption()   {}
+func (FormAttribute) isOutputOption()   {}
+func (FormAttribute) isSelectOption()   {}
+func (FormAttribute) isTextareaOption() {}
+
+// FormActionAttribute is the formaction attribute, accepted by the button and input elements.
+type FormActionAttribute struct {
+	attribute
+}
+
+// FormAction sets the formaction attribute, uRL to use for form submission.
+func FormAction(value string) FormActionAttribute {
+	return FormActionAttribute{attribute{name: "formaction", value: value}}
+}
+
+func (FormActionAttribute) isButtonOption() {}
+func (FormActionAttribute) isInputOption()  {}
+
+// FormEncTypeAttribute is the formenctype attribute, accepted by the button and input elements.
+type FormEncTypeAttribute struct {
+	attribute
+}
+
+// FormEncType sets the formenctype attribute, entry list encoding type to use for form submission.
+func FormEncType(value string) FormEncTypeAttribute {
+	return FormEncTypeAttribute{attribute{name: "formenctype", value: value}}
+}
+
+func (FormEncTypeAttribute) isButtonOption() {}
+func (FormEncTypeAttribute) isInputOption()  {}
+
+// FormMethodAttribute is the formmethod attribute, accepted by the button and input elements.
+type FormMethodAttribute struct {
+	attribute
+}
+
+// FormMethod sets the formmethod attribute, variant to use for form submission.
+func FormMethod(value string) FormMethodAttribute {
+	return FormMethodAttribute{attribute{name: "formmethod", value: value}}
+}
+
+func (FormMethodAttribute) isButtonOption() {}
+func (FormMethodAttribute) isInputOption()  {}
+
+// FormNoValidateAttribute is the formnovalidate attribute, accepted by the button and input elements.
+type FormNoValidateAttribute struct {
+	attribute
+}
+
+// FormNoValidate sets the formnovalidate attribute, bypass form control validation for form submission.
+func FormNoValidate(value bool) FormNoValidateAttribute {
+	return FormNoValidateAttribute{attribute{name: "formnovalidate", value: value}}
+}
+
+func (FormNoValidateAttribute) isButtonOption() {}
+func (FormNoValidateAttribute) isInputOption()  {}
+
+// FormTargetAttribute is the formtarget attribute, accepted by the button and input elements.
+type FormTargetAttribute struct {
+	attribute
+}
+
+// FormTarget sets the formtarget attribute, navigable for form submission.
+func FormTarget(value string) FormTargetAttribute {
+	return FormTargetAttribute{attribute{name: "formtarget", value: value}}
+}
+
+func (FormTargetAttribute) isButtonOption() {}
+func (FormTargetAttribute) isInputOption()  {}
+
+// HeadersAttribute is the headers attribute, accepted by the td and th elements.
+type HeadersAttribute struct {
+	attribute
+}
+
+// Headers sets the headers attribute, the header cells for this cell.
+func Headers(values ...string) HeadersAttribute {
+	return HeadersAttribute{attribute{name: "headers", value: strings.Join(values, " ")}}
+}
+
+func (HeadersAttribute) isTdOption() {}
+func (HeadersAttribute) isThOption() {}
+
+// HeightAttribute is the height attribute, accepted by the canvas, embed, iframe, img, input, object, source
+// and video elements.
+type HeightAttribute struct {
+	attribute
+}
+
+// Height sets the height attribute, vertical dimension.
+func Height(value int) HeightAttribute {
+	return HeightAttribute{attribute{name: "height", value: value}}
+}
+
+func (HeightAttribute) isCanvasOption() {}
+func (HeightAttribute) isEmbedOption()  {}
+func (HeightAttribute) isIframeOption() {}
+func (HeightAttribute) isImgOption()    {}
+func (HeightAttribute) isInputOption()  {}
+func (HeightAttribute) isObjectOption() {}
+func (HeightAttribute) isSourceOption() {}
+func (HeightAttribute) isVideoOption()  {}
+
+// HighAttribute is the high attribute, accepted by the meter elements.
+type HighAttribute struct {
+	attribute
+}
+
+// High sets the high attribute, low limit of high range.
+func High(value float64) HighAttribute {
+	return HighAttribute{attribute{name: "high", value: value}}
+}
+
+func (HighAttribute) isMeterOption() {}
+
+// HrefAttribute is the href attribute, accepted by the a, area, base and link elements.
+type HrefAttribute struct {
+	attribute
+}
+
+// Href sets the href attribute, address of the hyperlink or document base URL.
+func Href(value string) HrefAttribute {
+	return HrefAttribute{attribute{name: "href", value: value}}
+}
+
+func (HrefAttribute) isAOption()    {}
+func (HrefAttribute) isAreaOption() {}
+func (HrefAttribute) isBaseOption() {}
+func (HrefAttribute) isLinkOption() {}
+
+// HrefLangAttribute is the hreflang attribute, accepted by the a and link elements.
+type HrefLangAttribute struct {
+	attribute
+}
+
+// HrefLang sets the hreflang attribute, language of the linked resource.
+func HrefLang(value string) HrefLangAttribute {
+	return HrefLangAttribute{attribute{name: "hreflang", value: value}}
+}
+
+func (HrefLangAttribute) isAOption()    {}
+func (HrefLangAttribute) isLinkOption() {}
+
+// HTTPEquivAttribute is the http-equiv attribute, accepted by the meta elements.
+type HTTPEquivAttribute struct {
+	attribute
+}
+
+// HTTPEquiv sets the http-equiv attribute, pragma directive.
+func HTTPEquiv(value string) HTTPEquivAttribute {
+	return HTTPEquivAttribute{attribute{name: "http-equiv", value: value}}
+}
+
+func (HTTPEquivAttribute) isMetaOption() {}
+
+// ImageSizesAttribute is the imagesizes attribute, accepted by the link elements.
+type ImageSizesAttribute struct {
+	attribute
+}
+
+// ImageSizes sets the imagesizes attribute, image sizes for different page layouts (for rel="preload").
+func ImageSizes(value string) ImageSizesAttribute {
+	return ImageSizesAttribute{attribute{name: "imagesizes", value: value}}
+}
+
+func (ImageSizesAttribute) isLinkOption() {}
+
+// ImageSrcSetAttribute is the imagesrcset attribute, accepted by the link elements.
+type ImageSrcSetAttribute struct {
+	attribute
+}
+
+// ImageSrcSet sets the imagesrcset attribute, images to use in different situations, e.g., high-resolution
+// displays, small monitors, etc. (for rel="preload").
+func ImageSrcSet(value string) ImageSrcSetAttribute {
+	return ImageSrcSetAttribute{attribute{name: "imagesrcset", value: value}}
+}
+
+func (ImageSrcSetAttribute) isLinkOption() {}
+
+// IntegrityAttribute is the integrity attribute, accepted by the link and script elements.
+type IntegrityAttribute struct {
+	attribute
+}
+
+// Integrity sets the integrity attribute, integrity metadata used in Subresource Integrity checks.
+func Integrity(value string) IntegrityAttribute {
+	return IntegrityAttribute{attribute{name: "integrity", value: value}}
+}
+
+func (IntegrityAttribute) isLinkOption()   {}
+func (IntegrityAttribute) isScriptOption() {}
+
+// IsMapAttribute is the ismap attribute, accepted by the img elements.
+type IsMapAttribute struct {
+	attribute
+}
+
+// IsMap sets the ismap attribute, whether the image is a server-side image map.
+func IsMap(value bool) IsMapAttribute {
+	return IsMapAttribute{attribute{name: "ismap", value: value}}
+}
+
+func (IsMapAttribute) isImgOption() {}
+
+// KindAttribute is the kind attribute, accepted by the track elements.
+type KindAttribute struct {
+	attribute
+}
+
+// Kind sets the kind attribute, the type of text track.
+func Kind(value string) KindAttribute {
+	return KindAttribute{attribute{name: "kind", value: value}}
+}
+
+func (KindAttribute) isTrackOption() {}
+
+// LabelAttribute is the label attribute, accepted by the optgroup, option and track elements.
+type LabelAttribute struct {
+	attribute
+}
+
+// LabelAttr sets the label attribute, user-visible label.
+func LabelAttr(value string) LabelAttribute {
+	return LabelAttribute{attribute{name: "label", value: value}}
+}
+
+func (LabelAttribute) isOptgroupOption() {}
+func (LabelAttribute) isOptionOption()   {}
+func (LabelAttribute) isTrackOption()    {}
+
+// ListAttribute is the list attribute, accepted by the input elements.
+type ListAttribute struct {
+	attribute
+}
+
+// List sets the list attribute, list of autocomplete options.
+func List(value string) ListAttribute {
+	return ListAttribute{attribute{name: "list", value: value}}
+}
+
+func (ListAttribute) isInputOption() {}
+
+// LoadingAttribute is the loading attribute, accepted by the iframe and img elements.
+type LoadingAttribute struct {
+	attribute
+}
+
+// Loading sets the loading attribute, used when determining loading deferral.
+func Loading(value string) LoadingAttribute {
+	return LoadingAttribute{attribute{name: "loading", value: value}}
+}
+
+func (LoadingAttribute) isIframeOption() {}
+func (LoadingAttribute) isImgOption()    {}
+
+// LoopAttribute is the loop attribute, accepted by the audio and video elements.
+type LoopAttribute struct {
+	attribute
+}
+
+// Loop sets the loop attribute, whether to loop the media resource.
+func Loop(value bool) LoopAttribute {
+	return LoopAttribute{attribute{name: "loop", value: value}}
+}
+
+func (LoopAttribute) isAudioOption() {}
+func (LoopAttribute) isVideoOption() {}
+
+// LowAttribute is the low attribute, accepted by the meter elements.
+type LowAttribute struct {
+	attribute
+}
+
+// Low sets the low attribute, high limit of low range.
+func Low(value float64) LowAttribute {
+	return LowAttribute{attribute{name: "low", value: value}}
+}
+
+func (LowAttribute) isMeterOption() {}
+
+// MaxAttribute is the max attribute, accepted by the input, meter and progress elements.
+type MaxAttribute struct {
+	attribute
+}
+
+// Max sets the max attribute, maximum value.
+func Max(value string) MaxAttribute {
+	return MaxAttribute{attribute{name: "max", value: value}}
+}
+
+func (MaxAttribute) isInputOption()    {}
+func (MaxAttribute) isMeterOption()    {}
+func (MaxAttribute) isProgressOption() {}
+
+// MaxLengthAttribute is the maxlength attribute, accepted by the input and textarea elements.
+type MaxLengthAttribute struct {
+	attribute
+}
+
+// MaxLength sets the maxlength attribute, maximum length of value.
+func MaxLength(value int) MaxLengthAttribute {
+	return MaxLengthAttribute{attribute{name: "maxlength", value: value}}
+}
+
+func (MaxLengthAttribute) isInputOption()    {}
+func (MaxLengthAttribute) isTextareaOption() {}
+
+// MediaAttribute is the media attribute, accepted by the link, meta, source and style elements.
+type MediaAttribute struct {
+	attribute
+}
+
+// Media sets the media attribute, applicable media.
+func Media(value string) MediaAttribute {
+	return MediaAttribute{attribute{name: "media", value: value}}
+}
+
+func (MediaAttribute) isLinkOption()   {}
+func (MediaAttribute) isMetaOption()   {}
+func (MediaAttribute) isSourceOption() {}
+func (MediaAttribute) isStyleOption()  {}
+
+// MethodAttribute is the method attribute, accepted by the form elements.
+type MethodAttribute struct {
+	attribute
+}
+
+// Method sets the method attribute, variant to use for form submission.
+func Method(value string) MethodAttribute {
+	return MethodAttribute{attribute{name: "method", value: value}}
+}
+
+func (MethodAttribute) isFormOption() {}
+
+// MinAttribute is the min attribute, accepted by the input and meter elements.
+type MinAttribute struct {
+	attribute
+}
+
+// Min sets the min attribute, minimum value.
+func Min(value string) MinAttribute {
+	return MinAttribute{attribute{name: "min", value: value}}
+}
+
+func (MinAttribute) isInputOption() {}
+func (MinAttribute) isMeterOption() {}
+
+// MinLengthAttribute is the minlength attribute, accepted by the input and textarea elements.
+type MinLengthAttribute struct {
+	attribute
+}
+
+// MinLength sets the minlength attribute, minimum length of value.
+func MinLength(value int) MinLengthAttribute {
+	return MinLengthAttribute{attribute{name: "minlength", value: value}}
+}
+
+func (MinLengthAttribute) isInputOption()    {}
+func (MinLengthAttribute) isTextareaOption() {}
+
+// MultipleAttribute is the multiple attribute, accepted by the input and select elements.
+type MultipleAttribute struct {
+	attribute
+}
+
+// Multiple sets the multiple attribute, whether to allow multiple values.
+func Multiple(value bool) MultipleAttribute {
+	return MultipleAttribute{attribute{name: "multiple", value: value}}
+}
+
+func (MultipleAttribute) isInputOption()  {}
+func (MultipleAttribute) isSelectOption() {}
+
+// MutedAttribute is the muted attribute, accepted by the audio and video elements.
+type MutedAttribute struct {
+	attribute
+}
+
+// Muted sets the muted attribute, whether to mute the media resource by default.
+func Muted(value bool) MutedAttribute {
+	return MutedAttribute{attribute{name: "muted", value: value}}
+}
+
+func (MutedAttribute) isAudioOption() {}
+func (MutedAttribute) isVideoOption() {}
+
+// NameAttribute is the name attribute, accepted by the button, details, fieldset, form, iframe, input, map,
+// meta, object, output, select, slot and textarea elements.
+type NameAttribute struct {
+	attribute
+}
+
+// Name sets the name attribute, name of the element to use for form submission, in the form.elements API, or
+// as the name of the navigable, image map, metadata or slot.
+func Name(value string) NameAttribute {
+	return NameAttribute{attribute{name: "name", value: value}}
+}
+
+func (NameAttribute) isButtonOption()   {}
+func (NameAttribute) isDetailsOption()  {}
+func (NameAttribute) isFieldsetOption() {}
+func (NameAttribute) isFormOption()     {}
+func (NameAttribute) isIframeOption()   {}
+func (NameAttribute) isInputOption()    {}
+func (NameAttribute) isMapOption()      {}
+func (NameAttribute) isMetaOption()     {}
+func (NameAttribute) isObjectOption()   {}
+func (NameAttribute) isOutputOption()   {}
+func (NameAttribute) isSelectOption()   {}
+func (NameAttribute) isSlotOption()     {}
+func (NameAttribute) isTextareaOption() {}
+
+// NoModuleAttribute is the nomodule attribute, accepted by the script elements.
+type NoModuleAttribute struct {
+	attribute
+}
+
+// NoModule sets the nomodule attribute, prevents execution in user agents that support module scripts.
+func NoModule(value bool) NoModuleAttribute {
+	return NoModuleAttribute{attribute{name: "nomodule", value: value}}
+}
+
+func (NoModuleAttribute) isScriptOption() {}
+
+// NoValidateAttribute is the novalidate attribute, accepted by the form elements.
+type NoValidateAttribute struct {
+	attribute
+}
+
+// NoValidate sets the novalidate attribute, bypass form control validation for form submission.
+func NoValidate(value bool) NoValidateAttribute {
+	return NoValidateAttribute{attribute{name: "novalidate", value: value}}
+}
+
+func (NoValidateAttribute) isFormOption() {}
+
+// OpenAttribute is the open attribute, accepted by the details and dialog elements.
+type OpenAttribute struct {
+	attribute
+}
+
+// Open sets the open attribute, whether the details are visible, or whether the dialog box is showing.
+func Open(value bool) OpenAttribute {
+	return OpenAttribute{attribute{name: "open", value: value}}
+}
+
+func (OpenAttribute) isDetailsOption() {}
+func (OpenAttribute) isDialogOption()  {}
+
+// OptimumAttribute is the optimum attribute, accepted by the meter elements.
+type OptimumAttribute struct {
+	attribute
+}
+
+// Optimum sets the optimum attribute, optimum value in gauge.
+func Optimum(value float64) OptimumAttribute {
+	return OptimumAttribute{attribute{name: "optimum", value: value}}
+}
+
+func (OptimumAttribute) isMeterOption() {}
+
+// PatternAttribute is the pattern attribute, accepted by the input elements.
+type PatternAttribute struct {
+	attribute
+}
+
+// Pattern sets the pattern attribute, pattern to be matched by the form control's value.
+func Pattern(value string) PatternAttribute {
+	return PatternAttribute{attribute{name: "pattern", value: value}}
+}
+
+func (PatternAttribute) isInputOption() {}
+
+// PingAttribute is the ping attribute, accepted by the a and area elements.
+type PingAttribute struct {
+	attribute
+}
+
+// Ping sets the ping attribute, uRLs to ping.
+func Ping(values ...string) PingAttribute {
+	return PingAttribute{attribute{name: "ping", value: strings.Join(values, " ")}}
+}
+
+func (PingAttribute) isAOption()    {}
+func (PingAttribute) isAreaOption() {}
+
+// PlaceholderAttribute is the placeholder attribute, accepted by the input and textarea elements.
+type PlaceholderAttribute struct {
+	attribute
+}
+
+// Placeholder sets the placeholder attribute, user-visible label to be placed within the form control.
+func Placeholder(value string) PlaceholderAttribute {
+	return PlaceholderAttribute{attribute{name: "placeholder", value: value}}
+}
+
+func (PlaceholderAttribute) isInputOption()    {}
+func (PlaceholderAttribute) isTextareaOption() {}
+
+// PlaysInlineAttribute is the playsinline attribute, accepted by the video elements.
+type PlaysInlineAttribute struct {
+	attribute
+}
+
+// PlaysInline sets the playsinline attribute, encourage the user agent to display video content within the
+// element's playback area.
+func PlaysInline(value bool) PlaysInlineAttribute {
+	return PlaysInlineAttribute{attribute{name: "playsinline", value: value}}
+}
+
+func (PlaysInlineAttribute) isVideoOption() {}
+
+// PopoverTargetAttribute is the popovertarget attribute, accepted by the button and input elements.
+type PopoverTargetAttribute struct {
+	attribute
+}
+
+// PopoverTarget sets the popovertarget attribute, targets a popover element to toggle, show, or hide.
+func PopoverTarget(value string) PopoverTargetAttribute {
+	return PopoverTargetAttribute{attribute{name: "popovertarget", value: value}}
+}
+
+func (PopoverTargetAttribute) isButtonOption() {}
+func (PopoverTargetAttribute) isInputOption()  {}
+
+// PopoverTargetActionAttribute is the popovertargetaction attribute, accepted by the button and input
+// elements.
+type PopoverTargetActionAttribute struct {
+	attribute
+}
+
+// PopoverTargetAction sets the popovertargetaction attribute, indicates whether a targeted popover element is
+// to be toggled, shown, or hidden.
+func PopoverTargetAction(value string) PopoverTargetActionAttribute {
+	return PopoverTargetActionAttribute{attribute{name: "popovertargetaction", value: value}}
+}
+
+func (PopoverTargetActionAttribute) isButtonOption() {}
+func (PopoverTargetActionAttribute) isInputOption()  {}
+
+// PosterAttribute is the poster attribute, accepted by the video elements.
+type PosterAttribute struct {
+	attribute
+}
+
+// Poster sets the poster attribute, poster frame to show prior to video playback.
+func Poster(value string) PosterAttribute {
+	return PosterAttribute{attribute{name: "poster", value: value}}
+}
+
+func (PosterAttribute) isVideoOption() {}
+
+// PreloadAttribute is the preload attribute, accepted by the audio and video elements.
+type PreloadAttribute struct {
+	attribute
+}
+
+// Preload sets the preload attribute, hints how much buffering the media resource will likely need.
+func Preload(value string) PreloadAttribute {
+	return PreloadAttribute{attribute{name: "preload", value: value}}
+}
+
+func (PreloadAttribute) isAudioOption() {}
+func (PreloadAttribute) isVideoOption() {}
+
+// ReadOnlyAttribute is the readonly attribute, accepted by the input and textarea elements.
+type ReadOnlyAttribute struct {
+	attribute
+}
+
+// ReadOnly sets the readonly attribute, whether to allow the value to be edited by the user.
+func ReadOnly(value bool) ReadOnlyAttribute {
+	return ReadOnlyAttribute{attribute{name: "readonly", value: value}}
+}
+
+func (ReadOnlyAttribute) isInputOption()    {}
+func (ReadOnlyAttribute) isTextareaOption() {}
+
+// ReferrerPolicyAttribute is the referrerpolicy attribute, accepted by the a, area, iframe, img, link and
+// script elements.
+type ReferrerPolicyAttribute struct {
+	attribute
+}
+
+// ReferrerPolicy sets the referrerpolicy attribute, referrer policy for fetches initiated by the element.
+func ReferrerPolicy(value string) ReferrerPolicyAttribute {
+	return ReferrerPolicyAttribute{attribute{name: "referrerpolicy", value: value}}
+}
+
+func (ReferrerPolicyAttribute) isAOption()      {}
+func (ReferrerPolicyAttribute) isAreaOption()   {}
+func (ReferrerPolicyAttribute) isIframeOption() {}
+func (ReferrerPolicyAttribute) isImgOption()    {}
+func (ReferrerPolicyAttribute) isLinkOption()   {}
+func (ReferrerPolicyAttribute) isScriptOption() {}
+
+// RelAttribute is the rel attribute, accepted by the a, area, form and link elements.
+type RelAttribute struct {
+	attribute
+}
+
+// Rel sets the rel attribute, relationship between the location in the document containing the hyperlink and
+// the destination resource.
+func Rel(values ...string) RelAttribute {
+	return RelAttribute{attribute{name: "rel", value: strings.Join(values, " ")}}
+}
+
+func (RelAttribute) isAOption()    {}
+func (RelAttribute) isAreaOption() {}
+func (RelAttribute) isFormOption() {}
+func (RelAttribute) isLinkOption() {}
+
+// RequiredAttribute is the required attribute, accepted by the input, select and textarea elements.
+type RequiredAttribute struct {
+	attribute
+}
+
+// Required sets the required attribute, whether the control is required for form submission.
+func Required(value bool) RequiredAttribute {
+	return RequiredAttribute{attribute{name: "required", value: value}}
+}
+
+func (RequiredAttribute) isInputOption()    {}
+func (RequiredAttribute) isSelectOption()   {}
+func (RequiredAttribute) isTextareaOption() {}
+
+// ReversedAttribute is the reversed attribute, accepted by the ol elements.
+type ReversedAttribute struct {
+	attribute
+}
+
+// Reversed sets the reversed attribute, number the list backwards.
+func Reversed(value bool) ReversedAttribute {
+	return ReversedAttribute{attribute{name: "reversed", value: value}}
+}
+
+func (ReversedAttribute) isOlOption() {}
+
+// RowsAttribute is the rows attribute, accepted by the textarea elements.
+type RowsAttribute struct {
+	attribute
+}
+
+// Rows sets the rows attribute, number of lines to show.
+func Rows(value int) RowsAttribute {
+	return RowsAttribute{attribute{name: "rows", value: value}}
+}
+
+func (RowsAttribute) isTextareaOption() {}
+
+// RowSpanAttribute is the rowspan attribute, accepted by the td and th elements.
+type RowSpanAttribute struct {
+	attribute
+}
+
+// RowSpan sets the rowspan attribute, number of rows that the cell is to span.
+func RowSpan(value int) RowSpanAttribute {
+	return RowSpanAttribute{attribute{name: "rowspan", value: value}}
+}
+
+func (RowSpanAttribute) isTdOption() {}
+func (RowSpanAttribute) isThOption() {}
+
+// SandboxAttribute is the sandbox attribute, accepted by the iframe elements.
+type SandboxAttribute struct {
+	attribute
+}
+
+// Sandbox sets the sandbox attribute, security rules for nested content.
+func Sandbox(values ...string) SandboxAttribute {
+	return SandboxAttribute{attribute{name: "sandbox", value: strings.Join(values, " ")}}
+}
+
+func (SandboxAttribute) isIframeOption() {}
+
+// ScopeAttribute is the scope attribute, accepted by the th elements.
+type ScopeAttribute struct {
+	attribute
+}
+
+// Scope sets the scope attribute, specifies which cells the header cell applies to.
+func Scope(value string) ScopeAttribute {
+	return ScopeAttribute{attribute{name: "scope", value: value}}
+}
+
+func (ScopeAttribute) isThOption() {}
+
+// SelectedAttribute is the selected attribute, accepted by the option elements.
+type SelectedAttribute struct {
+	attribute
+}
+
+// Selected sets the selected attribute, whether the option is selected by default.
+func Selected(value bool) SelectedAttribute {
+	return SelectedAttribute{attribute{name: "selected", value: value}}
+}
+
+func (SelectedAttribute) isOptionOption() {}
+
+// ShapeAttribute is the shape attribute, accepted by the area elements.
+type ShapeAttribute struct {
+	attribute
+}
+
+// Shape sets the shape attribute, the kind of shape to be created in an image map.
+func Shape(value string) ShapeAttribute {
+	return ShapeAttribute{attribute{name: "shape", value: value}}
+}
+
+func (ShapeAttribute) isAreaOption() {}
+
+// SizeAttribute is the size attribute, accepted by the input and select elements.
+type SizeAttribute struct {
+	attribute
+}
+
+// Size sets the size attribute, size of the control.
+func Size(value int) SizeAttribute {
+	return SizeAttribute{attribute{name: "size", value: value}}
+}
+
+func (SizeAttribute) isInputOption()  {}
+func (SizeAttribute) isSelectOption() {}
+
+// SizesAttribute is the sizes attribute, accepted by the img, link and source elements.
+type SizesAttribute struct {
+	attribute
+}
+
+// Sizes sets the sizes attribute, sizes of the icons (for rel="icon"), or image sizes for different page
+// layouts.
+func Sizes(value string) SizesAttribute {
+	return SizesAttribute{attribute{name: "sizes", value: value}}
+}
+
+func (SizesAttribute) isImgOption()    {}
+func (SizesAttribute) isLinkOption()   {}
+func (SizesAttribute) isSourceOption() {}
+
+// SpanAttribute is the span attribute, accepted by the col and colgroup elements.
+type SpanAttribute struct {
+	attribute
+}
+
+// SpanAttr sets the span attribute, number of columns spanned by the element.
+func SpanAttr(value int) SpanAttribute {
+	return SpanAttribute{attribute{name: "span", value: value}}
+}
+
+func (SpanAttribute) isColOption()      {}
+func (SpanAttribute) isColgroupOption() {}
+
+// SrcAttribute is the src attribute, accepted by the audio, embed, iframe, img, input, script, source, track
+// and video elements.
+type SrcAttribute struct {
+	attribute
+}
+
+// Src sets the src attribute, address of the resource.
+func Src(value string) SrcAttribute {
+	return SrcAttribute{attribute{name: "src", value: value}}
+}
+
+func (SrcAttribute) isAudioOption()  {}
+func (SrcAttribute) isEmbedOption()  {}
+func (SrcAttribute) isIframeOption() {}
+func (SrcAttribute) isImgOption()    {}
+func (SrcAttribute) isInputOption()  {}
+func (SrcAttribute) isScriptOption() {}
+func (SrcAttribute) isSourceOption() {}
+func (SrcAttribute) isTrackOption()  {}
+func (SrcAttribute) isVideoOption()  {}
+
+// SrcDocAttribute is the srcdoc attribute, accepted by the iframe elements.
+type SrcDocAttribute struct {
+	attribute
+}
+
+// SrcDoc sets the srcdoc attribute, a document to render in the iframe.
+func SrcDoc(value string) SrcDocAttribute {
+	return SrcDocAttribute{attribute{name: "srcdoc", value: value}}
+}
+
+func (SrcDocAttribute) isIframeOption() {}
+
+// SrcLangAttribute is the srclang attribute, accepted by the track elements.
+type SrcLangAttribute struct {
+	attribute
+}
+
+// SrcLang sets the srclang attribute, language of the text track.
+func SrcLang(value string) SrcLangAttribute {
+	return SrcLangAttribute{attribute{name: "srclang", value: value}}
+}
+
+func (SrcLangAttribute) isTrackOption() {}
+
+// SrcSetAttribute is the srcset attribute, accepted by the img and source elements.
+type SrcSetAttribute struct {
+	attribute
+}
+
+// SrcSet sets the srcset attribute, images to use in different situations, e.g., high-resolution displays,
+// small monitors, etc..
+func SrcSet(value string) SrcSetAttribute {
+	return SrcSetAttribute{attribute{name: "srcset", value: value}}
+}
+
+func (SrcSetAttribute) isImgOption()    {}
+func (SrcSetAttribute) isSourceOption() {}
+
+// StartAttribute is the start attribute, accepted by the ol elements.
+type StartAttribute struct {
+	attribute
+}
+
+// Start sets the start attribute, starting value of the list.
+func Start(value int) StartAttribute {
+	return StartAttribute{attribute{name: "start", value: value}}
+}
+
+func (StartAttribute) isOlOption() {}
+
+// StepAttribute is the step attribute, accepted by the input elements.
+type StepAttribute struct {
+	attribute
+}
+
+// Step sets the step attribute, granularity to be matched by the form control's value.
+func Step(value string) StepAttribute {
+	return StepAttribute{attribute{name: "step", value: value}}
+}
+
+func (StepAttribute) isInputOption() {}
+
+// TargetAttribute is the target attribute, accepted by the a, area, base and form elements.
+type TargetAttribute struct {
+	attribute
+}
+
+// Target sets the target attribute, navigable for hyperlink navigation or form submission.
+func Target(value string) TargetAttribute {
+	return TargetAttribute{attribute{name: "target", value: value}}
+}
+
+func (TargetAttribute) isAOption()    {}
+func (TargetAttribute) isAreaOption() {}
+func (TargetAttribute) isBaseOption() {}
+func (TargetAttribute) isFormOption() {}
+
+// TypeAttribute is the type attribute, accepted by the a, button, embed, input, link, object, ol, script,
+// source and style elements.
+type TypeAttribute struct {
+	attribute
+}
+
+// Type sets the type attribute, type of the element, of the form control, of the list marker, or hint for the
+// type of the referenced resource.
+func Type(value string) TypeAttribute {
+	return TypeAttribute{attribute{name: "type", value: value}}
+}
+
+func (TypeAttribute) isAOption()      {}
+func (TypeAttribute) isButtonOption() {}
+func (TypeAttribute) isEmbedOption()  {}
+func (TypeAttribute) isInputOption()  {}
+func (TypeAttribute) isLinkOption()   {}
+func (TypeAttribute) isObjectOption() {}
+func (TypeAttribute) isOlOption()     {}
+func (TypeAttribute) isScriptOption() {}
+func (TypeAttribute) isSourceOption() {}
+func (TypeAttribute) isStyleOption()  {}
+
+// UseMapAttribute is the usemap attribute, accepted by the img elements.
+type UseMapAttribute struct {
+	attribute
+}
+
+// UseMap sets the usemap attribute, name of image map to use.
+func UseMap(value string) UseMapAttribute {
+	return UseMapAttribute{attribute{name: "usemap", value: value}}
+}
+
+func (UseMapAttribute) isImgOption() {}
+
+// ValueAttribute is the value attribute, accepted by the button, data, input, li, meter, option and progress
+// elements.
+type ValueAttribute struct {
+	attribute
+}
+
+// Value sets the value attribute, value to be used for form submission, machine-readable value, ordinal value
+// of the list item, or current value of the element.
+func Value(value string) ValueAttribute {
+	return ValueAttribute{attribute{name: "value", value: value}}
+}
+
+func (ValueAttribute) isButtonOption()   {}
+func (ValueAttribute) isDataOption()     {}
+func (ValueAttribute) isInputOption()    {}
+func (ValueAttribute) isLiOption()       {}
+func (ValueAttribute) isMeterOption()    {}
+func (ValueAttribute) isOptionOption()   {}
+func (ValueAttribute) isProgressOption() {}
+
+// WidthAttribute is the width attribute, accepted by the canvas, embed, iframe, img, input, object, source
+// and video elements.
+type WidthAttribute struct {
+	attribute
+}
+
+// Width sets the width attribute, horizontal dimension.
+func Width(value int) WidthAttribute {
+	return WidthAttribute{attribute{name: "width", value: value}}
+}
+
+func (WidthAttribute) isCanvasOption() {}
+func (WidthAttribute) isEmbedOption()  {}
+func (WidthAttribute) isIframeOption() {}
+func (WidthAttribute) isImgOption()    {}
+func (WidthAttribute) isInputOption()  {}
+func (WidthAttribute) isObjectOption() {}
+func (WidthAttribute) isSourceOption() {}
+func (WidthAttribute) isVideoOption()  {}
+
+// WrapAttribute is the wrap attribute, accepted by the textarea elements.
+type WrapAttribute struct {
+	attribute
+}
+
+// Wrap sets the wrap attribute, how the value of the form control is to be wrapped for form submission.
+func Wrap(value string) WrapAttribute {
+	return WrapAttribute{attribute{name: "wrap", value: value}}
+}
+
+func (WrapAttribute) isTextareaOption() {}
@@ -0,0 +1,1461 @@
+// Code generated by go run ./internal/gen; DO NOT EDIT.
+// Source: https://html.spec.whatwg.org/multipage/indices.html
+
+package html
+
+import (
+	"github.com/minivera/go-lander/nodes"
+)
+
+// AOption is an option accepted by A.
+type AOption interface {
+	ElementOption
+	isAOption()
+}
+
+// A creates an HTML node for the a element, hyperlink.
+func A(options ...AOption) *nodes.HTMLNode {
+	return newElement("a", options)
+}
+
+// AbbrOption is an option accepted by Abbr.
+type AbbrOption interface {
+	ElementOption
+	isAbbrOption()
+}
+
+// Abbr creates an HTML node for the abbr element, abbreviation.
+func Abbr(options ...AbbrOption) *nodes.HTMLNode {
+	return newElement("abbr", options)
+}
+
+// AddressOption is an option accepted by Address.
+type AddressOption interface {
+	ElementOption
+	isAddressOption()
+}
+
+// Address creates an HTML node for the address element, contact information for a page or article element.
+func Address(options ...AddressOption) *nodes.HTMLNode {
+	return newElement("address", options)
+}
+
+// AreaOption is an option accepted by Area.
+type AreaOption interface {
+	ElementOption
+	isAreaOption()
+}
+
+// Area creates an HTML node for the area element, hyperlink or dead area on an image map. The element is
+// void, it never has children.
+func Area(options ...AreaOption) *nodes.HTMLNode {
+	return newElement("area", options)
+}
+
+// ArticleOption is an option accepted by Article.
+type ArticleOption interface {
+	ElementOption
+	isArticleOption()
+}
+
+// Article creates an HTML node for the article element, self-contained syndicatable or reusable composition.
+func Article(options ...ArticleOption) *nodes.HTMLNode {
+	return newElement("article", options)
+}
+
+// AsideOption is an option accepted by Aside.
+type AsideOption interface {
+	ElementOption
+	isAsideOption()
+}
+
+// Aside creates an HTML node for the aside element, sidebar for tangentially related content.
+func Aside(options ...AsideOption) *nodes.HTMLNode {
+	return newElement("aside", options)
+}
+
+// AudioOption is an option accepted by Audio.
+type AudioOption interface {
+	ElementOption
+	isAudioOption()
+}
+
+// Audio creates an HTML node for the audio element, audio player.
+func Audio(options ...AudioOption) *nodes.HTMLNode {
+	return newElement("audio", options)
+}
+
+// BOption is an option accepted by B.
+type BOption interface {
+	ElementOption
+	isBOption()
+}
+
+// B creates an HTML node for the b element, keywords.
+func B(options ...BOption) *nodes.HTMLNode {
+	return newElement("b", options)
+}
+
+// BaseOption is an option accepted by Base.
+type BaseOption interface {
+	ElementOption
+	isBaseOption()
+}
+
+// Base creates an HTML node for the base element, base URL and default target navigable for hyperlinks and
+// forms. The element is void, it never has children.
+func Base(options ...BaseOption) *nodes.HTMLNode {
+	return newElement("base", options)
+}
+
+// BdiOption is an option accepted by Bdi.
+type BdiOption interface {
+	ElementOption
+	isBdiOption()
+}
+
+// Bdi creates an HTML node for the bdi element, text directionality isolation.
+func Bdi(options ...BdiOption) *nodes.HTMLNode {
+	return newElement("bdi", options)
+}
+
+// BdoOption is an option accepted by Bdo.
+type BdoOption interface {
+	ElementOption
+	isBdoOption()
+}
+
+// Bdo creates an HTML node for the bdo element, text directionality formatting.
+func Bdo(options ...BdoOption) *nodes.HTMLNode {
+	return newElement("bdo", options)
+}
+
+// BlockquoteOption is an option accepted by Blockquote.
+type BlockquoteOption interface {
+	ElementOption
+	isBlockquoteOption()
+}
+
+// Blockquote creates an HTML node for the blockquote element, a section quoted from another source.
+func Blockquote(options ...BlockquoteOption) *nodes.HTMLNode {
+	return newElement("blockquote", options)
+}
+
+// BodyOption is an option accepted by Body.
+type BodyOption interface {
+	ElementOption
+	isBodyOption()
+}
+
+// Body creates an HTML node for the body element, document body.
+func Body(options ...BodyOption) *nodes.HTMLNode {
+	return newElement("body", options)
+}
+
+// BrOption is an option accepted by Br.
+type BrOption interface {
+	ElementOption
+	isBrOption()
+}
+
+// Br creates an HTML node for the br element, line break, e.g. in poem or postal address. The element is
+// void, it never has children.
+func Br(options ...BrOption) *nodes.HTMLNode {
+	return newElement("br", options)
+}
+
+// ButtonOption is an option accepted by Button.
+type ButtonOption interface {
+	ElementOption
+	isButtonOption()
+}
+
+// Button creates an HTML node for the button element, button control.
+func Button(options ...ButtonOption) *nodes.HTMLNode {
+	return newElement("button", options)
+}
+
+// CanvasOption is an option accepted by Canvas.
+type CanvasOption interface {
+	ElementOption
+	isCanvasOption()
+}
+
+// Canvas creates an HTML node for the canvas element, scriptable bitmap canvas.
+func Canvas(options ...CanvasOption) *nodes.HTMLNode {
+	return newElement("canvas", options)
+}
+
+// CaptionOption is an option accepted by Caption.
+type CaptionOption interface {
+	ElementOption
+	isCaptionOption()
+}
+
+// Caption creates an HTML node for the caption element, table caption.
+func Caption(options ...CaptionOption) *nodes.HTMLNode {
+	return newElement("caption", options)
+}
+
+// CiteOption is an option accepted by Cite.
+type CiteOption interface {
+	ElementOption
+	isCiteOption()
+}
+
+// Cite creates an HTML node for the cite element, title of a work.
+func Cite(options ...CiteOption) *nodes.HTMLNode {
+	return newElement("cite", options)
+}
+
+// CodeOption is an option accepted by Code.
+type CodeOption interface {
+	ElementOption
+	isCodeOption()
+}
+
+// Code creates an HTML node for the code element, computer code.
+func Code(options ...CodeOption) *nodes.HTMLNode {
+	return newElement("code", options)
+}
+
+// ColOption is an option accepted by Col.
+type ColOption interface {
+	ElementOption
+	isColOption()
+}
+
+// Col creates an HTML node for the col element, table column. The element is void, it never has children.
+func Col(options ...ColOption) *nodes.HTMLNode {
+	return newElement("col", options)
+}
+
+// ColgroupOption is an option accepted by Colgroup.
+type ColgroupOption interface {
+	ElementOption
+	isColgroupOption()
+}
+
+// Colgroup creates an HTML node for the colgroup element, group of columns in a table.
+func Colgroup(options ...ColgroupOption) *nodes.HTMLNode {
+	return newElement("colgroup", options)
+}
+
+// DataOption is an option accepted by Data.
+type DataOption interface {
+	ElementOption
+	isDataOption()
+}
+
+// Data creates an HTML node for the data element, machine-readable equivalent.
+func Data(options ...DataOption) *nodes.HTMLNode {
+	return newElement("data", options)
+}
+
+// DatalistOption is an option accepted by Datalist.
+type DatalistOption interface {
+	ElementOption
+	isDatalistOption()
+}
+
+// Datalist creates an HTML node for the datalist element, container for options for combo box control.
+func Datalist(options ...DatalistOption) *nodes.HTMLNode {
+	return newElement("datalist", options)
+}
+
+// DdOption is an option accepted by Dd.
+type DdOption interface {
+	ElementOption
+	isDdOption()
+}
+
+// Dd creates an HTML node for the dd element, content for corresponding dt element(s).
+func Dd(options ...DdOption) *nodes.HTMLNode {
+	return newElement("dd", options)
+}
+
+// DelOption is an option accepted by Del.
+type DelOption interface {
+	ElementOption
+	isDelOption()
+}
+
+// Del creates an HTML node for the del element, a removal from the document.
+func Del(options ...DelOption) *nodes.HTMLNode {
+	return newElement("del", options)
+}
+
+// DetailsOption is an option accepted by Details.
+type DetailsOption interface {
+	ElementOption
+	isDetailsOption()
+}
+
+// Details creates an HTML node for the details element, disclosure control for hiding details.
+func Details(options ...DetailsOption) *nodes.HTMLNode {
+	return newElement("details", options)
+}
+
+// DfnOption is an option accepted by Dfn.
+type DfnOption interface {
+	ElementOption
+	isDfnOption()
+}
+
+// Dfn creates an HTML node for the dfn element, defining instance.
+func Dfn(options ...DfnOption) *nodes.HTMLNode {
+	return newElement("dfn", options)
+}
+
+// DialogOption is an option accepted by Dialog.
+type DialogOption interface {
+	ElementOption
+	isDialogOption()
+}
+
+// Dialog creates an HTML node for the dialog element, dialog box or window.
+func Dialog(options ...DialogOption) *nodes.HTMLNode {
+	return newElement("dialog", options)
+}
+
+// DivOption is an option accepted by Div.
+type DivOption interface {
+	ElementOption
+	isDivOption()
+}
+
+// Div creates an HTML node for the div element, generic flow container, or container for name-value groups in
+// dl elements.
+func Div(options ...DivOption) *nodes.HTMLNode {
+	return newElement("div", options)
+}
+
+// DlOption is an option accepted by Dl.
+type DlOption interface {
+	ElementOption
+	isDlOption()
+}
+
+// Dl creates an HTML node for the dl element, association list consisting of zero or more name-value groups.
+func Dl(options ...DlOption) *nodes.HTMLNode {
+	return newElement("dl", options)
+}
+
+// DtOption is an option accepted by Dt.
+type DtOption interface {
+	ElementOption
+	isDtOption()
+}
+
+// Dt creates an HTML node for the dt element, legend for corresponding dd element(s).
+func Dt(options ...DtOption) *nodes.HTMLNode {
+	return newElement("dt", options)
+}
+
+// EmOption is an option accepted by Em.
+type EmOption interface {
+	ElementOption
+	isEmOption()
+}
+
+// Em creates an HTML node for the em element, stress emphasis.
+func Em(options ...EmOption) *nodes.HTMLNode {
+	return newElement("em", options)
+}
+
+// EmbedOption is an option accepted by Embed.
+type EmbedOption interface {
+	ElementOption
+	isEmbedOption()
+}
+
+// Embed creates an HTML node for the embed element, plugin. The element is void, it never has children.
+func Embed(options ...EmbedOption) *nodes.HTMLNode {
+	return newElement("embed", options)
+}
+
+// FieldsetOption is an option accepted by Fieldset.
+type FieldsetOption interface {
+	ElementOption
+	isFieldsetOption()
+}
+
+// Fieldset creates an HTML node for the fieldset element, group of form controls.
+func Fieldset(options ...FieldsetOption) *nodes.HTMLNode {
+	return newElement("fieldset", options)
+}
+
+// FigcaptionOption is an option accepted by Figcaption.
+type FigcaptionOption interface {
+	ElementOption
+	isFigcaptionOption()
+}
+
+// Figcaption creates an HTML node for the figcaption element, caption for figure.
+func Figcaption(options ...FigcaptionOption) *nodes.HTMLNode {
+	return newElement("figcaption", options)
+}
+
+// FigureOption is an option accepted by Figure.
+type FigureOption interface {
+	ElementOption
+	isFigureOption()
+}
+
+// Figure creates an HTML node for the figure element, figure with optional caption.
+func Figure(options ...FigureOption) *nodes.HTMLNode {
+	return newElement("figure", options)
+}
+
+// FooterOption is an option accepted by Footer.
+type FooterOption interface {
+	ElementOption
+	isFooterOption()
+}
+
+// Footer creates an HTML node for the footer element, footer for a page or section.
+func Footer(options ...FooterOption) *nodes.HTMLNode {
+	return newElement("footer", options)
+}
+
+// FormOption is an option accepted by Form.
+type FormOption interface {
+	ElementOption
+	isFormOption()
+}
+
+// Form creates an HTML node for the form element, user-submittable form.
+func Form(options ...FormOption) *nodes.HTMLNode {
+	return newElement("form", options)
+}
+
+// H1Option is an option accepted by H1.
+type H1Option interface {
+	ElementOption
+	isH1Option()
+}
+
+// H1 creates an HTML node for the h1 element, heading of rank 1.
+func H1(options ...H1Option) *nodes.HTMLNode {
+	return newElement("h1", options)
+}
+
+// H2Option is an option accepted by H2.
+type H2Option interface {
+	ElementOption
+	isH2Option()
+}
+
+// H2 creates an HTML node for the h2 element, heading of rank 2.
+func H2(options ...H2Option) *nodes.HTMLNode {
+	return newElement("h2", options)
+}
+
+// H3Option is an option accepted by H3.
+type H3Option interface {
+	ElementOption
+	isH3Option()
+}
+
+// H3 creates an HTML node for the h3 element, heading of rank 3.
+func H3(options ...H3Option) *nodes.HTMLNode {
+	return newElement("h3", options)
+}
+
+// H4Option is an option accepted by H4.
+type H4Option interface {
+	ElementOption
+	isH4Option()
+}
+
+// H4 creates an HTML node for the h4 element, heading of rank 4.
+func H4(options ...H4Option) *nodes.HTMLNode {
+	return newElement("h4", options)
+}
+
+// H5Option is an option accepted by H5.
+type H5Option interface {
+	ElementOption
+	isH5Option()
+}
+
+// H5 creates an HTML node for the h5 element, heading of rank 5.
+func H5(options ...H5Option) *nodes.HTMLNode {
+	return newElement("h5", options)
+}
+
+// H6Option is an option accepted by H6.
+type H6Option interface {
+	ElementOption
+	isH6Option()
+}
+
+// H6 creates an HTML node for the h6 element, heading of rank 6.
+func H6(options ...H6Option) *nodes.HTMLNode {
+	return newElement("h6", options)
+}
+
+// HeadOption is an option accepted by Head.
+type HeadOption interface {
+	ElementOption
+	isHeadOption()
+}
+
+// Head creates an HTML node for the head element, container for document metadata.
+func Head(options ...HeadOption) *nodes.HTMLNode {
+	return newElement("head", options)
+}
+
+// HeaderOption is an option accepted by Header.
+type HeaderOption interface {
+	ElementOption
+	isHeaderOption()
+}
+
+// Header creates an HTML node for the header element, introductory or navigational aids for a page or
+// section.
+func Header(options ...HeaderOption) *nodes.HTMLNode {
+	return newElement("header", options)
+}
+
+// HgroupOption is an option accepted by Hgroup.
+type HgroupOption interface {
+	ElementOption
+	isHgroupOption()
+}
+
+// Hgroup creates an HTML node for the hgroup element, heading container.
+func Hgroup(options ...HgroupOption) *nodes.HTMLNode {
+	return newElement("hgroup", options)
+}
+
+// HrOption is an option accepted by Hr.
+type HrOption interface {
+	ElementOption
+	isHrOption()
+}
+
+// Hr creates an HTML node for the hr element, thematic break. The element is void, it never has children.
+func Hr(options ...HrOption) *nodes.HTMLNode {
+	return newElement("hr", options)
+}
+
+// HTMLOption is an option accepted by HTML.
+type HTMLOption interface {
+	ElementOption
+	isHTMLOption()
+}
+
+// HTML creates an HTML node for the html element, root element.
+func HTML(options ...HTMLOption) *nodes.HTMLNode {
+	return newElement("html", options)
+}
+
+// IOption is an option accepted by I.
+type IOption interface {
+	ElementOption
+	isIOption()
+}
+
+// I creates an HTML node for the i element, alternate voice.
+func I(options ...IOption) *nodes.HTMLNode {
+	return newElement("i", options)
+}
+
+// IframeOption is an option accepted by Iframe.
+type IframeOption interface {
+	ElementOption
+	isIframeOption()
+}
+
+// Iframe creates an HTML node for the iframe element, child navigable.
+func Iframe(options ...IframeOption) *nodes.HTMLNode {
+	return newElement("iframe", options)
+}
+
+// ImgOption is an option accepted by Img.
+type ImgOption interface {
+	ElementOption
+	isImgOption()
+}
+
+// Img creates an HTML node for the img element, image. The element is void, it never has children.
+func Img(options ...ImgOption) *nodes.HTMLNode {
+	return newElement("img", options)
+}
+
+// InputOption is an option accepted by Input.
+type InputOption interface {
+	ElementOption
+	isInputOption()
+}
+
+// Input creates an HTML node for the input element, form control. The element is void, it never has children.
+func Input(options ...InputOption) *nodes.HTMLNode {
+	return newElement("input", options)
+}
+
+// InsOption is an option accepted by Ins.
+type InsOption interface {
+	ElementOption
+	isInsOption()
+}
+
+// Ins creates an HTML node for the ins element, an addition to the document.
+func Ins(options ...InsOption) *nodes.HTMLNode {
+	return newElement("ins", options)
+}
+
+// KbdOption is an option accepted by Kbd.
+type KbdOption interface {
+	ElementOption
+	isKbdOption()
+}
+
+// Kbd creates an HTML node for the kbd element, user input.
+func Kbd(options ...KbdOption) *nodes.HTMLNode {
+	return newElement("kbd", options)
+}
+
+// LabelOption is an option accepted by Label.
+type LabelOption interface {
+	ElementOption
+	isLabelOption()
+}
+
+// Label creates an HTML node for the label element, caption for a form control.
+func Label(options ...LabelOption) *nodes.HTMLNode {
+	return newElement("label", options)
+}
+
+// LegendOption is an option accepted by Legend.
+type LegendOption interface {
+	ElementOption
+	isLegendOption()
+}
+
+// Legend creates an HTML node for the legend element, caption for fieldset.
+func Legend(options ...LegendOption) *nodes.HTMLNode {
+	return newElement("legend", options)
+}
+
+// LiOption is an option accepted by Li.
+type LiOption interface {
+	ElementOption
+	isLiOption()
+}
+
+// Li creates an HTML node for the li element, list item.
+func Li(options ...LiOption) *nodes.HTMLNode {
+	return newElement("li", options)
+}
+
+// LinkOption is an option accepted by Link.
+type LinkOption interface {
+	ElementOption
+	isLinkOption()
+}
+
+// Link creates an HTML node for the link element, link metadata. The element is void, it never has children.
+func Link(options ...LinkOption) *nodes.HTMLNode {
+	return newElement("link", options)
+}
+
+// MainOption is an option accepted by Main.
+type MainOption interface {
+	ElementOption
+	isMainOption()
+}
+
+// Main creates an HTML node for the main element, container for the dominant contents of the document.
+func Main(options ...MainOption) *nodes.HTMLNode {
+	return newElement("main", options)
+}
+
+// MapOption is an option accepted by Map.
+type MapOption interface {
+	ElementOption
+	isMapOption()
+}
+
+// Map creates an HTML node for the map element, image map.
+func Map(options ...MapOption) *nodes.HTMLNode {
+	return newElement("map", options)
+}
+
+// MarkOption is an option accepted by Mark.
+type MarkOption interface {
+	ElementOption
+	isMarkOption()
+}
+
+// Mark creates an HTML node for the mark element, highlight.
+func Mark(options ...MarkOption) *nodes.HTMLNode {
+	return newElement("mark", options)
+}
+
+// MenuOption is an option accepted by Menu.
+type MenuOption interface {
+	ElementOption
+	isMenuOption()
+}
+
+// Menu creates an HTML node for the menu element, menu of commands.
+func Menu(options ...MenuOption) *nodes.HTMLNode {
+	return newElement("menu", options)
+}
+
+// MetaOption is an option accepted by Meta.
+type MetaOption interface {
+	ElementOption
+	isMetaOption()
+}
+
+// Meta creates an HTML node for the meta element, text metadata. The element is void, it never has children.
+func Meta(options ...MetaOption) *nodes.HTMLNode {
+	return newElement("meta", options)
+}
+
+// MeterOption is an option accepted by Meter.
+type MeterOption interface {
+	ElementOption
+	isMeterOption()
+}
+
+// Meter creates an HTML node for the meter element, gauge.
+func Meter(options ...MeterOption) *nodes.HTMLNode {
+	return newElement("meter", options)
+}
+
+// NavOption is an option accepted by Nav.
+type NavOption interface {
+	ElementOption
+	isNavOption()
+}
+
+// Nav creates an HTML node for the nav element, section with navigational links.
+func Nav(options ...NavOption) *nodes.HTMLNode {
+	return newElement("nav", options)
+}
+
+// NoscriptOption is an option accepted by Noscript.
+type NoscriptOption interface {
+	ElementOption
+	isNoscriptOption()
+}
+
+// Noscript creates an HTML node for the noscript element, fallback content for script.
+func Noscript(options ...NoscriptOption) *nodes.HTMLNode {
+	return newElement("noscript", options)
+}
+
+// ObjectOption is an option accepted by Object.
+type ObjectOption interface {
+	ElementOption
+	isObjectOption()
+}
+
+// Object creates an HTML node for the object element, image, child navigable, or plugin.
+func Object(options ...ObjectOption) *nodes.HTMLNode {
+	return newElement("object", options)
+}
+
+// OlOption is an option accepted by Ol.
+type OlOption interface {
+	ElementOption
+	isOlOption()
+}
+
+// Ol creates an HTML node for the ol element, ordered list.
+func Ol(options ...OlOption) *nodes.HTMLNode {
+	return newElement("ol", options)
+}
+
+// OptgroupOption is an option accepted by Optgroup.
+type OptgroupOption interface {
+	ElementOption
+	isOptgroupOption()
+}
+
+// Optgroup creates an HTML node for the optgroup element, group of options in a list box.
+func Optgroup(options ...OptgroupOption) *nodes.HTMLNode {
+	return newElement("optgroup", options)
+}
+
+// OptionOption is an option accepted by Option.
+type OptionOption interface {
+	ElementOption
+	isOptionOption()
+}
+
+// Option creates an HTML node for the option element, option in a list box or combo box control.
+func Option(options ...OptionOption) *nodes.HTMLNode {
+	return newElement("option", options)
+}
+
+// OutputOption is an option accepted by Output.
+type OutputOption interface {
+	ElementOption
+	isOutputOption()
+}
+
+// Output creates an HTML node for the output element, calculated output value.
+func Output(options ...OutputOption) *nodes.HTMLNode {
+	return newElement("output", options)
+}
+
+// POption is an option accepted by P.
+type POption interface {
+	ElementOption
+	isPOption()
+}
+
+// P creates an HTML node for the p element, paragraph.
+func P(options ...POption) *nodes.HTMLNode {
+	return newElement("p", options)
+}
+
+// PictureOption is an option accepted by Picture.
+type PictureOption interface {
+	ElementOption
+	isPictureOption()
+}
+
+// Picture creates an HTML node for the picture element, image.
+func Picture(options ...PictureOption) *nodes.HTMLNode {
+	return newElement("picture", options)
+}
+
+// PreOption is an option accepted by Pre.
+type PreOption interface {
+	ElementOption
+	isPreOption()
+}
+
+// Pre creates an HTML node for the pre element, block of preformatted text.
+func Pre(options ...PreOption) *nodes.HTMLNode {
+	return newElement("pre", options)
+}
+
+// ProgressOption is an option accepted by Progress.
+type ProgressOption interface {
+	ElementOption
+	isProgressOption()
+}
+
+// Progress creates an HTML node for the progress element, progress bar.
+func Progress(options ...ProgressOption) *nodes.HTMLNode {
+	return newElement("progress", options)
+}
+
+// QOption is an option accepted by Q.
+type QOption interface {
+	ElementOption
+	isQOption()
+}
+
+// Q creates an HTML node for the q element, quotation.
+func Q(options ...QOption) *nodes.HTMLNode {
+	return newElement("q", options)
+}
+
+// RpOption is an option accepted by Rp.
+type RpOption interface {
+	ElementOption
+	isRpOption()
+}
+
+// Rp creates an HTML node for the rp element, parenthesis for ruby annotation text.
+func Rp(options ...RpOption) *nodes.HTMLNode {
+	return newElement("rp", options)
+}
+
+// RtOption is an option accepted by Rt.
+type RtOption interface {
+	ElementOption
+	isRtOption()
+}
+
+// Rt creates an HTML node for the rt element, ruby annotation text.
+func Rt(options ...RtOption) *nodes.HTMLNode {
+	return newElement("rt", options)
+}
+
+// RubyOption is an option accepted by Ruby.
+type RubyOption interface {
+	ElementOption
+	isRubyOption()
+}
+
+// Ruby creates an HTML node for the ruby element, ruby annotation(s).
+func Ruby(options ...RubyOption) *nodes.HTMLNode {
+	return newElement("ruby", options)
+}
+
+// SOption is an option accepted by S.
+type SOption interface {
+	ElementOption
+	isSOption()
+}
+
+// S creates an HTML node for the s element, inaccurate text.
+func S(options ...SOption) *nodes.HTMLNode {
+	return newElement("s", options)
+}
+
+// SampOption is an option accepted by Samp.
+type SampOption interface {
+	ElementOption
+	isSampOption()
+}
+
+// Samp creates an HTML node for the samp element, computer output.
+func Samp(options ...SampOption) *nodes.HTMLNode {
+	return newElement("samp", options)
+}
+
+// ScriptOption is an option accepted by Script.
+type ScriptOption interface {
+	ElementOption
+	isScriptOption()
+}
+
+// Script creates an HTML node for the script element, embedded script.
+func Script(options ...ScriptOption) *nodes.HTMLNode {
+	return newElement("script", options)
+}
+
+// SearchOption is an option accepted by Search.
+type SearchOption interface {
+	ElementOption
+	isSearchOption()
+}
+
+// Search creates an HTML node for the search element, container for search controls.
+func Search(options ...SearchOption) *nodes.HTMLNode {
+	return newElement("search", options)
+}
+
+// SectionOption is an option accepted by Section.
+type SectionOption interface {
+	ElementOption
+	isSectionOption()
+}
+
+// Section creates an HTML node for the section element, generic document or application section.
+func Section(options ...SectionOption) *nodes.HTMLNode {
+	return newElement("section", options)
+}
+
+// SelectOption is an option accepted by Select.
+type SelectOption interface {
+	ElementOption
+	isSelectOption()
+}
+
+// Select creates an HTML node for the select element, list box control.
+func Select(options ...SelectOption) *nodes.HTMLNode {
+	return newElement("select", options)
+}
+
+// SlotOption is an option accepted by Slot.
+type SlotOption interface {
+	ElementOption
+	isSlotOption()
+}
+
+// Slot creates an HTML node for the slot element, shadow tree slot.
+func Slot(options ...SlotOption) *nodes.HTMLNode {
+	return newElement("slot", options)
+}
+
+// SmallOption is an option accepted by Small.
+type SmallOption interface {
+	ElementOption
+	isSmallOption()
+}
+
+// Small creates an HTML node for the small element, side comment.
+func Small(options ...SmallOption) *nodes.HTMLNode {
+	return newElement("small", options)
+}
+
+// SourceOption is an option accepted by Source.
+type SourceOption interface {
+	ElementOption
+	isSourceOption()
+}
+
+// Source creates an HTML node for the source element, image source for img or media source for video or
+// audio. The element is void, it never has children.
+func Source(options ...SourceOption) *nodes.HTMLNode {
+	return newElement("source", options)
+}
+
+// SpanOption is an option accepted by Span.
+type SpanOption interface {
+	ElementOption
+	isSpanOption()
+}
+
+// Span creates an HTML node for the span element, generic phrasing container.
+func Span(options ...SpanOption) *nodes.HTMLNode {
+	return newElement("span", options)
+}
+
+// StrongOption is an option accepted by Strong.
+type StrongOption interface {
+	ElementOption
+	isStrongOption()
+}
+
+// Strong creates an HTML node for the strong element, importance.
+func Strong(options ...StrongOption) *nodes.HTMLNode {
+	return newElement("strong", options)
+}
+
+// StyleOption is an option accepted by Style.
+type StyleOption interface {
+	ElementOption
+	isStyleOption()
+}
+
+// Style creates an HTML node for the style element, embedded styling information.
+func Style(options ...StyleOption) *nodes.HTMLNode {
+	return newElement("style", options)
+}
+
+// SubOption is an option accepted by Sub.
+type SubOption interface {
+	ElementOption
+	isSubOption()
+}
+
+// Sub creates an HTML node for the sub element, subscript.
+func Sub(options ...SubOption) *nodes.HTMLNode {
+	return newElement("sub", options)
+}
+
+// SummaryOption is an option accepted by Summary.
+type SummaryOption interface {
+	ElementOption
+	isSummaryOption()
+}
+
+// Summary creates an HTML node for the summary element, caption for details.
+func Summary(options ...SummaryOption) *nodes.HTMLNode {
+	return newElement("summary", options)
+}
+
+// SupOption is an option accepted by Sup.
+type SupOption interface {
+	ElementOption
+	isSupOption()
+}
+
+// Sup creates an HTML node for the sup element, superscript.
+func Sup(options ...SupOption) *nodes.HTMLNode {
+	return newElement("sup", options)
+}
+
+// TableOption is an option accepted by Table.
+type TableOption interface {
+	ElementOption
+	isTableOption()
+}
+
+// Table creates an HTML node for the table element, table.
+func Table(options ...TableOption) *nodes.HTMLNode {
+	return newElement("table", options)
+}
+
+// TbodyOption is an option accepted by Tbody.
+type TbodyOption interface {
+	ElementOption
+	isTbodyOption()
+}
+
+// Tbody creates an HTML node for the tbody element, group of rows in a table.
+func Tbody(options ...TbodyOption) *nodes.HTMLNode {
+	return newElement("tbody", options)
+}
+
+// TdOption is an option accepted by Td.
+type TdOption interface {
+	ElementOption
+	isTdOption()
+}
+
+// Td creates an HTML node for the td element, table cell.
+func Td(options ...TdOption) *nodes.HTMLNode {
+	return newElement("td", options)
+}
+
+// TemplateOption is an option accepted by Template.
+type TemplateOption interface {
+	ElementOption
+	isTemplateOption()
+}
+
+// Template creates an HTML node for the template element, template.
+func Template(options ...TemplateOption) *nodes.HTMLNode {
+	return newElement("template", options)
+}
+
+// TextareaOption is an option accepted by Textarea.
+type TextareaOption interface {
+	ElementOption
+	isTextareaOption()
+}
+
+// Textarea creates an HTML node for the textarea element, multiline text controls.
+func Textarea(options ...TextareaOption) *nodes.HTMLNode {
+	return newElement("textarea", options)
+}
+
+// TfootOption is an option accepted by Tfoot.
+type TfootOption interface {
+	ElementOption
+	isTfootOption()
+}
+
+// Tfoot creates an HTML node for the tfoot element, group of footer rows in a table.
+func Tfoot(options ...TfootOption) *nodes.HTMLNode {
+	return newElement("tfoot", options)
+}
+
+// ThOption is an option accepted by Th.
+type ThOption interface {
+	ElementOption
+	isThOption()
+}
+
+// Th creates an HTML node for the th element, table header cell.
+func Th(options ...ThOption) *nodes.HTMLNode {
+	return newElement("th", options)
+}
+
+// TheadOption is an option accepted by Thead.
+type TheadOption interface {
+	ElementOption
+	isTheadOption()
+}
+
+// Thead creates an HTML node for the thead element, group of heading rows in a table.
+func Thead(options ...TheadOption) *nodes.HTMLNode {
+	return newElement("thead", options)
+}
+
+// TimeOption is an option accepted by Time.
+type TimeOption interface {
+	ElementOption
+	isTimeOption()
+}
+
+// Time creates an HTML node for the time element, machine-readable equivalent of date- or time-related data.
+func Time(options ...TimeOption) *nodes.HTMLNode {
+	return newElement("time", options)
+}
+
+// TitleOption is an option accepted by Title.
+type TitleOption interface {
+	ElementOption
+	isTitleOption()
+}
+
+// Title creates an HTML node for the title element, document title.
+func Title(options ...TitleOption) *nodes.HTMLNode {
+	return newElement("title", options)
+}
+
+// TrOption is an option accepted by Tr.
+type TrOption interface {
+	ElementOption
+	isTrOption()
+}
+
+// Tr creates an HTML node for the tr element, table row.
+func Tr(options ...TrOption) *nodes.HTMLNode {
+	return newElement("tr", options)
+}
+
+// TrackOption is an option accepted by Track.
+type TrackOption interface {
+	ElementOption
+	isTrackOption()
+}
+
+// Track creates an HTML node for the track element, timed text track. The element is void, it never has
+// children.
+func Track(options ...TrackOption) *nodes.HTMLNode {
+	return newElement("track", options)
+}
+
+// UOption is an option accepted by U.
+type UOption interface {
+	ElementOption
+	isUOption()
+}
+
+// U creates an HTML node for the u element, unarticulated annotation.
+func U(options ...UOption) *nodes.HTMLNode {
+	return newElement("u", options)
+}
+
+// UlOption is an option accepted by Ul.
+type UlOption interface {
+	ElementOption
+	isUlOption()
+}
+
+// Ul creates an HTML node for the ul element, list.
+func Ul(options ...UlOption) *nodes.HTMLNode {
+	return newElement("ul", options)
+}
+
+// VarOption is an option accepted by Var.
+type VarOption interface {
+	ElementOption
+	isVarOption()
+}
+
+// Var creates an HTML node for the var element, variable.
+func Var(options ...VarOption) *nodes.HTMLNode {
+	return newElement("var", options)
+}
+
+// VideoOption is an option accepted by Video.
+type VideoOption interface {
+	ElementOption
+	isVideoOption()
+}
+
+// Video creates an HTML node for the video element, video player.
+func Video(options ...VideoOption) *nodes.HTMLNode {
+	return newElement("video", options)
+}
+
+// WbrOption is an option accepted by Wbr.
+type WbrOption interface {
+	ElementOption
+	isWbrOption()
+}
+
+// Wbr creates an HTML node for the wbr element, line breaking opportunity. The element is void, it never has
+// children.
+func Wbr(options ...WbrOption) *nodes.HTMLNode {
+	return newElement("wbr", options)
+}
+
+func (GlobalAttribute) isAOption()          {}
+func (GlobalAttribute) isAbbrOption()       {}
+func (GlobalAttribute) isAddressOption()    {}
+func (GlobalAttribute) isAreaOption()       {}
+func (GlobalAttribute) isArticleOption()    {}
+func (GlobalAttribute) isAsideOption()      {}
+func (GlobalAttribute) isAudioOption()      {}
+func (GlobalAttribute) isBOption()          {}
+func (GlobalAttribute) isBaseOption()       {}
+func (GlobalAttribute) isBdiOption()        {}
+func (GlobalAttribute) isBdoOption()        {}
+func (GlobalAttribute) isBlockquoteOption() {}
+func (GlobalAttribute) isBodyOption()       {}
+func (GlobalAttribute) isBrOption()         {}
+func (GlobalAttribute) isButtonOption()     {}
+func (GlobalAttribute) isCanvasOption()     {}
+func (GlobalAttribute) isCaptionOption()    {}
+func (GlobalAttribute) isCiteOption()       {}
+func (GlobalAttribute) isCodeOption()       {}
+func (GlobalAttribute) isColOption()        {}
+func (GlobalAttribute) isColgroupOption()   {}
+func (GlobalAttribute) isDataOption()       {}
+func (GlobalAttribute) isDatalistOption()   {}
+func (GlobalAttribute) isDdOption()         {}
+func (GlobalAttribute) isDelOption()        {}
+func (GlobalAttribute) isDetailsOption()    {}
+func (GlobalAttribute) isDfnOption()        {}
+func (GlobalAttribute) isDialogOption()     {}
+func (GlobalAttribute) isDivOption()        {}
+func (GlobalAttribute) isDlOption()         {}
+func (GlobalAttribute) isDtOption()         {}
+func (GlobalAttribute) isEmOption()         {}
+func (GlobalAttribute) isEmbedOption()      {}
+func (GlobalAttribute) isFieldsetOption()   {}
+func (GlobalAttribute) isFigcaptionOption() {}
+func (GlobalAttribute) isFigureOption()     {}
+func (GlobalAttribute) isFooterOption()     {}
+func (GlobalAttribute) isFormOption()       {}
+func (GlobalAttribute) isH1Option()         {}
+func (GlobalAttribute) isH2Option()         {}
+func (GlobalAttribute) isH3Option()         {}
+func (GlobalAttribute) isH4Option()         {}
+func (GlobalAttribute) isH5Option()         {}
+func (GlobalAttribute) isH6Option()         {}
+func (GlobalAttribute) isHeadOption()       {}
+func (GlobalAttribute) isHeaderOption()     {}
+func (GlobalAttribute) isHgroupOption()     {}
+func (GlobalAttribute) isHrOption()         {}
+func (GlobalAttribute) isHTMLOption()       {}
+func (GlobalAttribute) isIOption()          {}
+func (GlobalAttribute) isIframeOption()     {}
+func (GlobalAttribute) isImgOption()        {}
+func (GlobalAttribute) isInputOption()      {}
+func (GlobalAttribute) isInsOption()        {}
+func (GlobalAttribute) isKbdOption()        {}
+func (GlobalAttribute) isLabelOption()      {}
+func (GlobalAttribute) isLegendOption()     {}
+func (GlobalAttribute) isLiOption()         {}
+func (GlobalAttribute) isLinkOption()       {}
+func (GlobalAttribute) isMainOption()       {}
+func (GlobalAttribute) isMapOption()        {}
+func (GlobalAttribute) isMarkOption()       {}
+func (GlobalAttribute) isMenuOption()       {}
+func (GlobalAttribute) isMetaOption()       {}
+func (GlobalAttribute) isMeterOption()      {}
+func (GlobalAttribute) isNavOption()        {}
+func (GlobalAttribute) isNoscriptOption()   {}
+func (GlobalAttribute) isObjectOption()     {}
+func (GlobalAttribute) isOlOption()         {}
+func (GlobalAttribute) isOptgroupOption()   {}
+func (GlobalAttribute) isOptionOption()     {}
+func (GlobalAttribute) isOutputOption()     {}
+func (GlobalAttribute) isPOption()          {}
+func (GlobalAttribute) isPictureOption()    {}
+func (GlobalAttribute) isPreOption()        {}
+func (GlobalAttribute) isProgressOption()   {}
+func (GlobalAttribute) isQOption()          {}
+func (GlobalAttribute) isRpOption()         {}
+func (GlobalAttribute) isRtOption()         {}
+func (GlobalAttribute) isRubyOption()       {}
+func (GlobalAttribute) isSOption()          {}
+func (GlobalAttribute) isSampOption()       {}
+func (GlobalAttribute) isScriptOption()     {}
+func (GlobalAttribute) isSearchOption()     {}
+func (GlobalAttribute) isSectionOption()    {}
+func (GlobalAttribute) isSelectOption()     {}
+func (GlobalAttribute) isSlotOption()       {}
+func (GlobalAttribute) isSmallOption()      {}
+func (GlobalAttribute) isSourceOption()     {}
+func (GlobalAttribute) isSpanOption()       {}
+func (GlobalAttribute) isStrongOption()     {}
+func (GlobalAttribute) isStyleOption()      {}
+func (GlobalAttribute) isSubOption()        {}
+func (GlobalAttribute) isSummaryOption()    {}
+func (GlobalAttribute) isSupOption()        {}
+func (GlobalAttribute) isTableOption()      {}
+func (GlobalAttribute) isTbodyOption()      {}
+func (GlobalAttribute) isTdOption()         {}
+func (GlobalAttribute) isTemplateOption()   {}
+func (GlobalAttribute) isTextareaOption()   {}
+func (GlobalAttribute) isTfootOption()      {}
+func (GlobalAttribute) isThOption()         {}
+func (GlobalAttribute) isTheadOption()      {}
+func (GlobalAttribute) isTimeOption()       {}
+func (GlobalAttribute) isTitleOption()      {}
+func (GlobalAttribute) isTrOption()         {}
+func (GlobalAttribute) isTrackOption()      {}
+func (GlobalAttribute) isUOption()          {}
+func (GlobalAttribute) isUlOption()         {}
+func (GlobalAttribute) isVarOption()        {}
+func (GlobalAttribute) isVideoOption()      {}
+func (GlobalAttribute) isWbrOption()        {}
+
+func (ChildrenOption) isAOption()          {}
+func (ChildrenOption) isAbbrOption()       {}
+func (ChildrenOption) isAddressOption()    {}
+func (ChildrenOption) isArticleOption()    {}
+func (ChildrenOption) isAsideOption()      {}
+func (ChildrenOption) isAudioOption()      {}
+func (ChildrenOption) isBOption()          {}
+func (ChildrenOption) isBdiOption()        {}
+func (ChildrenOption) isBdoOption()        {}
+func (ChildrenOption) isBlockquoteOption() {}
+func (ChildrenOption) isBodyOption()       {}
+func (ChildrenOption) isButtonOption()     {}
+func (ChildrenOption) isCanvasOption()     {}
+func (ChildrenOption) isCaptionOption()    {}
+func (ChildrenOption) isCiteOption()       {}
+func (ChildrenOption) isCodeOption()       {}
+func (ChildrenOption) isColgroupOption()   {}
+func (ChildrenOption) isDataOption()       {}
+func (ChildrenOption) isDatalistOption()   {}
+func (ChildrenOption) isDdOption()         {}
+func (ChildrenOption) isDelOption()        {}
+func (ChildrenOption) isDetailsOption()    {}
+func (ChildrenOption) isDfnOption()        {}
+func (ChildrenOption) isDialogOption()     {}
+func (ChildrenOption) isDivOption()        {}
+func (ChildrenOption) isDlOption()         {}
+func (ChildrenOption) isDtOption()         {}
+func (ChildrenOption) isEmOption()         {}
+func (ChildrenOption) isFieldsetOption()   {}
+func (ChildrenOption) isFigcaptionOption() {}
+func (ChildrenOption) isFigureOption()     {}
+func (ChildrenOption) isFooterOption()     {}
+func (ChildrenOption) isFormOption()       {}
+func (ChildrenOption) isH1Option()         {}
+func (ChildrenOption) isH2Option()         {}
+func (ChildrenOption) isH3Option()         {}
+func (ChildrenOption) isH4Option()         {}
+func (ChildrenOption) isH5Option()         {}
+func (ChildrenOption) isH6Option()         {}
+func (ChildrenOption) isHeadOption()       {}
+func (ChildrenOption) isHeaderOption()     {}
+func (ChildrenOption) isHgroupOption()     {}
+func (ChildrenOption) isHTMLOption()       {}
+func (ChildrenOption) isIOption()          {}
+func (ChildrenOption) isIframeOption()     {}
+func (ChildrenOption) isInsOption()        {}
+func (ChildrenOption) isKbdOption()        {}
+func (ChildrenOption) isLabelOption()      {}
+func (ChildrenOption) isLegendOption()     {}
+func (ChildrenOption) isLiOption()         {}
+func (ChildrenOption) isMainOption()       {}
+func (ChildrenOption) isMapOption()        {}
+func (ChildrenOption) isMarkOption()       {}
+func (ChildrenOption) isMenuOption()       {}
+func (ChildrenOption) isMeterOption()      {}
+func (ChildrenOption) isNavOption()        {}
+func (ChildrenOption) isNoscriptOption()   {}
+func (ChildrenOption) isObjectOption()     {}
+func (ChildrenOption) isOlOption()         {}
+func (ChildrenOption) isOptgroupOption()   {}
+func (ChildrenOption) isOptionOption()     {}
+func (ChildrenOption) isOutputOption()     {}
+func (ChildrenOption) isPOption()          {}
+func (ChildrenOption) isPictureOption()    {}
+func (ChildrenOption) isPreOption()        {}
+func (ChildrenOption) isProgressOption()   {}
+func (ChildrenOption) isQOption()          {}
+func (ChildrenOption) isRpOption()         {}
+func (ChildrenOption) isRtOption()         {}
+func (ChildrenOption) isRubyOption()       {}
+func (ChildrenOption) isSOption()          {}
+func (ChildrenOption) isSampOption()       {}
+func (ChildrenOption) isScriptOption()     {}
+func (ChildrenOption) isSearchOption()     {}
+func (ChildrenOption) isSectionOption()    {}
+func (ChildrenOption) isSelectOption()     {}
+func (ChildrenOption) isSlotOption()       {}
+func (ChildrenOption) isSmallOption()      {}
+func (ChildrenOption) isSpanOption()       {}
+func (ChildrenOption) isStrongOption()     {}
+func (ChildrenOption) isStyleOption()      {}
+func (ChildrenOption) isSubOption()        {}
+func (ChildrenOption) isSummaryOption()    {}
+func (ChildrenOption) isSupOption()        {}
+func (ChildrenOption) isTableOption()      {}
+func (ChildrenOption) isTbodyOption()      {}
+func (ChildrenOption) isTdOption()         {}
+func (ChildrenOption) isTemplateOption()   {}
+func (ChildrenOption) isTextareaOption()   {}
+func (ChildrenOption) isTfootOption()      {}
+func (ChildrenOption) isThOption()         {}
+func (ChildrenOption) isTheadOption()      {}
+func (ChildrenOption) isTimeOption()       {}
+func (ChildrenOption) isTitleOption()      {}
+func (ChildrenOption) isTrOption()         {}
+func (ChildrenOption) isUOption()          {}
+func (ChildrenOption) isUlOption()         {}
+func (ChildrenOption) isVarOption()        {}
+func (ChildrenOption) isVideoOption()      {}
@@ -0,0 +1,348 @@
+// Code generated by go run ./internal/gen; DO NOT EDIT.
+// Source: https://html.spec.whatwg.org/multipage/indices.html
+
+package html
+
+import (
+	"github.com/minivera/go-lander/events"
+)
+
+// OnAbort listens to the abort event, with the given listener options.
+func OnAbort[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("abort", listener, options...)
+}
+
+// OnAuxClick listens to the auxclick event, with the given listener options.
+func OnAuxClick[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("auxclick", listener, options...)
+}
+
+// OnBeforeInput listens to the beforeinput event, with the given listener options.
+func OnBeforeInput[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("beforeinput", listener, options...)
+}
+
+// OnBeforeMatch listens to the beforematch event, with the given listener options.
+func OnBeforeMatch[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("beforematch", listener, options...)
+}
+
+// OnBeforeToggle listens to the beforetoggle event, with the given listener options.
+func OnBeforeToggle[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("beforetoggle", listener, options...)
+}
+
+// OnBlur listens to the blur event, with the given listener options.
+func OnBlur[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("blur", listener, options...)
+}
+
+// OnCancel listens to the cancel event, with the given listener options.
+func OnCancel[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("cancel", listener, options...)
+}
+
+// OnCanPlay listens to the canplay event, with the given listener options.
+func OnCanPlay[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("canplay", listener, options...)
+}
+
+// OnCanPlayThrough listens to the canplaythrough event, with the given listener options.
+func OnCanPlayThrough[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("canplaythrough", listener, options...)
+}
+
+// OnChange listens to the change event, with the given listener options.
+func OnChange[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("change", listener, options...)
+}
+
+// OnClick listens to the click event, with the given listener options.
+func OnClick[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("click", listener, options...)
+}
+
+// OnClose listens to the close event, with the given listener options.
+func OnClose[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("close", listener, options...)
+}
+
+// OnContextMenu listens to the contextmenu event, with the given listener options.
+func OnContextMenu[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("contextmenu", listener, options...)
+}
+
+// OnCopy listens to the copy event, with the given listener options.
+func OnCopy[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("copy", listener, options...)
+}
+
+// OnCut listens to the cut event, with the given listener options.
+func OnCut[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("cut", listener, options...)
+}
+
+// OnDblClick listens to the dblclick event, with the given listener options.
+func OnDblClick[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("dblclick", listener, options...)
+}
+
+// OnDrag listens to the drag event, with the given listener options.
+func OnDrag[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("drag", listener, options...)
+}
+
+// OnDragEnd listens to the dragend event, with the given listener options.
+func OnDragEnd[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("dragend", listener, options...)
+}
+
+// OnDragEnter listens to the dragenter event, with the given listener options.
+func OnDragEnter[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("dragenter", listener, options...)
+}
+
+// OnDragLeave listens to the dragleave event, with the given listener options.
+func OnDragLeave[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("dragleave", listener, options...)
+}
+
+// OnDragOver listens to the dragover event, with the given listener options.
+func OnDragOver[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("dragover", listener, options...)
+}
+
+// OnDragStart listens to the dragstart event, with the given listener options.
+func OnDragStart[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("dragstart", listener, options...)
+}
+
+// OnDrop listens to the drop event, with the given listener options.
+func OnDrop[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("drop", listener, options...)
+}
+
+// OnDurationChange listens to the durationchange event, with the given listener options.
+func OnDurationChange[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("durationchange", listener, options...)
+}
+
+// OnEmptied listens to the emptied event, with the given listener options.
+func OnEmptied[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("emptied", listener, options...)
+}
+
+// OnEnded listens to the ended event, with the given listener options.
+func OnEnded[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("ended", listener, options...)
+}
+
+// OnError listens to the error event, with the given listener options.
+func OnError[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("error", listener, options...)
+}
+
+// OnFocus listens to the focus event, with the given listener options.
+func OnFocus[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("focus", listener, options...)
+}
+
+// OnFormData listens to the formdata event, with the given listener options.
+func OnFormData[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("formdata", listener, options...)
+}
+
+// OnInput listens to the input event, with the given listener options.
+func OnInput[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("input", listener, options...)
+}
+
+// OnInvalid listens to the invalid event, with the given listener options.
+func OnInvalid[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("invalid", listener, options...)
+}
+
+// OnKeyDown listens to the keydown event, with the given listener options.
+func OnKeyDown[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("keydown", listener, options...)
+}
+
+// OnKeyPress listens to the keypress event, with the given listener options.
+func OnKeyPress[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("keypress", listener, options...)
+}
+
+// OnKeyUp listens to the keyup event, with the given listener options.
+func OnKeyUp[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("keyup", listener, options...)
+}
+
+// OnLoad listens to the load event, with the given listener options.
+func OnLoad[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("load", listener, options...)
+}
+
+// OnLoadedData listens to the loadeddata event, with the given listener options.
+func OnLoadedData[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("loadeddata", listener, options...)
+}
+
+// OnLoadedMetadata listens to the loadedmetadata event, with the given listener options.
+func OnLoadedMetadata[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("loadedmetadata", listener, options...)
+}
+
+// OnLoadStart listens to the loadstart event, with the given listener options.
+func OnLoadStart[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("loadstart", listener, options...)
+}
+
+// OnMouseDown listens to the mousedown event, with the given listener options.
+func OnMouseDown[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("mousedown", listener, options...)
+}
+
+// OnMouseEnter listens to the mouseenter event, with the given listener options.
+func OnMouseEnter[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("mouseenter", listener, options...)
+}
+
+// OnMouseLeave listens to the mouseleave event, with the given listener options.
+func OnMouseLeave[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("mouseleave", listener, options...)
+}
+
+// OnMouseMove listens to the mousemove event, with the given listener options.
+func OnMouseMove[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("mousemove", listener, options...)
+}
+
+// OnMouseOut listens to the mouseout event, with the given listener options.
+func OnMouseOut[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("mouseout", listener, options...)
+}
+
+// OnMouseOver listens to the mouseover event, with the given listener options.
+func OnMouseOver[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("mouseover", listener, options...)
+}
+
+// OnMouseUp listens to the mouseup event, with the given listener options.
+func OnMouseUp[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("mouseup", listener, options...)
+}
+
+// OnPaste listens to the paste event, with the given listener options.
+func OnPaste[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("paste", listener, options...)
+}
+
+// OnPause listens to the pause event, with the given listener options.
+func OnPause[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("pause", listener, options...)
+}
+
+// OnPlay listens to the play event, with the given listener options.
+func OnPlay[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("play", listener, options...)
+}
+
+// OnPlaying listens to the playing event, with the given listener options.
+func OnPlaying[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("playing", listener, options...)
+}
+
+// OnProgress listens to the progress event, with the given listener options.
+func OnProgress[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("progress", listener, options...)
+}
+
+// OnRateChange listens to the ratechange event, with the given listener options.
+func OnRateChange[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("ratechange", listener, options...)
+}
+
+// OnReset listens to the reset event, with the given listener options.
+func OnReset[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("reset", listener, options...)
+}
+
+// OnResize listens to the resize event, with the given listener options.
+func OnResize[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("resize", listener, options...)
+}
+
+// OnScroll listens to the scroll event, with the given listener options.
+func OnScroll[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("scroll", listener, options...)
+}
+
+// OnScrollEnd listens to the scrollend event, with the given listener options.
+func OnScrollEnd[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("scrollend", listener, options...)
+}
+
+// OnSecurityPolicyViolation listens to the securitypolicyviolation event, with the given listener options.
+func OnSecurityPolicyViolation[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("securitypolicyviolation", listener, options...)
+}
+
+// OnSeeked listens to the seeked event, with the given listener options.
+func OnSeeked[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("seeked", listener, options...)
+}
+
+// OnSeeking listens to the seeking event, with the given listener options.
+func OnSeeking[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("seeking", listener, options...)
+}
+
+// OnSelect listens to the select event, with the given listener options.
+func OnSelect[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("select", listener, options...)
+}
+
+// OnSlotChange listens to the slotchange event, with the given listener options.
+func OnSlotChange[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("slotchange", listener, options...)
+}
+
+// OnStalled listens to the stalled event, with the given listener options.
+func OnStalled[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("stalled", listener, options...)
+}
+
+// OnSubmit listens to the submit event, with the given listener options.
+func OnSubmit[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("submit", listener, options...)
+}
+
+// OnSuspend listens to the suspend event, with the given listener options.
+func OnSuspend[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("suspend", listener, options...)
+}
+
+// OnTimeUpdate listens to the timeupdate event, with the given listener options.
+func OnTimeUpdate[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("timeupdate", listener, options...)
+}
+
+// OnToggle listens to the toggle event, with the given listener options.
+func OnToggle[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("toggle", listener, options...)
+}
+
+// OnVolumeChange listens to the volumechange event, with the given listener options.
+func OnVolumeChange[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("volumechange", listener, options...)
+}
+
+// OnWaiting listens to the waiting event, with the given listener options.
+func OnWaiting[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("waiting", listener, options...)
+}
+
+// OnWheel listens to the wheel event, with the given listener options.
+func OnWheel[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("wheel", listener, options...)
+}
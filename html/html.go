@@ -0,0 +1,140 @@
+// Package html provides typed constructors for the HTML elements, such as Div, Input or Button, as an
+// alternative to lander.Html. Each constructor only accepts the global attributes, the attributes the
+// specification defines for its element, and children if the element is not void, so a misspelled or
+// misplaced attribute fails to compile rather than silently rendering:
+//
+//	html.Form(
+//		html.OnSubmit(onSubmit),
+//		html.Children(
+//			html.Input(html.Type("email"), html.Name("email"), html.Required(true)),
+//			html.Button(html.Type("submit"), html.Disabled(loading), html.Text("Sign in")),
+//		),
+//	)
+//
+// The constructors return plain *nodes.HTMLNode values, they can be mixed freely with lander.Html and any
+// other node. The elements, attributes and events are generated from the WHATWG HTML specification, see
+// internal/gen/spec.json; Attr, Dataset and Aria cover everything else.
+package html
+
+//go:generate go run ./internal/gen
+
+import (
+	"strings"
+
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/nodes"
+)
+
+// element is the attributes and children of the element being created.
+type element struct {
+	attributes nodes.Attributes
+	children   nodes.Children
+}
+
+// ElementOption is an option given to the constructor of an element. The constructors only accept the
+// options of their own element, see for example DivOption.
+type ElementOption interface {
+	apply(element *element)
+}
+
+// attribute sets an attribute on the element, overriding any previous value except for the class
+// attribute, where the values are merged.
+type attribute struct {
+	name  string
+	value interface{}
+}
+
+func (a attribute) apply(element *element) {
+	if previous, ok := element.attributes[a.name].(string); ok && a.name == "class" {
+		if value, ok := a.value.(string); ok {
+			element.attributes[a.name] = strings.TrimSpace(previous + " " + value)
+			return
+		}
+	}
+
+	element.attributes[a.name] = a.value
+}
+
+// ChildrenOption adds children to an element, only the elements that are not void accept it.
+type ChildrenOption struct {
+	children nodes.Children
+}
+
+func (c ChildrenOption) apply(element *element) {
+	element.children = append(element.children, c.children...)
+}
+
+// newElement creates an HTML node with the given tag from the options, nil options are ignored.
+func newElement[T ElementOption](tag string, options []T) *nodes.HTMLNode {
+	created := &element{
+		attributes: nodes.Attributes{},
+		children:   nodes.Children{},
+	}
+
+	for _, option := range options {
+		if ElementOption(option) == nil {
+			continue
+		}
+
+		option.apply(created)
+	}
+
+	return nodes.NewHTMLNode(tag, created.attributes, created.children)
+}
+
+// Attr sets an attribute that has no typed option, such as the attributes of custom elements. The value
+// follows the conversion rules of nodes.ExtractAttributes.
+func Attr(name string, value interface{}) GlobalAttribute {
+	return GlobalAttribute{attribute{name: name, value: value}}
+}
+
+// Dataset sets the custom data attribute with the given name, for example Dataset("user-id", id) sets the
+// data-user-id attribute.
+func Dataset(name string, value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "data-" + name, value: value}}
+}
+
+// Aria sets the ARIA attribute with the given name, for example Aria("expanded", "true") sets the
+// aria-expanded attribute. The value is always assigned as an attribute, ARIA states are not reflected as
+// properties in every browser.
+func Aria(name string, value string) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "aria-" + name, value: nodes.AsAttribute(value)}}
+}
+
+// On listens to the event with the given name, using the given listener options. Prefer the typed options,
+// such as OnClick, for the events of the specification.
+func On[T events.ListenerFuncs](event string, listener T, options ...events.ListenerOption) GlobalAttribute {
+	return GlobalAttribute{attribute{name: event, value: events.Listener(listener, options...)}}
+}
+
+// Key sets the key of the element, see nodes.HTMLNode.Key.
+func Key(key interface{}) GlobalAttribute {
+	return GlobalAttribute{attribute{name: nodes.KeyAttribute, value: key}}
+}
+
+// Ref sets the ref filled with the DOM element of the node once mounted, see nodes.Ref.
+func Ref(ref *nodes.Ref) GlobalAttribute {
+	return GlobalAttribute{attribute{name: nodes.RefAttribute, value: ref}}
+}
+
+// InlineStyle sets the inline styles of the element from a map of CSS properties, which are diffed
+// property by property. Use StyleAttr to set the style attribute from a string instead.
+func InlineStyle(style nodes.StyleMap) GlobalAttribute {
+	return GlobalAttribute{attribute{name: nodes.StyleAttribute, value: style}}
+}
+
+// Children adds the given children to the element, nil children are kept as empty positions like in any
+// other node.
+func Children(children ...nodes.Child) ChildrenOption {
+	return ChildrenOption{children: children}
+}
+
+// Text adds a text node with the given content to the element.
+func Text(text string) ChildrenOption {
+	return ChildrenOption{children: nodes.Children{nodes.NewTextNode(text)}}
+}
+
+// GlobalAttribute is an attribute accepted by all elements, such as ID, Class, or the event listeners.
+type GlobalAttribute struct {
+	attribute
+}
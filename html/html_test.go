@@ -0,0 +1,118 @@
+package html_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/html"
+	"github.com/minivera/go-lander/nodes"
+)
+
+func TestElements(t *testing.T) {
+	tcs := []struct {
+		scenario string
+		node     *nodes.HTMLNode
+		expected string
+	}{
+		{
+			scenario: "element without options",
+			node:     html.Div(),
+			expected: `<div></div>`,
+		},
+		{
+			scenario: "typed attributes",
+			node: html.Input(
+				html.Type("email"),
+				html.Name("email"),
+				html.MaxLength(120),
+				html.Required(true),
+				html.Disabled(false),
+			),
+			expected: `<input maxlength="120" name="email" required type="email">`,
+		},
+		{
+			scenario: "global attributes and children",
+			node: html.Ul(
+				html.ID("list"),
+				html.Class("items", "large"),
+				html.Children(
+					html.Li(html.Value("1"), html.Text("first")),
+					nil,
+					html.Li(html.Text("second")),
+				),
+			),
+			expected: `<ul class="items large" id="list"><li value="1">first</li><li>second</li></ul>`,
+		},
+		{
+			scenario: "classes are merged",
+			node:     html.Span(html.Class("a"), html.Class(), html.Class("b", "c")),
+			expected: `<span class="a b c"></span>`,
+		},
+		{
+			scenario: "attributes named like elements",
+			node: html.Button(
+				html.FormAttr("login"),
+				html.TitleAttr("Sign in"),
+				html.Text("Sign in"),
+			),
+			expected: `<button form="login" title="Sign in">Sign in</button>`,
+		},
+		{
+			scenario: "custom attributes",
+			node: html.Div(
+				html.Attr("custom", "value"),
+				html.Dataset("user-id", "12"),
+				html.Aria("expanded", "true"),
+				html.InlineStyle(nodes.StyleMap{"color": "red"}),
+			),
+			expected: `<div aria-expanded="true" custom="value" data-user-id="12" style="color: red;"></div>`,
+		},
+		{
+			scenario: "nil options are ignored",
+			node:     html.P(nil, html.Text("text"), nil),
+			expected: `<p>text</p>`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.scenario, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.node.ToString())
+		})
+	}
+}
+
+func TestElements_Key(t *testing.T) {
+	ref := &nodes.Ref{}
+	node := html.Li(html.Key("first"), html.Ref(ref))
+
+	assert.Equal(t, "first", node.Key)
+	assert.Same(t, ref, node.Ref)
+	assert.Equal(t, `<li></li>`, node.ToString())
+}
+
+func TestElements_Events(t *testing.T) {
+	clicked := false
+	node := html.Button(
+		html.OnClick(func(*events.DOMEvent) error {
+			clicked = true
+			return nil
+		}),
+		html.OnFocus(func(*events.FocusEvent) error {
+			return nil
+		}, events.Capture()),
+		html.On("custom", func(*events.MouseEvent) error {
+			return nil
+		}),
+	)
+
+	require.Len(t, node.EventListeners, 3)
+	assert.True(t, node.EventListeners["focus"].Capture)
+	assert.Contains(t, node.EventListeners, "custom")
+
+	require.NoError(t, node.EventListeners["click"].Func(&events.DOMEvent{}))
+	assert.True(t, clicked)
+	assert.Equal(t, `<button></button>`, node.ToString())
+}
@@ -0,0 +1,365 @@
+// Command gen generates the element constructors and attribute options of the html package from spec.json,
+// a transcription of the index of elements, the index of attributes, and the event handler content attributes
+// of the WHATWG HTML specification. Run it with `go generate ./html` from the root of the repository.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// spec is the content of spec.json.
+type spec struct {
+	// Source is the URL of the specification the data was transcribed from.
+	Source     string          `json:"source"`
+	Elements   []elementSpec   `json:"elements"`
+	Attributes []attributeSpec `json:"attributes"`
+	Events     []eventSpec     `json:"events"`
+}
+
+type elementSpec struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Void elements never have children.
+	Void bool `json:"void"`
+}
+
+type attributeSpec struct {
+	Name string `json:"name"`
+	// GoName is the name of the option when the attribute name is made of several words, such as "tabindex".
+	GoName      string `json:"go"`
+	Description string `json:"description"`
+	// Kind is the Go type of the attribute value, either string, bool, int, float, or list for a set of
+	// space-separated tokens.
+	Kind string `json:"kind"`
+	// Elements are the elements the attribute applies to, global attributes apply to all elements.
+	Elements []string `json:"elements"`
+	Global   bool     `json:"global"`
+}
+
+type eventSpec struct {
+	Name string `json:"name"`
+	// GoName is the name of the option, since event names are not split into words.
+	GoName string `json:"go"`
+}
+
+// acronyms are the names written in capitals in Go identifiers.
+var acronyms = map[string]string{
+	"html": "HTML",
+	"id":   "ID",
+	"http": "HTTP",
+}
+
+// kinds maps the kinds of attribute values to the parameters and value of the generated options.
+var kinds = map[string]struct{ Param, Value string }{
+	"string": {"value string", "value"},
+	"bool":   {"value bool", "value"},
+	"int":    {"value int", "value"},
+	"float":  {"value float64", "value"},
+	"list":   {"values ...string", `strings.Join(values, " ")`},
+}
+
+type element struct {
+	elementSpec
+	GoName string
+}
+
+type attribute struct {
+	attributeSpec
+	GoName   string
+	TypeName string
+	Param    string
+	Value    string
+	Targets  []element
+}
+
+type data struct {
+	Source     string
+	Elements   []element
+	Attributes []attribute
+	Globals    []attribute
+	Events     []eventSpec
+	Containers []element
+}
+
+// goName converts an HTML name, such as "accept-charset", to an exported Go identifier.
+func goName(name string) string {
+	parts := strings.Split(name, "-")
+	for i, part := range parts {
+		if acronym, ok := acronyms[part]; ok {
+			parts[i] = acronym
+			continue
+		}
+
+		parts[i] = strings.ToUpper(part[:1]) + part[1:]
+	}
+
+	return strings.Join(parts, "")
+}
+
+// lowerFirst lowers the first letter of a description so it can follow the start of a sentence.
+func lowerFirst(text string) string {
+	return strings.ToLower(text[:1]) + text[1:]
+}
+
+// comment formats the text as a doc comment, wrapped at the width of the comments of the repository.
+func comment(text string) string {
+	var lines []string
+	line := "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > 110 && line != "//" {
+			lines = append(lines, line)
+			line = "//"
+		}
+		line += " " + word
+	}
+
+	return strings.Join(append(lines, line), "\n")
+}
+
+// prepare resolves the names and targets of the spec's elements and attributes. Attributes named like an
+// element, such as "form", are suffixed with Attr. Returns an error if two identifiers collide.
+func prepare(s spec) (data, error) {
+	d := data{Source: s.Source, Events: s.Events}
+	identifiers := map[string]string{}
+	declare := func(identifier, owner string) error {
+		if other, ok := identifiers[identifier]; ok {
+			return fmt.Errorf("identifier %s of %s collides with %s", identifier, owner, other)
+		}
+		identifiers[identifier] = owner
+		return nil
+	}
+
+	// Identifiers of the hand-written part of the package
+	for _, identifier := range []string{"ElementOption", "GlobalAttribute", "Attr", "Dataset", "Aria", "On", "Key",
+		"Ref", "InlineStyle", "Children", "Text", "ChildrenOption"} {
+		if err := declare(identifier, "html.go"); err != nil {
+			return data{}, err
+		}
+	}
+
+	byName := map[string]element{}
+	for _, spec := range s.Elements {
+		created := element{elementSpec: spec, GoName: goName(spec.Name)}
+		if err := declare(created.GoName, "element "+spec.Name); err != nil {
+			return data{}, err
+		}
+		if err := declare(created.GoName+"Option", "element "+spec.Name); err != nil {
+			return data{}, err
+		}
+
+		byName[spec.Name] = created
+		d.Elements = append(d.Elements, created)
+		if !spec.Void {
+			d.Containers = append(d.Containers, created)
+		}
+	}
+
+	for _, spec := range s.Attributes {
+		kind, ok := kinds[spec.Kind]
+		if !ok {
+			return data{}, fmt.Errorf("attribute %s has unknown kind %q", spec.Name, spec.Kind)
+		}
+
+		created := attribute{attributeSpec: spec, GoName: spec.GoName, Param: kind.Param, Value: kind.Value}
+		if created.GoName == "" {
+			created.GoName = goName(spec.Name)
+		}
+		created.TypeName = created.GoName + "Attribute"
+		if _, ok := byName[spec.Name]; ok {
+			created.GoName += "Attr"
+		}
+		if err := declare(created.GoName, "attribute "+spec.Name); err != nil {
+			return data{}, err
+		}
+
+		if spec.Global {
+			d.Globals = append(d.Globals, created)
+			continue
+		}
+
+		if err := declare(created.TypeName, "attribute "+spec.Name); err != nil {
+			return data{}, err
+		}
+		for _, name := range spec.Elements {
+			target, ok := byName[name]
+			if !ok {
+				return data{}, fmt.Errorf("attribute %s applies to unknown element %s", spec.Name, name)
+			}
+			created.Targets = append(created.Targets, target)
+		}
+		sort.Slice(created.Targets, func(i, j int) bool {
+			return created.Targets[i].Name < created.Targets[j].Name
+		})
+
+		d.Attributes = append(d.Attributes, created)
+	}
+
+	for _, event := range s.Events {
+		if err := declare(event.GoName, "event "+event.Name); err != nil {
+			return data{}, err
+		}
+	}
+
+	return d, nil
+}
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"lowerFirst": lowerFirst,
+	"comment":    comment,
+	"join": func(targets []element) string {
+		names := make([]string, len(targets))
+		for i, target := range targets {
+			names[i] = target.Name
+		}
+
+		if len(names) == 1 {
+			return names[0]
+		}
+		return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	},
+}).Parse(`
+{{define "header"}}// Code generated by go run ./internal/gen; DO NOT EDIT.
+// Source: {{.Source}}
+
+package html
+{{end}}
+
+{{define "elements.go"}}{{template "header" .}}
+import (
+	"github.com/minivera/go-lander/nodes"
+)
+{{range .Elements}}
+// {{.GoName}}Option is an option accepted by {{.GoName}}.
+type {{.GoName}}Option interface {
+	ElementOption
+	is{{.GoName}}Option()
+}
+
+{{comment (printf "%s creates an HTML node for the %s element, %s.%s" .GoName .Name (lowerFirst .Description) (or (and .Void " The element is void, it never has children.") ""))}}
+func {{.GoName}}(options ...{{.GoName}}Option) *nodes.HTMLNode {
+	return newElement("{{.Name}}", options)
+}
+{{end}}
+{{range .Elements}}
+func (GlobalAttribute) is{{.GoName}}Option() {}
+{{- end}}
+{{range .Containers}}
+func (ChildrenOption) is{{.GoName}}Option() {}
+{{- end}}
+{{end}}
+
+{{define "attributes.go"}}{{template "header" .}}
+{{$imports := false}}{{range .Attributes}}{{if eq .Kind "list"}}{{$imports = true}}{{end}}{{end}}
+{{- range .Globals}}{{if eq .Kind "list"}}{{$imports = true}}{{end}}{{end}}
+{{- if $imports}}
+import (
+	"strings"
+)
+{{end}}
+{{- range .Globals}}
+{{comment (printf "%s sets the global %s attribute, %s." .GoName .Name (lowerFirst .Description))}}
+func {{.GoName}}({{.Param}}) GlobalAttribute {
+	return GlobalAttribute{attribute{name: "{{.Name}}", value: {{.Value}}}}
+}
+{{end}}
+{{- range .Attributes}}
+{{comment (printf "%s is the %s attribute, accepted by the %s elements." .TypeName .Name (join .Targets))}}
+type {{.TypeName}} struct {
+	attribute
+}
+
+{{comment (printf "%s sets the %s attribute, %s." .GoName .Name (lowerFirst .Description))}}
+func {{.GoName}}({{.Param}}) {{.TypeName}} {
+	return {{.TypeName}}{attribute{name: "{{.Name}}", value: {{.Value}}}}
+}
+{{$type := .TypeName}}{{range .Targets}}
+func ({{$type}}) is{{.GoName}}Option() {}
+{{- end}}
+{{end}}
+{{end}}
+
+{{define "events.go"}}{{template "header" .}}
+import (
+	"github.com/minivera/go-lander/events"
+)
+{{range .Events}}
+// {{.GoName}} listens to the {{.Name}} event, with the given listener options.
+func {{.GoName}}[T events.ListenerFuncs](listener T, options ...events.ListenerOption) GlobalAttribute {
+	return On("{{.Name}}", listener, options...)
+}
+{{end}}
+{{end}}
+`))
+
+// generate returns the content of the generated files, by file name.
+func generate(s spec) (map[string][]byte, error) {
+	d, err := prepare(s)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	for _, name := range []string{"elements.go", "attributes.go", "events.go"} {
+		var buffer bytes.Buffer
+		if err := templates.ExecuteTemplate(&buffer, name, d); err != nil {
+			return nil, fmt.Errorf("could not generate %s. %w", name, err)
+		}
+
+		formatted, err := format.Source(buffer.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("could not format %s. %w", name, err)
+		}
+		files[name] = formatted
+	}
+
+	return files, nil
+}
+
+// readSpec reads and decodes the spec file at the given path.
+func readSpec(path string) (spec, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return spec{}, err
+	}
+
+	var s spec
+	if err := json.Unmarshal(content, &s); err != nil {
+		return spec{}, fmt.Errorf("could not decode %s. %w", path, err)
+	}
+
+	return s, nil
+}
+
+func main() {
+	specPath := flag.String("spec", "internal/gen/spec.json", "path of the spec file")
+	out := flag.String("out", ".", "directory of the html package")
+	flag.Parse()
+
+	s, err := readSpec(*specPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	files, err := generate(s)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(*out, name), content, 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
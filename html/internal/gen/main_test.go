@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	s, err := readSpec("spec.json")
+	require.NoError(t, err)
+
+	files, err := generate(s)
+	require.NoError(t, err)
+
+	for name, content := range files {
+		committed, err := os.ReadFile(filepath.Join("..", "..", name))
+		require.NoError(t, err)
+		assert.Equal(t, string(content), string(committed), "%s is out of date, run go generate ./html", name)
+	}
+}
+
+func TestGenerate_Collisions(t *testing.T) {
+	tcs := []struct {
+		scenario string
+		spec     spec
+	}{
+		{
+			scenario: "attribute colliding with the hand-written options",
+			spec: spec{
+				Attributes: []attributeSpec{{Name: "text", Kind: "string", Global: true}},
+			},
+		},
+		{
+			scenario: "attributes with the same name",
+			spec: spec{
+				Elements: []elementSpec{{Name: "input"}},
+				Attributes: []attributeSpec{
+					{Name: "name", Kind: "string", Elements: []string{"input"}},
+					{Name: "name", Kind: "string", Global: true},
+				},
+			},
+		},
+		{
+			scenario: "event colliding with an attribute",
+			spec: spec{
+				Attributes: []attributeSpec{{Name: "onclick", GoName: "OnClick", Kind: "string", Global: true}},
+				Events:     []eventSpec{{Name: "click", GoName: "OnClick"}},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.scenario, func(t *testing.T) {
+			_, err := generate(tc.spec)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package lander_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/diffing"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/nodes"
+)
+
+// element creates an in-memory element with the given attributes and children, to simulate the markup
+// parsed by the browser from a server render.
+func element(document dom.Document, tag string, attributes map[string]string, children ...dom.Node) dom.Element {
+	created := document.CreateElement(tag)
+	for name, value := range attributes {
+		created.SetAttribute(name, value)
+	}
+	for _, child := range children {
+		created.AppendChild(child)
+	}
+
+	return created
+}
+
+func TestHydrateIntoDocument(t *testing.T) {
+	document, app := setupDocument(t)
+
+	style := element(document, "style", map[string]string{"id": "lander-style-tag"})
+	button := element(document, "button", map[string]string{"type": "button"}, document.CreateTextNode("+"))
+	text := document.CreateTextNode("Count: 0")
+	content := element(document, "div", map[string]string{"class": "counter"}, button, text)
+	app.AppendChild(style)
+	app.AppendChild(content)
+
+	mounted := make(chan bool, 1)
+	count := 0
+	var env *lander.DomEnvironment
+	render := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		ctx.OnMount(func() error {
+			mounted <- true
+			return nil
+		})
+
+		return lander.Html("div", nodes.Attributes{"class": "counter"}, nodes.Children{
+			lander.Html("button", nodes.Attributes{
+				"type": "button",
+				"click": func(*events.DOMEvent) error {
+					count++
+					return env.Update()
+				},
+			}, nodes.Children{lander.Text("+")}),
+			lander.Text("Count: "),
+			lander.Text(fmt.Sprintf("%d", count)),
+		})
+	}
+
+	env, err := lander.HydrateIntoDocument(document, lander.Component(render, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	select {
+	case <-mounted:
+	case <-time.After(time.Second):
+		t.Fatal("the mount listener was never triggered")
+	}
+
+	// The style tag is moved to the head and the existing nodes are reused
+	assert.True(t, document.Head().QuerySelector("#lander-style-tag").IsSameNode(style))
+	require.Len(t, app.ChildNodes(), 1)
+	assert.True(t, app.ChildNodes()[0].IsSameNode(content))
+	assert.True(t, content.ChildNodes()[0].IsSameNode(button))
+	// The merged text was split in two to match the virtual text nodes
+	assert.True(t, content.ChildNodes()[1].IsSameNode(text))
+	assert.Len(t, content.ChildNodes(), 3)
+
+	button.(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, `<div class="counter"><button type="button">+</button>Count: 1</div>`, app.InnerHTML())
+	assert.True(t, app.ChildNodes()[0].IsSameNode(content))
+}
+
+func TestHydrateIntoDocument_Mismatches(t *testing.T) {
+	document, app := setupDocument(t)
+
+	app.AppendChild(element(
+		document, "div", map[string]string{"title": "server"},
+		element(document, "span", nil, document.CreateTextNode("server text")),
+		element(document, "p", nil),
+		element(document, "em", map[string]string{"hidden": ""}),
+		element(document, "i", nil),
+	))
+
+	render := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Html("div", nodes.Attributes{"title": "client"}, nodes.Children{
+			lander.Html("span", nodes.Attributes{}, nodes.Children{lander.Text("client text")}),
+			lander.Html("section", nodes.Attributes{}, nodes.Children{}),
+			lander.Html("em", nodes.Attributes{}, nodes.Children{}),
+		})
+	}
+
+	env, err := lander.HydrateIntoDocument(document, lander.Component(render, nodes.Props{}, nodes.Children{}), "#app")
+	require.Error(t, err)
+	assert.NotNil(t, env)
+
+	var hydrationErr *diffing.HydrationError
+	require.True(t, errors.As(err, &hydrationErr))
+
+	var types []diffing.HydrationMismatchType
+	for _, mismatch := range hydrationErr.Mismatches {
+		types = append(types, mismatch.Type)
+	}
+	assert.Equal(t, []diffing.HydrationMismatchType{
+		diffing.AttributeMismatch,
+		diffing.TextMismatch,
+		diffing.TagMismatch,
+		diffing.AttributeMismatch,
+		diffing.ExtraNodeMismatch,
+	}, types)
+	assert.Equal(t, "client text", hydrationErr.Mismatches[1].Expected)
+	assert.Equal(t, "server text", hydrationErr.Mismatches[1].Actual)
+	assert.Equal(t, "hidden", hydrationErr.Mismatches[3].Name)
+
+	// The DOM is fixed to match the virtual tree
+	assert.Equal(t, `<div title="client"><span>client text</span><section></section><em></em></div>`, app.InnerHTML())
+}
+
+func TestHydrateIntoDocument_EmptyText(t *testing.T) {
+	document, app := setupDocument(t)
+
+	// The server never renders empty text nodes
+	text := document.CreateTextNode("label")
+	content := element(document, "p", nil, text)
+	app.AppendChild(content)
+
+	render := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Html("p", nodes.Attributes{}, nodes.Children{
+			lander.Text(""),
+			lander.Text("label"),
+			lander.Text(""),
+		})
+	}
+
+	_, err := lander.HydrateIntoDocument(document, lander.Component(render, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	// The empty text nodes are created around the text rendered by the server, which is kept
+	require.Len(t, content.ChildNodes(), 3)
+	assert.True(t, content.ChildNodes()[1].IsSameNode(text))
+	assert.Equal(t, `<p>label</p>`, app.InnerHTML())
+}
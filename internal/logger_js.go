@@ -0,0 +1,15 @@
+//go:build js && wasm
+
+package internal
+
+import (
+	"syscall/js"
+)
+
+func init() {
+	if !js.Global().Truthy() || !js.Global().Get("DEBUG").Truthy() {
+		return
+	}
+
+	IsDebug = js.Global().Get("DEBUG").Bool()
+}
@@ -0,0 +1,167 @@
+package lander_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/nodes"
+)
+
+type keyedListApp struct {
+	items      []string
+	components bool
+}
+
+type keyedItemProps struct {
+	name string
+}
+
+func keyedItem(_ context.Context, props keyedItemProps, _ nodes.Children) nodes.Child {
+	return lander.Html("li", nodes.Attributes{}, nodes.Children{lander.Text(props.name)})
+}
+
+func (a *keyedListApp) render(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+	children := nodes.Children{}
+	for _, item := range a.items {
+		if a.components {
+			children = append(children, lander.Component(keyedItem, keyedItemProps{name: item}, nodes.Children{}, lander.WithKey(item)))
+		} else {
+			children = append(children, lander.Html("li", nodes.Attributes{"key": item}, nodes.Children{lander.Text(item)}))
+		}
+	}
+
+	return lander.Html("ul", nodes.Attributes{}, children)
+}
+
+func domNodesByText(t *testing.T, list dom.Element) map[string]dom.Node {
+	t.Helper()
+
+	result := map[string]dom.Node{}
+	for _, child := range list.Children() {
+		result[child.ChildNodes()[0].(dom.Text).NodeValue()] = child
+	}
+
+	return result
+}
+
+func TestKeyedChildren(t *testing.T) {
+	for _, components := range []bool{false, true} {
+		document, app := setupDocument(t)
+
+		list := &keyedListApp{items: []string{"a", "b", "c", "d"}, components: components}
+		env, err := lander.RenderIntoDocument(document, lander.Component(list.render, nodes.Props{}, nodes.Children{}), "#app")
+		require.NoError(t, err)
+
+		ul := app.Children()[0]
+		before := domNodesByText(t, ul)
+
+		steps := []struct {
+			items    []string
+			expected string
+		}{
+			{[]string{"b", "c", "d"}, "<ul><li>b</li><li>c</li><li>d</li></ul>"},
+			{[]string{"d", "b", "c"}, "<ul><li>d</li><li>b</li><li>c</li></ul>"},
+			{[]string{"d", "e", "c", "b"}, "<ul><li>d</li><li>e</li><li>c</li><li>b</li></ul>"},
+			{[]string{"c", "a", "d", "b", "e"}, "<ul><li>c</li><li>a</li><li>d</li><li>b</li><li>e</li></ul>"},
+		}
+
+		for _, step := range steps {
+			list.items = step.items
+			require.NoError(t, env.Update())
+			assert.Equal(t, step.expected, app.InnerHTML())
+
+			// Nodes matched by key must keep their DOM node
+			after := domNodesByText(t, ul)
+			for key, node := range after {
+				if previous, ok := before[key]; ok {
+					assert.True(t, previous.IsSameNode(node), "node %s was recreated", key)
+				}
+			}
+			before = after
+		}
+	}
+}
+
+func TestKeyedChildren_LargeList(t *testing.T) {
+	document, app := setupDocument(t)
+
+	list := &keyedListApp{}
+	for i := 0; i < 1000; i++ {
+		list.items = append(list.items, string(rune('a'+i%26))+string(rune('a'+i/26)))
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(list.render, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	ul := app.Children()[0]
+	last := ul.Children()[999]
+
+	// Move the last row first, all other rows should stay in place
+	list.items = append([]string{list.items[999]}, list.items[:999]...)
+	require.NoError(t, env.Update())
+
+	assert.True(t, ul.Children()[0].IsSameNode(last))
+	assert.Len(t, ul.Children(), 1000)
+}
+
+type keyedOptionalItemProps struct {
+	name  string
+	shown bool
+}
+
+func keyedOptionalItem(_ context.Context, props keyedOptionalItemProps, _ nodes.Children) nodes.Child {
+	if !props.shown {
+		return nil
+	}
+
+	return lander.Html("li", nodes.Attributes{}, nodes.Children{lander.Text(props.name)})
+}
+
+func TestKeyedChildren_GrowingChildren(t *testing.T) {
+	document, app := setupDocument(t)
+
+	items := []string{"a", "b", "c"}
+	shown := map[string]bool{"a": true, "c": true}
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		children := nodes.Children{}
+		for _, item := range items {
+			children = append(children, lander.Component(
+				keyedOptionalItem,
+				keyedOptionalItemProps{name: item, shown: shown[item]},
+				nodes.Children{},
+				lander.WithKey(item),
+			))
+		}
+
+		return lander.Html("ul", nodes.Attributes{}, children)
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, `<ul><li>a</li><li>c</li></ul>`, app.InnerHTML())
+
+	// A child kept in place that renders new DOM nodes inserts them before its following siblings
+	shown["b"] = true
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<ul><li>a</li><li>b</li><li>c</li></ul>`, app.InnerHTML())
+
+	// Growing while the other children move
+	shown["b"] = false
+	require.NoError(t, env.Update())
+	items = []string{"c", "a", "b"}
+	shown["b"] = true
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<ul><li>c</li><li>a</li><li>b</li></ul>`, app.InnerHTML())
+
+	items = []string{"b", "c", "a"}
+	shown["b"], shown["c"] = false, false
+	require.NoError(t, env.Update())
+	shown["c"] = true
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<ul><li>c</li><li>a</li></ul>`, app.InnerHTML())
+}
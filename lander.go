@@ -1,5 +1,3 @@
-//go:build js && wasm
-
 package lander
 
 import (
@@ -7,7 +5,8 @@ import (
 	"github.com/minivera/go-lander/nodes"
 )
 
-// Html creates an HTML node in the virtual tree using the provided tag, attributes, and children.
+// Html creates an HTML node in the virtual tree using the provided tag, attributes, and children. The
+// special "key" attribute sets the key of the node, see HTMLNode.Key.
 func Html(tag string, attributes nodes.Attributes, children nodes.Children) *nodes.HTMLNode {
 	return nodes.NewHTMLNode(tag, attributes, children)
 }
@@ -26,6 +25,18 @@ func Text(text string) *nodes.TextNode {
 	return nodes.NewTextNode(text)
 }
 
+// ComponentOption is an option that can be given to Component to configure the created component node.
+type ComponentOption func(node *nodes.FuncNode)
+
+// WithKey sets the key of the created component node. When the children of a node are keyed, they are
+// matched by key rather than by position during diffing, which keeps the component and its DOM nodes
+// attached to the right item when a list is reordered. Keys must be comparable and unique among siblings.
+func WithKey(key interface{}) ComponentOption {
+	return func(node *nodes.FuncNode) {
+		node.Key = key
+	}
+}
+
 // Component creates a function component node using the provided factory, props, and children. The factory
 // will be executed on every render cycle with the most up-to-date props and children, and is expected to
 // return a single child. Components also take a context, which provides hooks to lister to mount, render
@@ -33,12 +44,15 @@ func Text(text string) *nodes.TextNode {
 //
 // Lander expects a component as its first node, this component could then render an HTML element or text
 // node, but only a component can be given to RenderInto.
-func Component[T any](factory nodes.FunctionComponent[T], props T, children nodes.Children) *nodes.FuncNode {
-	// Create an intermediary function so we hide the generic away. The generic is here only for
-	// developer convenience.
-	return nodes.NewFuncNode(func(ctx context.Context, props interface{}, children nodes.Children) nodes.Child {
-		return factory(ctx, props.(T), children)
-	}, props, children)
+func Component[T any](factory nodes.FunctionComponent[T], props T, children nodes.Children,
+	options ...ComponentOption) *nodes.FuncNode {
+	node := nodes.NewComponentNode(factory, props, children)
+
+	for _, option := range options {
+		option(node)
+	}
+
+	return node
 }
 
 // Fragment creates a fragment node, which is a utility node that allows returning multiple children from
@@ -47,3 +61,76 @@ func Component[T any](factory nodes.FunctionComponent[T], props T, children node
 func Fragment(children nodes.Children) *nodes.FragmentNode {
 	return nodes.NewFragmentNode(children)
 }
+
+// Portal creates a portal node, which renders its children into another DOM container than their closest
+// DOM parent, such as the body for modals or tooltips. The target is either a CSS selector resolved in the
+// document when the portal is mounted, a dom.Element, or, in the browser, the js.Value of an element.
+//
+// The children of a portal are diffed, rendered and given scoped values like any other child, and they are
+// removed from the container when the portal is unmounted. Their events bubble to the ancestors of the
+// portal in the tree, not to the ancestors of the container. Portals are not rendered by RenderToString,
+// their children are mounted when the app is hydrated.
+func Portal(target interface{}, children nodes.Children) *nodes.PortalNode {
+	return nodes.NewPortalNode(target, children)
+}
+
+// RawHTMLOption is an option that can be given to RawHTML to configure the created raw HTML node.
+type RawHTMLOption func(options *rawHTMLOptions)
+
+type rawHTMLOptions struct {
+	tag       string
+	sanitizer nodes.Sanitizer
+}
+
+// WithContainerTag sets the tag of the element the raw HTML is rendered into, nodes.DefaultRawHTMLTag
+// otherwise.
+func WithContainerTag(tag string) RawHTMLOption {
+	return func(options *rawHTMLOptions) {
+		options.tag = tag
+	}
+}
+
+// WithSanitizer sets the function the HTML string is passed through before being rendered, to clean up
+// markup that cannot be fully trusted.
+func WithSanitizer(sanitizer nodes.Sanitizer) RawHTMLOption {
+	return func(options *rawHTMLOptions) {
+		options.sanitizer = sanitizer
+	}
+}
+
+// RawHTML creates a raw HTML node, which renders the HTML string as is inside a container element, such as
+// CMS content or the output of a markdown renderer. The node is diffed by its content, the whole content of
+// the container is replaced when the string changes. RenderToString writes the string verbatim.
+//
+// The HTML string is never escaped. Only give trusted markup to RawHTML, or clean it with WithSanitizer,
+// otherwise the app is open to cross-site scripting.
+func RawHTML(html string, options ...RawHTMLOption) *nodes.RawHTMLNode {
+	config := rawHTMLOptions{}
+	for _, option := range options {
+		option(&config)
+	}
+
+	return nodes.NewRawHTMLNode(config.tag, html, config.sanitizer)
+}
+
+// ProviderProps are the properties of the provider component created by Provider.
+type ProviderProps[T any] struct {
+	// Context is the scoped context the value is provided for.
+	Context *context.ScopedContext[T]
+	// Value is the value provided to the descendants of the provider.
+	Value T
+}
+
+func provider[T any](ctx context.Context, props ProviderProps[T], children nodes.Children) nodes.Child {
+	props.Context.Provide(ctx, props.Value)
+
+	return nodes.NewFragmentNode(children)
+}
+
+// Provider creates a component node that provides the value of the given scoped context to all its children
+// and their descendants. Descendants get the value with the Use method of the scoped context, the nearest
+// provider wins. See context.CreateContext.
+func Provider[T any](scoped *context.ScopedContext[T], value T, children nodes.Children,
+	options ...ComponentOption) *nodes.FuncNode {
+	return Component(provider[T], ProviderProps[T]{Context: scoped, Value: value}, children, options...)
+}
@@ -0,0 +1,133 @@
+package lander_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/nodes"
+)
+
+type lifecycleProps struct {
+	name   string
+	events *[]string
+}
+
+func lifecycleComponent(ctx context.Context, props lifecycleProps, children nodes.Children) nodes.Child {
+	record := func(event string) func() error {
+		return func() error {
+			*props.events = append(*props.events, event+" "+props.name)
+			return nil
+		}
+	}
+
+	ctx.OnMount(record("mount"))
+	ctx.OnRender(record("render"))
+	ctx.OnUnmount(record("unmount"))
+
+	return lander.Html("div", nodes.Attributes{}, children)
+}
+
+func TestLifecycle_Order(t *testing.T) {
+	document, _ := setupDocument(t)
+
+	var events []string
+	visible := true
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		if !visible {
+			return lander.Html("div", nodes.Attributes{}, nodes.Children{})
+		}
+
+		return lander.Html("div", nodes.Attributes{}, nodes.Children{
+			lander.Component(lifecycleComponent, lifecycleProps{name: "parent", events: &events}, nodes.Children{
+				lander.Component(lifecycleComponent, lifecycleProps{name: "child", events: &events}, nodes.Children{}),
+			}),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	// Children are mounted before their parents
+	assert.Equal(t, []string{"mount child", "render child", "mount parent", "render parent"}, events)
+
+	// Listeners run synchronously, before Update returns
+	events = nil
+	require.NoError(t, env.Update())
+	assert.Equal(t, []string{"render child", "render parent"}, events)
+
+	// Parents are unmounted before their children
+	events = nil
+	visible = false
+	require.NoError(t, env.Update())
+	assert.Equal(t, []string{"unmount parent", "unmount child"}, events)
+}
+
+func TestLifecycle_MultipleListeners(t *testing.T) {
+	document, _ := setupDocument(t)
+
+	var events []string
+	component := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		for _, name := range []string{"first", "second", "third"} {
+			name := name
+			ctx.OnMount(func() error {
+				events = append(events, name)
+				return nil
+			})
+		}
+
+		return lander.Text("listening")
+	}
+
+	_, err := lander.RenderIntoDocument(document, lander.Component(component, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "third"}, events)
+}
+
+func TestLifecycle_AggregatedErrors(t *testing.T) {
+	document, app := setupDocument(t)
+
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+	failing := false
+	called := 0
+	component := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		ctx.OnRender(func() error {
+			called++
+			if failing {
+				return errFirst
+			}
+			return nil
+		})
+		ctx.OnRender(func() error {
+			called++
+			if failing {
+				return errSecond
+			}
+			return nil
+		})
+
+		return lander.Text("rendered")
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(component, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	// Every listener runs, the render completes and all errors are returned to the caller
+	called = 0
+	failing = true
+	err = env.Update()
+	require.Error(t, err)
+	assert.Equal(t, 2, called)
+	assert.Equal(t, "rendered", app.InnerHTML())
+
+	var listenerErr *context.ListenerError
+	require.ErrorAs(t, err, &listenerErr)
+	assert.Len(t, listenerErr.Errors, 2)
+	assert.ErrorIs(t, err, errFirst)
+	assert.ErrorIs(t, err, errSecond)
+}
@@ -0,0 +1,178 @@
+package nodes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/internal"
+)
+
+// AttrTarget defines where an attribute value is assigned on the DOM element.
+type AttrTarget int
+
+const (
+	// AttrDefault assigns the value following the conversion table of ExtractAttributes.
+	AttrDefault AttrTarget = iota
+	// AttrAttribute only assigns the value as an attribute, using setAttribute.
+	AttrAttribute
+	// AttrProperty only assigns the value as an object property. Slices and maps are converted to the arrays
+	// and objects of JavaScript, values without a JavaScript equivalent, such as structs, are ignored.
+	AttrProperty
+)
+
+// Attr is an attribute value with an explicit target, it can be used as the value of any key of
+// Attributes. Use it to force a value to be set as an attribute, such as `aria-checked`, or as a
+// property, such as a map given as an object to a custom element.
+type Attr struct {
+	// Value is the value to assign, it follows the same conversion table as any other attribute value.
+	// A nil value removes the attribute.
+	Value interface{}
+	// Target is where the value is assigned on the DOM element.
+	Target AttrTarget
+}
+
+// AsAttribute forces the given value to be assigned as an attribute only.
+func AsAttribute(value interface{}) Attr {
+	return Attr{Value: value, Target: AttrAttribute}
+}
+
+// AsProperty forces the given value to be assigned as an object property only.
+func AsProperty(value interface{}) Attr {
+	return Attr{Value: value, Target: AttrProperty}
+}
+
+// attributeString converts the given value to its attribute string. Returns false if the value should not
+// be set as an attribute, either because it is not supported, or because it is false or nil.
+func attributeString(value interface{}) (string, bool) {
+	switch casted := value.(type) {
+	case string:
+		return casted, true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", casted), true
+	case float32:
+		return strconv.FormatFloat(float64(casted), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(casted, 'f', -1, 64), true
+	case bool:
+		// Bool attributes only adds the attribute if true, like required=""
+		return "", casted
+	case []string:
+		return strings.Join(casted, " "), true
+	case fmt.Stringer:
+		return casted.String(), true
+	default:
+		return "", false
+	}
+}
+
+// isAttributeValue returns true if the value is supported by attributeString, false included.
+func isAttributeValue(value interface{}) bool {
+	if _, ok := value.(bool); ok {
+		return true
+	}
+
+	_, ok := attributeString(value)
+	return ok
+}
+
+// extractValue assigns the value under the given key in attrs and props, following the conversion
+// table of ExtractAttributes. Returns false if the value is not supported.
+func extractValue(key string, value interface{}, attrs map[string]string, props map[string]interface{}) bool {
+	// Style maps are serialized, HTML nodes patch them property by property using HTMLNode.InlineStyle
+	if style, ok := toStyleMap(value); ok && key == StyleAttribute {
+		attrs[key] = style.String()
+		return true
+	}
+
+	switch casted := value.(type) {
+	case nil:
+		// Nil values are never assigned, which removes any previous value
+		return true
+	case Attr:
+		return extractAttr(key, casted, attrs, props)
+	case map[string]string:
+		for subKey, subValue := range casted {
+			attrs[key+"-"+subKey] = subValue
+		}
+		return true
+	case map[string]interface{}:
+		extractMap(key, casted, attrs)
+		return true
+	}
+
+	if !isAttributeValue(value) {
+		return false
+	}
+
+	converted, set := attributeString(value)
+	if set {
+		attrs[key] = converted
+	}
+
+	// Properties keep the Go value, unless the DOM could not convert it
+	switch value.(type) {
+	case fmt.Stringer, []string:
+		props[key] = converted
+	default:
+		props[key] = value
+	}
+
+	return true
+}
+
+// extractAttr assigns the value of an Attr to its target.
+func extractAttr(key string, attr Attr, attrs map[string]string, props map[string]interface{}) bool {
+	if attr.Value == nil {
+		return true
+	}
+
+	switch attr.Target {
+	case AttrAttribute:
+		if !isAttributeValue(attr.Value) {
+			return false
+		}
+		if converted, ok := attributeString(attr.Value); ok {
+			attrs[key] = converted
+		}
+		return true
+	case AttrProperty:
+		converted, ok := dom.PropertyValue(attr.Value)
+		if !ok {
+			return false
+		}
+		props[key] = converted
+		return true
+	default:
+		return extractValue(key, attr.Value, attrs, props)
+	}
+}
+
+// extractMap assigns each value of the map as an attribute prefixed with the key, such as `data-id` for
+// the "id" key of the "data" map. Nil and unsupported values are skipped.
+func extractMap(key string, values map[string]interface{}, attrs map[string]string) {
+	// Sort the keys so the diagnostics are stable
+	keys := make([]string, 0, len(values))
+	for subKey := range values {
+		keys = append(keys, subKey)
+	}
+	sort.Strings(keys)
+
+	for _, subKey := range keys {
+		subValue := values[subKey]
+		if subValue == nil {
+			continue
+		}
+
+		if !isAttributeValue(subValue) {
+			internal.Debugf("Ignoring attribute %s-%s, unsupported value of type %T\n", key, subKey, subValue)
+			continue
+		}
+
+		if converted, ok := attributeString(subValue); ok {
+			attrs[key+"-"+subKey] = converted
+		}
+	}
+}
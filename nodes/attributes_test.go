@@ -0,0 +1,137 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/minivera/go-lander/nodes"
+)
+
+type unit string
+
+func (u unit) String() string {
+	return string(u) + "px"
+}
+
+func TestExtractAttributes(t *testing.T) {
+	tcs := []struct {
+		name       string
+		value      interface{}
+		attributes map[string]string
+		properties map[string]interface{}
+	}{
+		{
+			name:       "string",
+			value:      "text",
+			attributes: map[string]string{"test": "text"},
+			properties: map[string]interface{}{"test": "text"},
+		},
+		{
+			name:       "int",
+			value:      12,
+			attributes: map[string]string{"test": "12"},
+			properties: map[string]interface{}{"test": 12},
+		},
+		{
+			name:       "int64",
+			value:      int64(-3),
+			attributes: map[string]string{"test": "-3"},
+			properties: map[string]interface{}{"test": int64(-3)},
+		},
+		{
+			name:       "float64",
+			value:      0.5,
+			attributes: map[string]string{"test": "0.5"},
+			properties: map[string]interface{}{"test": 0.5},
+		},
+		{
+			name:       "true",
+			value:      true,
+			attributes: map[string]string{"test": ""},
+			properties: map[string]interface{}{"test": true},
+		},
+		{
+			name:       "false",
+			value:      false,
+			attributes: map[string]string{},
+			properties: map[string]interface{}{"test": false},
+		},
+		{
+			name:       "string slice",
+			value:      []string{"first", "second"},
+			attributes: map[string]string{"test": "first second"},
+			properties: map[string]interface{}{"test": "first second"},
+		},
+		{
+			name:       "stringer",
+			value:      unit("10"),
+			attributes: map[string]string{"test": "10px"},
+			properties: map[string]interface{}{"test": "10px"},
+		},
+		{
+			name:       "string map",
+			value:      map[string]string{"id": "1"},
+			attributes: map[string]string{"test-id": "1"},
+			properties: map[string]interface{}{},
+		},
+		{
+			name:       "map",
+			value:      map[string]interface{}{"id": 1, "active": true, "hidden": false, "removed": nil, "bad": struct{}{}},
+			attributes: map[string]string{"test-id": "1", "test-active": ""},
+			properties: map[string]interface{}{},
+		},
+		{
+			name:       "nil",
+			value:      nil,
+			attributes: map[string]string{},
+			properties: map[string]interface{}{},
+		},
+		{
+			name:       "forced attribute",
+			value:      nodes.AsAttribute(true),
+			attributes: map[string]string{"test": ""},
+			properties: map[string]interface{}{},
+		},
+		{
+			name:       "forced property",
+			value:      nodes.AsProperty([]int{1, 2}),
+			attributes: map[string]string{},
+			properties: map[string]interface{}{"test": []interface{}{1, 2}},
+		},
+		{
+			name:       "forced property map",
+			value:      nodes.AsProperty(map[string][]string{"tags": {"a"}}),
+			attributes: map[string]string{},
+			properties: map[string]interface{}{"test": map[string]interface{}{"tags": []interface{}{"a"}}},
+		},
+		{
+			name:       "forced property without JavaScript equivalent",
+			value:      nodes.AsProperty(struct{ Name string }{Name: "test"}),
+			attributes: map[string]string{},
+			properties: map[string]interface{}{},
+		},
+		{
+			name:       "forced nil",
+			value:      nodes.AsProperty(nil),
+			attributes: map[string]string{},
+			properties: map[string]interface{}{},
+		},
+		{
+			name:       "unsupported",
+			value:      struct{}{},
+			attributes: map[string]string{},
+			properties: map[string]interface{}{},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			attrs, props, listeners := nodes.ExtractAttributes(nodes.Attributes{"test": tc.value})
+			assert.Equal(t, tc.attributes, attrs)
+			assert.Equal(t, tc.properties, props)
+			assert.Empty(t, listeners)
+		})
+	}
+}
@@ -25,6 +25,13 @@ type FuncNode struct {
 
 	factory       noGenericFunctionComponent
 	givenChildren []Node
+	// identity identifies the component function, the factory may wrap it. See SameComponent.
+	identity uintptr
+
+	// Key is the key of this component in its parent's children. When the children of a node are keyed,
+	// they are matched by key rather than by position during diffing. Keys must be comparable and unique
+	// among siblings.
+	Key interface{}
 
 	// Properties are the node's properties, which are passed to the factory on render.
 	Properties interface{}
@@ -32,6 +39,10 @@ type FuncNode struct {
 	// RenderResult is a reference to the result of the factory render, which is kept to allow
 	// diffing later in the algorithm.
 	RenderResult Node
+
+	slots      []*HookSlot
+	slotCursor int
+	rendered   bool
 }
 
 // NewFuncNode creates a new component node with the provided information.
@@ -40,9 +51,38 @@ func NewFuncNode(factory noGenericFunctionComponent, props interface{}, givenChi
 		Properties:    props,
 		factory:       factory,
 		givenChildren: givenChildren,
+		identity:      componentIdentity(factory),
 	}
 }
 
+// NewComponentNode creates a new component node rendering the given typed factory. The factory is wrapped to
+// hide the generic, the node is still identified by the given factory.
+func NewComponentNode[T any](factory FunctionComponent[T], props T, givenChildren []Node) *FuncNode {
+	node := NewFuncNode(func(ctx context.Context, props interface{}, children Children) Child {
+		return factory(ctx, props.(T), children)
+	}, props, givenChildren)
+	node.identity = componentIdentity(factory)
+
+	return node
+}
+
+// comparePropsFields compares the unexported fields of the props too, props are often unexported structs.
+var comparePropsFields = cmp.Exporter(func(reflect.Type) bool {
+	return true
+})
+
+// componentIdentity returns the address of the code of the given function. Closures created from the same
+// function literal share it, so a component defined inline is the same component on every render.
+func componentIdentity(factory interface{}) uintptr {
+	return reflect.ValueOf(factory).Pointer()
+}
+
+// SameComponent returns true if both nodes render the same component function. A different component at
+// the same position is never updated in place, as it would receive the hook slots of the other component.
+func (n *FuncNode) SameComponent(other *FuncNode) bool {
+	return n.identity == other.identity
+}
+
 // Render triggers the component's factory, passing the properties and children of the node.
 // It will save the result in the node's memory for later diffs.
 func (n *FuncNode) Render(ctx context.Context) Node {
@@ -50,6 +90,27 @@ func (n *FuncNode) Render(ctx context.Context) Node {
 	return n.RenderResult
 }
 
+// Update replaces the factory, properties and children of the component with the ones of the other node.
+// Components are rendered from a clone during diffing, updating keeps the node stored in the tree in sync
+// so it can be rendered again on its own.
+func (n *FuncNode) Update(other *FuncNode) {
+	n.factory = other.factory
+	n.identity = other.identity
+	n.givenChildren = other.givenChildren
+	n.Key = other.Key
+	n.Properties = other.Properties
+}
+
+// ToString returns the HTML of the last render result of the component. Returns an empty string if the
+// component was never rendered or rendered nil.
+func (n *FuncNode) ToString() string {
+	if n.RenderResult == nil {
+		return ""
+	}
+
+	return n.RenderResult.ToString()
+}
+
 func (n *FuncNode) Diff(other Node) bool {
 	otherAsFunc, ok := other.(*FuncNode)
 	if !ok {
@@ -60,7 +121,7 @@ func (n *FuncNode) Diff(other Node) bool {
 		return true
 	}
 
-	if &otherAsFunc.factory != &n.factory {
+	if !n.SameComponent(otherAsFunc) {
 		return true
 	}
 
@@ -68,7 +129,7 @@ func (n *FuncNode) Diff(other Node) bool {
 		return true
 	}
 
-	if !cmp.Equal(otherAsFunc.Properties, n.Properties) {
+	if !cmp.Equal(otherAsFunc.Properties, n.Properties, comparePropsFields) {
 		return true
 	}
 
@@ -91,7 +152,9 @@ func (n *FuncNode) Clone() *FuncNode {
 	return &FuncNode{
 		baseNode:      n.baseNode,
 		factory:       n.factory,
+		identity:      n.identity,
 		givenChildren: n.givenChildren,
+		Key:           n.Key,
 		Properties:    n.Properties,
 		RenderResult:  nil,
 	}
@@ -0,0 +1,97 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+)
+
+// controlledElements is the set of the form elements whose state can be changed by the user.
+var controlledElements = map[string]bool{
+	"input":    true,
+	"textarea": true,
+	"select":   true,
+}
+
+// controlledProperties is the set of the properties of form elements changed by the user. When given
+// as attributes, these properties are synced with the DOM rather than diffed, see HTMLNode.SyncControlled.
+var controlledProperties = map[string]bool{
+	"value":         true,
+	"checked":       true,
+	"selectedIndex": true,
+}
+
+// isControlledProperty returns true if the property is controlled for an element with the given tag.
+func isControlledProperty(tag, name string) bool {
+	return controlledElements[tag] && controlledProperties[name]
+}
+
+// IsControlled returns true if this node is a controlled form element, an input, textarea or select
+// given a value, checked or selectedIndex attribute.
+func (n *HTMLNode) IsControlled() bool {
+	for name := range n.Properties {
+		if isControlledProperty(n.Tag, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SyncControlled sets the controlled properties of this node on its DOM element, but only when the DOM
+// differs from the virtual node, for example when the user typed a value the app did not accept. The
+// selection of text fields is kept when their value is set, so the caret does not jump to the end. The
+// value is never set while the user is composing text with an IME, the app is rendered again once the
+// composition ends, see events.IsComposing.
+//
+// SyncControlled must be called once the children of the node are mounted, the value of a select can only
+// be set once its options exist.
+func (n *HTMLNode) SyncControlled() {
+	if n.DomNode == nil || !controlledElements[n.Tag] {
+		return
+	}
+
+	// The selected index wins over the value when both are given, as it is set last
+	for _, name := range []string{"value", "checked", "selectedIndex"} {
+		value, ok := n.Properties[name]
+		if !ok || sameControlledValue(n.DomNode.GetProperty(name), value) {
+			continue
+		}
+
+		if name == "value" {
+			if events.IsComposing(n.DomNode) {
+				continue
+			}
+
+			setValuePreservingSelection(n.DomNode, value)
+			continue
+		}
+
+		n.DomNode.SetProperty(name, value)
+	}
+}
+
+// sameControlledValue compares the value of a DOM property with the value of the virtual node. The DOM
+// returns strings for values and numbers as float64, both are compared through their string version.
+func sameControlledValue(current, expected interface{}) bool {
+	return current != nil && fmt.Sprint(current) == fmt.Sprint(expected)
+}
+
+// setValuePreservingSelection sets the value of a text field and restores its selection, which browsers
+// move to the end of the new value. The selection is not restored on fields that do not have one, such
+// as number inputs, where the selection is nil.
+func setValuePreservingSelection(element dom.Element, value interface{}) {
+	start := element.GetProperty("selectionStart")
+	end := element.GetProperty("selectionEnd")
+
+	element.SetProperty("value", value)
+
+	if start == nil || end == nil {
+		return
+	}
+
+	// The browser clamps the selection to the length of the new value
+	element.SetProperty("selectionStart", start)
+	element.SetProperty("selectionEnd", end)
+}
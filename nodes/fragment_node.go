@@ -20,6 +20,10 @@ func NewFragmentNode(children []Node) *FragmentNode {
 func (n *FragmentNode) ToString() string {
 	content := ""
 	for _, child := range n.Children {
+		if child == nil {
+			continue
+		}
+
 		content += child.ToString()
 	}
 
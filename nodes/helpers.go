@@ -1,19 +1,48 @@
-//go:build js && wasm
-
 package nodes
 
 import (
-	"fmt"
 	"math/rand"
-	"syscall/js"
 	"time"
 
+	"github.com/minivera/go-lander/dom"
 	lEvents "github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/internal"
 )
 
+// KeyAttribute is the name of the attribute used to give a key to an HTML node. See HTMLNode.Key.
+const KeyAttribute = "key"
+
+// KeyOf returns the key of the given node, or nil if the node is not keyed. Only HTML and component nodes
+// can be keyed.
+func KeyOf(node Node) interface{} {
+	switch typed := node.(type) {
+	case *HTMLNode:
+		return typed.Key
+	case *FuncNode:
+		return typed.Key
+	default:
+		return nil
+	}
+}
+
 // ExtractAttributes extracts the relevant attributes, props, and listeners for an HTML node given the
 // attributes map. This allows extracting based on types, which can then be reconciled with the DOM nodes
-// attributes and properties.
+// attributes and properties. Values are converted following this table:
+//
+//   - string is set as both an attribute and a property.
+//   - Integers and floats are formatted in base 10 for the attribute, the property keeps the number.
+//   - bool sets the property, and the empty attribute only if true, like required="".
+//   - []string is joined with spaces, for class lists or `rel` for example.
+//   - fmt.Stringer is converted with String().
+//   - map[string]string and map[string]interface{} set one attribute per entry, prefixed with the key and a
+//     dash, such as `"data": map[string]string{"id": "1"}` for `data-id="1"`.
+//   - StyleMap and map[string]string under the "style" key are serialized as the inline style, see StyleMap.
+//   - nil is never set, any value set by a previous render is removed.
+//   - Attr forces the value to be set as an attribute or a property only.
+//   - Event listeners, typed or not, and *events.EventListener are extracted as listeners.
+//   - The "key" and "ref" attributes are never extracted, see HTMLNode.Key and Ref.
+//
+// Any other value is ignored with a debug diagnostic.
 func ExtractAttributes(attributes map[string]interface{}) (
 	map[string]string, map[string]interface{}, map[string]*lEvents.EventListener) {
 
@@ -22,33 +51,30 @@ func ExtractAttributes(attributes map[string]interface{}) (
 	events := map[string]*lEvents.EventListener{}
 
 	for key, value := range attributes {
+		// The key and ref are only used by the virtual tree, they never reach the DOM
+		if key == KeyAttribute || key == RefAttribute {
+			continue
+		}
+
 		switch casted := value.(type) {
-		case string:
-			attrs[key] = casted
-			props[key] = casted
-		case int:
-			attrs[key] = fmt.Sprintf("%d", casted)
-			props[key] = casted
-		case bool:
-			// Bool attributes only adds the attribute if true, like required=""
-			props[key] = casted
-			if casted {
-				attrs[key] = ""
-			}
-		case func(*lEvents.DOMEvent) error:
-			events[key] = &lEvents.EventListener{
-				Name: key,
-				Func: casted,
+		case *lEvents.EventListener:
+			// The listener may be shared between nodes, copy it before naming it
+			listener := *casted
+			listener.Name = key
+			events[key] = &listener
+		default:
+			if listener, ok := lEvents.ListenerFunc(value); ok {
+				events[key] = &lEvents.EventListener{
+					Name: key,
+					Func: listener,
+				}
+				continue
 			}
-		case lEvents.EventListenerFunc:
-			events[key] = &lEvents.EventListener{
-				Name: key,
-				Func: casted,
+
+			// Unsupported values are ignored to avoid panicking
+			if !extractValue(key, value, attrs, props) {
+				internal.Debugf("Ignoring attribute %s, unsupported value of type %T\n", key, value)
 			}
-		default:
-			// attributes only support vars of type string, bool, int or EventListener
-			// Any other attribute is ignored to avoid panicking.
-			continue
 		}
 	}
 
@@ -57,25 +83,24 @@ func ExtractAttributes(attributes map[string]interface{}) (
 
 // NewHTMLElement creates a new HTML node and sets all its attributes, properties, and event listeners
 // on creation.
-func NewHTMLElement(document js.Value, currentElement *HTMLNode) js.Value {
-	var domElement js.Value
+func NewHTMLElement(document dom.Document, currentElement *HTMLNode) dom.Element {
+	var domElement dom.Element
 	if currentElement.Namespace != "" {
-		domElement = document.Call("createElementNS", currentElement.Namespace, currentElement.Tag)
+		domElement = document.CreateElementNS(currentElement.Namespace, currentElement.Tag)
 	} else {
-		domElement = document.Call("createElement", currentElement.Tag)
+		domElement = document.CreateElement(currentElement.Tag)
 	}
 
 	for key, value := range currentElement.Attributes {
-		domElement.Call("setAttribute", key, value)
+		domElement.SetAttribute(key, value)
 	}
 
-	classList := domElement.Get("classList")
 	for _, value := range currentElement.Classes {
-		classList.Call("add", value)
+		domElement.AddClass(value)
 	}
 
 	if currentElement.DomID != "" {
-		domElement.Set("id", currentElement.DomID)
+		domElement.SetProperty("id", currentElement.DomID)
 	}
 
 	return domElement
@@ -0,0 +1,103 @@
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/minivera/go-lander/internal"
+)
+
+// HookSlot stores the state of a single hook call in a component. Slots are stored on the component node
+// kept in the tree, in the order the hooks are called, see the experimental hooks package.
+type HookSlot struct {
+	// Kind is the name of the hook using this slot. The kind is checked on every render to detect hooks
+	// called in a different order.
+	Kind string
+
+	// Value is the state of the hook, its type is determined by the hook.
+	Value interface{}
+}
+
+// StartRender moves the hook cursor of the component back to its first slot. Call StartRender on the
+// node stored in the tree right before rendering it, or one of its clones.
+func (n *FuncNode) StartRender() {
+	n.slotCursor = 0
+}
+
+// EndRender marks the component as rendered. In debug mode, EndRender panics if the component called fewer
+// hooks than during its previous render. Otherwise, a warning is logged and the slots of the hooks that were
+// not called are discarded.
+func (n *FuncNode) EndRender() {
+	if n.rendered && n.slotCursor != len(n.slots) {
+		message := fmt.Sprintf(
+			"component rendered %d hooks, but rendered %d hooks during the previous render, hooks must be called in the same order on every render",
+			n.slotCursor,
+			len(n.slots),
+		)
+		if internal.IsDebug {
+			panic(message)
+		}
+
+		internal.Debugf("%s, discarding the remaining hooks\n", message)
+		n.slots = n.slots[:n.slotCursor]
+	}
+
+	n.rendered = true
+}
+
+// NextSlot returns the next hook slot of the component and moves the cursor forward. The slot is created
+// with the given kind if the component never called that many hooks, in which case created is true.
+//
+// The kind of the slot is always checked, a hook never receives the state of another kind of hook. In debug
+// mode, NextSlot panics if the slot was created for a different kind of hook, or if a slot has to be created
+// after the first render. Otherwise, a warning is logged and the slot is reset, with the slots following it,
+// so the hook starts from a new state.
+func (n *FuncNode) NextSlot(kind string) (slot *HookSlot, created bool) {
+	index := n.slotCursor
+	n.slotCursor++
+
+	if index < len(n.slots) {
+		slot = n.slots[index]
+		if slot.Kind == kind {
+			return slot, false
+		}
+
+		message := fmt.Sprintf(
+			"hook %d was %s during the previous render, but is now %s, hooks must be called in the same order on every render",
+			index,
+			slot.Kind,
+			kind,
+		)
+		if internal.IsDebug {
+			panic(message)
+		}
+
+		internal.Debugf("%s, resetting the hook\n", message)
+		n.slots = n.slots[:index]
+	} else if n.rendered {
+		message := fmt.Sprintf(
+			"component rendered more hooks than during the previous render, hook %d (%s) is new, hooks must be called in the same order on every render",
+			index,
+			kind,
+		)
+		if internal.IsDebug {
+			panic(message)
+		}
+
+		internal.Debugf("%s, creating the hook\n", message)
+	}
+
+	slot = &HookSlot{
+		Kind: kind,
+	}
+	n.slots = append(n.slots, slot)
+
+	return slot, true
+}
+
+// ClearSlots discards the hook slots of the component, called when the component is unmounted. A component
+// mounted again starts with new slots.
+func (n *FuncNode) ClearSlots() {
+	n.slots = nil
+	n.slotCursor = 0
+	n.rendered = false
+}
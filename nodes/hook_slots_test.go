@@ -0,0 +1,126 @@
+package nodes_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/internal"
+	"github.com/minivera/go-lander/nodes"
+)
+
+func TestFuncNode_NextSlot(t *testing.T) {
+	tcs := []struct {
+		name   string
+		first  []string
+		second []string
+		// created is whether each hook of the second render gets a new slot outside of debug mode
+		created []bool
+		panic   bool
+	}{
+		{
+			name:    "same order",
+			first:   []string{"UseState", "UseEffect"},
+			second:  []string{"UseState", "UseEffect"},
+			created: []bool{false, false},
+			panic:   false,
+		},
+		{
+			name:    "different kind",
+			first:   []string{"UseState", "UseEffect"},
+			second:  []string{"UseEffect", "UseState"},
+			created: []bool{true, true},
+			panic:   true,
+		},
+		{
+			name:    "more hooks",
+			first:   []string{"UseState"},
+			second:  []string{"UseState", "UseState"},
+			created: []bool{false, true},
+			panic:   true,
+		},
+		{
+			name:    "fewer hooks",
+			first:   []string{"UseState", "UseState"},
+			second:  []string{"UseState"},
+			created: []bool{false},
+			panic:   true,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		newNode := func() *nodes.FuncNode {
+			return nodes.NewFuncNode(func(context.Context, interface{}, nodes.Children) nodes.Child {
+				return nil
+			}, nodes.Props{}, nil)
+		}
+		render := func(node *nodes.FuncNode, kinds []string) []bool {
+			var created []bool
+			node.StartRender()
+			for _, kind := range kinds {
+				_, isNew := node.NextSlot(kind)
+				created = append(created, isNew)
+			}
+			node.EndRender()
+
+			return created
+		}
+
+		t.Run(tc.name+" in debug mode", func(t *testing.T) {
+			internal.IsDebug = true
+			defer func() {
+				internal.IsDebug = false
+			}()
+
+			node := newNode()
+			render(node, tc.first)
+			if tc.panic {
+				assert.Panics(t, func() {
+					render(node, tc.second)
+				})
+			} else {
+				assert.NotPanics(t, func() {
+					render(node, tc.second)
+				})
+			}
+		})
+
+		t.Run(tc.name, func(t *testing.T) {
+			node := newNode()
+			render(node, tc.first)
+			assert.Equal(t, tc.created, render(node, tc.second))
+
+			// The slots were reset to match the second render
+			assert.Equal(t, make([]bool, len(tc.second)), render(node, tc.second))
+		})
+	}
+}
+
+func TestFuncNode_ClearSlots(t *testing.T) {
+	node := nodes.NewFuncNode(func(context.Context, interface{}, nodes.Children) nodes.Child {
+		return nil
+	}, nodes.Props{}, nil)
+
+	node.StartRender()
+	slot, created := node.NextSlot("UseState")
+	assert.True(t, created)
+	slot.Value = 1
+	node.EndRender()
+
+	// Slots are read again in order on the next render
+	node.StartRender()
+	again, created := node.NextSlot("UseState")
+	assert.False(t, created)
+	assert.Same(t, slot, again)
+	node.EndRender()
+
+	// Cleared slots are created again
+	node.ClearSlots()
+	node.StartRender()
+	fresh, created := node.NextSlot("UseState")
+	assert.True(t, created)
+	assert.Nil(t, fresh.Value)
+	node.EndRender()
+}
@@ -2,9 +2,12 @@ package nodes
 
 import (
 	"fmt"
+	"html"
+	"reflect"
+	"sort"
 	"strings"
-	"syscall/js"
 
+	"github.com/minivera/go-lander/dom"
 	"github.com/minivera/go-lander/events"
 )
 
@@ -19,7 +22,7 @@ type HTMLNode struct {
 
 	// DomNode is the real DOM node associated with this virtual node. If set, this node is
 	// mounted.
-	DomNode js.Value
+	DomNode dom.Element
 
 	// ActiveClass is an active, random, class given to this element by the styling function.
 	ActiveClass string
@@ -28,6 +31,12 @@ type HTMLNode struct {
 	Namespace string
 	// DomID is the ID of the element in the DOM, set as "id" on the element itself.
 	DomID string
+	// Key is the key of this element in its parent's children, given through the "key" attribute. When
+	// the children of a node are keyed, they are matched by key rather than by position during diffing.
+	// Keys must be comparable and unique among siblings.
+	Key interface{}
+	// Ref is the ref given through the "ref" attribute, if any. It holds the DomNode while mounted.
+	Ref *Ref
 	// Tag is the HMTL tag of this element, such as "div" or "span".
 	Tag string
 	// Classes is a list of CSS classes to assign to this element.
@@ -38,6 +47,9 @@ type HTMLNode struct {
 	// Properties is a map of any type to assign on the DOM element directly as object properties.
 	// attributes should be stored in Attributes.
 	Properties map[string]interface{}
+	// InlineStyle is the style map given as the "style" attribute, if any. Its properties are set and
+	// diffed one by one, a style string is kept in Attributes instead.
+	InlineStyle StyleMap
 	// EventListeners is a map of event listeners to their events. Does not expect the "on" prefix.
 	// events listeners are added directly on the DOM element.
 	EventListeners map[string]*events.EventListener
@@ -50,149 +62,264 @@ type HTMLNode struct {
 
 // NewHTMLNode creates a new HTML node with the provided information.
 func NewHTMLNode(tag string, attributes Attributes, children []Node) *HTMLNode {
+	node := &HTMLNode{
+		Key:      attributes[KeyAttribute],
+		Tag:      tag,
+		Children: children,
+		Styles:   []string{},
+	}
+	node.setAttributes(attributes)
+
+	return node
+}
+
+// setAttributes extracts the attributes map on this node. The ID and classes are only kept in DomID
+// and Classes, they are never part of the attributes or properties.
+func (n *HTMLNode) setAttributes(attributes map[string]interface{}) {
 	attrs, props, listeners := ExtractAttributes(attributes)
+	n.InlineStyle = extractStyle(attributes, attrs)
 
-	var id string
-	if val, ok := attrs["id"]; ok {
-		id = val
+	n.DomID = attrs["id"]
+	n.Classes = strings.Fields(attrs["class"])
+	for _, key := range []string{"id", "class"} {
+		delete(attrs, key)
+		delete(props, key)
 	}
 
-	var classes []string
-	if val, ok := attrs["class"]; ok {
-		classes = strings.Split(val, " ")
-	}
+	n.Attributes = attrs
+	n.Properties = props
+	n.EventListeners = listeners
 
-	return &HTMLNode{
-		DomID:          id,
-		Tag:            tag,
-		Classes:        classes,
-		Attributes:     attrs,
-		Properties:     props,
-		EventListeners: listeners,
-		Children:       children,
-		Styles:         []string{},
-	}
+	ref, _ := attributes[RefAttribute].(*Ref)
+	n.SetRef(ref)
 }
 
 // Update updates this HTML node with the provided attributes map. The map will be extracted to
 // attributes, props, and event listeners using ExtractAttributes, then applied to the virtual
-// DOM node and the underlying real DOM node.
+// DOM node and the underlying real DOM node. Only the attributes, properties, and classes that were
+// added, changed, or removed since the last update are applied on the DOM node, anything else is left
+// untouched so transitions, focus, and cursors are kept.
 func (n *HTMLNode) Update(newAttributes map[string]interface{}) {
 	oldAttributes := n.Attributes
 	oldProps := n.Properties
-	attrs, props, listeners := ExtractAttributes(newAttributes)
+	oldStyle := n.InlineStyle
+	oldClasses := n.Classes
+	oldID := n.DomID
+	n.setAttributes(newAttributes)
 
-	n.DomID = ""
-
-	if val, ok := attrs["id"]; ok {
-		n.DomID = val
-		delete(attrs, "id")
-	}
-
-	if val, ok := attrs["class"]; ok {
-		n.Classes = strings.Split(val, " ")
-		delete(attrs, "class")
-	}
-
-	n.Attributes = attrs
-	n.Properties = props
-	n.EventListeners = listeners
-
-	// Remove, then set the new attributes/properties
+	// Remove the old values first, so a style string can be replaced by style properties
 	for key := range oldAttributes {
-		n.DomNode.Call("removeAttribute", key)
+		if _, ok := n.Attributes[key]; !ok {
+			n.DomNode.RemoveAttribute(key)
+		}
 	}
 	for key := range oldProps {
-		n.DomNode.Set(key, nil)
+		if _, ok := n.Properties[key]; !ok && !isControlledProperty(n.Tag, key) {
+			n.DomNode.SetProperty(key, nil)
+		}
 	}
 
+	// Only the changed style properties are patched, before a style string can replace them
+	patchStyle(n.DomNode, oldStyle, n.InlineStyle)
+
 	for key, value := range n.Attributes {
-		n.DomNode.Call("setAttribute", key, value)
+		if oldValue, ok := oldAttributes[key]; !ok || oldValue != value {
+			n.DomNode.SetAttribute(key, value)
+		}
 	}
 	for key, value := range n.Properties {
-		n.DomNode.Set(key, value)
-	}
+		// Controlled properties are synced against the DOM once the children are patched
+		if isControlledProperty(n.Tag, key) {
+			continue
+		}
 
-	// Clear the old class list, then set the new classes
-	classList := n.DomNode.Get("classList")
-	classesLength := classList.Get("length").Int()
-	for i := 0; i < classesLength; i += 1 {
-		classList.Call("remove", classList.Call("item", i))
+		if oldValue, ok := oldProps[key]; !ok || !reflect.DeepEqual(oldValue, value) {
+			n.DomNode.SetProperty(key, value)
+		}
 	}
 
-	for _, value := range n.Classes {
-		classList.Call("add", value)
-	}
+	patchClasses(n.DomNode, oldClasses, n.Classes)
 
 	// Set the ID if needed, if not, remove it
+	if n.DomID == oldID {
+		return
+	}
 	if n.DomID != "" {
-		n.DomNode.Set("id", n.DomID)
+		n.DomNode.SetProperty("id", n.DomID)
 	} else {
-		n.DomNode.Delete("id")
+		n.DomNode.RemoveAttribute("id")
+	}
+}
+
+// patchClasses removes the old classes missing from the new classes, then adds the new classes missing
+// from the old classes. Classes added on the DOM element outside lander are kept.
+func patchClasses(element dom.Element, oldClasses, newClasses []string) {
+	oldSet := make(map[string]bool, len(oldClasses))
+	for _, class := range oldClasses {
+		oldSet[class] = true
+	}
+	newSet := make(map[string]bool, len(newClasses))
+	for _, class := range newClasses {
+		newSet[class] = true
+	}
+
+	for _, class := range oldClasses {
+		if !newSet[class] {
+			element.RemoveClass(class)
+		}
+	}
+	for _, class := range newClasses {
+		if !oldSet[class] {
+			element.AddClass(class)
+		}
 	}
 }
 
 // Mount sets the real DOM node on this HTML node, the applies the attributes, props, and event listeners
 // on the underlying real DOM node.
-func (n *HTMLNode) Mount(domNode js.Value) {
+func (n *HTMLNode) Mount(domNode dom.Element) {
 	n.DomNode = domNode
+	n.Ref.attach(domNode)
 
 	// Attributes
 	for name, value := range n.Attributes {
-		n.DomNode.Call("setAttribute", name, value)
+		n.DomNode.SetAttribute(name, value)
 	}
 
-	// Properties
+	// Properties, controlled properties are synced once the children are mounted
 	for name, value := range n.Properties {
-		n.DomNode.Set(name, value)
+		if !isControlledProperty(n.Tag, name) {
+			n.DomNode.SetProperty(name, value)
+		}
+	}
+
+	// Inline style
+	for name, value := range n.InlineStyle {
+		n.DomNode.SetStyleProperty(name, value)
 	}
 
 	// Classes
-	classList := n.DomNode.Get("classList")
 	for _, value := range n.Classes {
-		classList.Call("add", value)
+		n.DomNode.AddClass(value)
 	}
 
 	// Add the active class
 	if n.ActiveClass != "" {
-		classList.Call("add", n.ActiveClass)
+		n.DomNode.AddClass(n.ActiveClass)
 	}
 
 	// ID if set
 	if n.DomID != "" {
-		n.DomNode.Set("id", n.DomID)
+		n.DomNode.SetProperty("id", n.DomID)
 	}
 }
 
+// voidElements is the set of HTML elements that cannot have any content and must be rendered without
+// a closing tag.
+var voidElements = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"link":   true,
+	"meta":   true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+// rawTextElements is the set of HTML elements whose text content is not parsed as HTML by the browser,
+// their text children must not be escaped.
+var rawTextElements = map[string]bool{
+	"script": true,
+	"style":  true,
+}
+
 func (n *HTMLNode) ToString() string {
 	content := ""
 	for _, child := range n.Children {
+		if child == nil {
+			continue
+		}
+
+		if textChild, ok := child.(*TextNode); ok && rawTextElements[n.Tag] {
+			content += textChild.Text
+			continue
+		}
+
 		content += child.ToString()
 	}
 
-	attributesString := make([]string, len(n.Attributes))
-	count := 0
+	attributes := map[string]string{}
 	for key, val := range n.Attributes {
-		attributesString[count] = fmt.Sprintf("%s=\"%s\"", key, val)
-		count += 1
+		attributes[key] = val
 	}
 
-	html := fmt.Sprintf("<%s ", n.Tag)
+	// Properties that were not extracted as attributes are rendered as attributes, as there are no
+	// DOM nodes to assign them on.
+	for key, val := range n.Properties {
+		if _, ok := attributes[key]; ok {
+			continue
+		}
+
+		switch casted := val.(type) {
+		case string:
+			attributes[key] = casted
+		case bool:
+			if casted {
+				attributes[key] = ""
+			}
+		case int, int64, float64:
+			attributes[key] = fmt.Sprint(casted)
+		}
+	}
+
+	if len(n.InlineStyle) > 0 {
+		attributes[StyleAttribute] = n.InlineStyle.String()
+	}
+
+	// ID and classes are handled separately, they are tracked outside the attributes after an update
+	delete(attributes, "id")
+	delete(attributes, "class")
 	if n.DomID != "" {
-		html += fmt.Sprintf("id=\"%s\" ", n.DomID)
+		attributes["id"] = n.DomID
+	}
+
+	classes := append([]string{}, n.Classes...)
+	if n.ActiveClass != "" {
+		classes = append(classes, n.ActiveClass)
+	}
+	if len(classes) > 0 {
+		attributes["class"] = strings.Join(classes, " ")
 	}
 
-	if len(n.Classes) > 0 || n.ActiveClass != "" {
-		html += fmt.Sprintf("class=\"%s\" ", strings.Join(append(n.Classes, n.ActiveClass), " "))
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	return fmt.Sprintf(
-		"%s%s>%s</%s>",
-		html,
-		strings.Join(attributesString, " "),
-		content,
-		n.Tag,
-	)
+	tag := html.EscapeString(n.Tag)
+	result := "<" + tag
+	for _, key := range keys {
+		if attributes[key] == "" {
+			result += " " + html.EscapeString(key)
+			continue
+		}
+
+		result += fmt.Sprintf(" %s=\"%s\"", html.EscapeString(key), html.EscapeString(attributes[key]))
+	}
+	result += ">"
+
+	if voidElements[n.Tag] {
+		return result
+	}
+
+	return fmt.Sprintf("%s%s</%s>", result, content, tag)
 }
 
 func (n *HTMLNode) Diff(other Node) bool {
@@ -230,6 +357,28 @@ func (n *HTMLNode) Diff(other Node) bool {
 		}
 	}
 
+	if len(otherAsHtml.Properties) != len(n.Properties) {
+		return true
+	}
+
+	for key, val := range n.Properties {
+		otherVal, ok := otherAsHtml.Properties[key]
+		if !ok || !reflect.DeepEqual(val, otherVal) {
+			return true
+		}
+	}
+
+	if len(otherAsHtml.InlineStyle) != len(n.InlineStyle) {
+		return true
+	}
+
+	for name, value := range n.InlineStyle {
+		otherValue, ok := otherAsHtml.InlineStyle[name]
+		if !ok || value != otherValue {
+			return true
+		}
+	}
+
 	// We don't check event listeners here, they should always be updated
 
 	if len(otherAsHtml.Styles) != len(n.Styles) {
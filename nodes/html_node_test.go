@@ -0,0 +1,158 @@
+package nodes_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/nodes"
+)
+
+// recordingElement records the operations applied on an in-memory element.
+type recordingElement struct {
+	*dom.MemoryElement
+	operations []string
+}
+
+func (e *recordingElement) SetAttribute(name, value string) {
+	e.operations = append(e.operations, fmt.Sprintf("set attribute %s=%s", name, value))
+	e.MemoryElement.SetAttribute(name, value)
+}
+
+func (e *recordingElement) RemoveAttribute(name string) {
+	e.operations = append(e.operations, "remove attribute "+name)
+	e.MemoryElement.RemoveAttribute(name)
+}
+
+func (e *recordingElement) SetProperty(name string, value interface{}) {
+	e.operations = append(e.operations, fmt.Sprintf("set property %s=%v", name, value))
+	e.MemoryElement.SetProperty(name, value)
+}
+
+func (e *recordingElement) AddClass(class string) {
+	e.operations = append(e.operations, "add class "+class)
+	e.MemoryElement.AddClass(class)
+}
+
+func (e *recordingElement) RemoveClass(class string) {
+	e.operations = append(e.operations, "remove class "+class)
+	e.MemoryElement.RemoveClass(class)
+}
+
+func (e *recordingElement) SetStyleProperty(name, value string) {
+	e.operations = append(e.operations, fmt.Sprintf("set style %s=%s", name, value))
+	e.MemoryElement.SetStyleProperty(name, value)
+}
+
+func (e *recordingElement) RemoveStyleProperty(name string) {
+	e.operations = append(e.operations, "remove style "+name)
+	e.MemoryElement.RemoveStyleProperty(name)
+}
+
+func TestHTMLNode_Update(t *testing.T) {
+	tcs := []struct {
+		name       string
+		before     nodes.Attributes
+		after      nodes.Attributes
+		operations []string
+		html       string
+	}{
+		{
+			name:       "unchanged",
+			before:     nodes.Attributes{"id": "field", "class": "a b", "title": "hello", "value": "text"},
+			after:      nodes.Attributes{"id": "field", "class": "a b", "title": "hello", "value": "text"},
+			operations: nil,
+			html:       `<div class="a b" id="field" title="hello" value="text"></div>`,
+		},
+		{
+			name:   "changed values",
+			before: nodes.Attributes{"title": "hello", "value": "text", "tabindex": 1},
+			after:  nodes.Attributes{"title": "bye", "value": "text", "tabindex": 2},
+			operations: []string{
+				"set attribute tabindex=2",
+				"set attribute title=bye",
+				"set property tabindex=2",
+				"set property title=bye",
+			},
+			html: `<div tabindex="2" title="bye" value="text"></div>`,
+		},
+		{
+			name:   "added and removed values",
+			before: nodes.Attributes{"title": "hello", "disabled": true},
+			after:  nodes.Attributes{"placeholder": "name", "disabled": false},
+			operations: []string{
+				"remove attribute disabled",
+				"remove attribute title",
+				"set attribute placeholder=name",
+				"set property disabled=false",
+				"set property placeholder=name",
+				"set property title=<nil>",
+			},
+			html: `<div placeholder="name"></div>`,
+		},
+		{
+			name:   "properties only",
+			before: nodes.Attributes{"items": nodes.AsProperty([]int{1, 2})},
+			after:  nodes.Attributes{"items": nodes.AsProperty([]int{1, 3})},
+			operations: []string{
+				"set property items=[1 3]",
+			},
+			html: `<div></div>`,
+		},
+		{
+			name:   "classes and ID",
+			before: nodes.Attributes{"id": "first", "class": "a b c"},
+			after:  nodes.Attributes{"class": []string{"c", "d", "a"}},
+			operations: []string{
+				"add class d",
+				"remove attribute id",
+				"remove class b",
+			},
+			html: `<div class="a c d"></div>`,
+		},
+		{
+			name:   "style properties",
+			before: nodes.Attributes{"style": nodes.StyleMap{"width": "1px", "color": "red"}},
+			after:  nodes.Attributes{"style": nodes.StyleMap{"width": "2px", "height": "1px"}},
+			operations: []string{
+				"remove style color",
+				"set style height=1px",
+				"set style width=2px",
+			},
+			html: `<div style="width: 2px; height: 1px;"></div>`,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			document := dom.NewMemoryDocument()
+			element := &recordingElement{MemoryElement: document.CreateElement("div").(*dom.MemoryElement)}
+
+			node := nodes.NewHTMLNode("div", tc.before, nodes.Children{})
+			node.Mount(element)
+			element.operations = nil
+
+			// Nodes are only patched when different
+			assert.Equal(t, tc.operations != nil, node.Diff(nodes.NewHTMLNode("div", tc.after, nodes.Children{})))
+
+			node.Update(tc.after)
+
+			// Maps are iterated in random order, only the set of operations matters
+			sort.Strings(element.operations)
+			assert.Equal(t, tc.operations, element.operations)
+			assert.Equal(t, tc.html, element.OuterHTML())
+		})
+	}
+}
+
+func TestHTMLNode_DiffProperties(t *testing.T) {
+	before := nodes.NewHTMLNode("input", nodes.Attributes{"value": nodes.AsProperty("a")}, nodes.Children{})
+
+	assert.False(t, before.Diff(nodes.NewHTMLNode("input", nodes.Attributes{"value": nodes.AsProperty("a")}, nodes.Children{})))
+	assert.True(t, before.Diff(nodes.NewHTMLNode("input", nodes.Attributes{"value": nodes.AsProperty("b")}, nodes.Children{})))
+	assert.True(t, before.Diff(nodes.NewHTMLNode("input", nodes.Attributes{}, nodes.Children{})))
+}
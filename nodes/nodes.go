@@ -1,5 +1,3 @@
-//go:build js && wasm
-
 package nodes
 
 // Child is a utility type that is interchangeable with Node. It defines a single child
@@ -20,13 +18,16 @@ const (
 	TextNodeType
 	FuncNodeType
 	FragmentNodeType
+	PortalNodeType
+	RawHTMLNodeType
 )
 
 // Node is a generic interface for a Node in the virtual DOM tree. All nodes should implement this
 // interface through the baseNode concrete struct.
 type Node interface {
-	// ToString returns the node's content as valid HTML for rendering on the server side. Not used
-	// at the moment.
+	// ToString returns the node's content as valid HTML for rendering on the server side. Text and
+	// attribute values are escaped. Component nodes must have been rendered first to have any content,
+	// see lander.RenderToString.
 	ToString() string
 
 	// Diff checks if the current node is different to the other node. Will return true of the nodes are
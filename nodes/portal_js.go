@@ -0,0 +1,23 @@
+//go:build js && wasm
+
+package nodes
+
+import (
+	"syscall/js"
+
+	"github.com/minivera/go-lander/dom"
+)
+
+// toElement converts a portal target to a DOM element, js.Value targets are wrapped. Returns nil if the
+// target is not an element.
+func toElement(target interface{}) dom.Element {
+	switch typed := target.(type) {
+	case dom.Element:
+		return typed
+	case js.Value:
+		element, _ := dom.WrapNode(typed).(dom.Element)
+		return element
+	default:
+		return nil
+	}
+}
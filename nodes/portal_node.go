@@ -0,0 +1,132 @@
+package nodes
+
+import (
+	"github.com/minivera/go-lander/dom"
+)
+
+// PortalNode is an implementation of the Node interface which renders its children into another DOM
+// container than its closest DOM parent, such as the body for a modal. Like fragments, portals are only
+// handled in the virtual tree. Their children are diffed, rendered, and given values like any other
+// child of the portal's parent, and the events of their DOM nodes bubble through the portal's parent.
+type PortalNode struct {
+	baseNode
+
+	// Target is the container the children are rendered into, either a CSS selector resolved in the
+	// document when the portal is mounted, or a DOM element.
+	Target interface{}
+
+	// Children is a slice of the children provided to this portal.
+	Children []Node
+
+	// Container is the DOM element the children were mounted into, nil until the portal is mounted or if
+	// the target could not be found.
+	Container dom.Element
+
+	release func()
+}
+
+// NewPortalNode creates a new portal node with the provided information. The target may be a CSS selector,
+// a dom.Element or, in the browser, a js.Value of an element.
+func NewPortalNode(target interface{}, children []Node) *PortalNode {
+	if _, ok := target.(string); !ok {
+		target = toElement(target)
+	}
+
+	return &PortalNode{
+		Target:   target,
+		Children: children,
+	}
+}
+
+// Resolve returns the DOM element of the target in the given document. Returns nil if the selector did not
+// match any element, or if the target is not supported.
+func (n *PortalNode) Resolve(document dom.Document) dom.Element {
+	switch target := n.Target.(type) {
+	case string:
+		return document.QuerySelector(target)
+	case dom.Element:
+		return target
+	default:
+		return nil
+	}
+}
+
+// Mount sets the container the children were mounted into, and the function to call to release the portal
+// once it is removed from the tree.
+func (n *PortalNode) Mount(container dom.Element, release func()) {
+	n.Container = container
+	n.release = release
+}
+
+// Release calls the release function given on mount, if any. The portal is no longer mounted afterwards.
+func (n *PortalNode) Release() {
+	if n.release != nil {
+		n.release()
+	}
+
+	n.Container = nil
+	n.release = nil
+}
+
+// ToString returns an empty string, the children of portals are never rendered on the server since their
+// container is not part of the rendered HTML. They are mounted when the app is hydrated.
+func (n *PortalNode) ToString() string {
+	return ""
+}
+
+// Diff returns true if the other node is not a portal, or if it renders into another target. Portals
+// rendering into another target must be replaced, their children are diffed otherwise.
+func (n *PortalNode) Diff(other Node) bool {
+	otherAsPortal, ok := other.(*PortalNode)
+	if !ok {
+		return true
+	}
+
+	return !sameTarget(n.Target, otherAsPortal.Target)
+}
+
+func (n *PortalNode) Type() NodeType {
+	return PortalNodeType
+}
+
+// InsertChildren inserts a children at the provided position in the portal's children.
+// Returns an error if the children cannot be inserted. Inserts at the end if provided -1
+// as the position.
+func (n *PortalNode) InsertChildren(node Node, position int) error {
+	fragment := FragmentNode{Children: n.Children}
+	err := fragment.InsertChildren(node, position)
+	n.Children = fragment.Children
+
+	return err
+}
+
+// ReplaceChildren replaces the provided node with the new node in the portal's children.
+// Returns an error if the children cannot be replaced.
+func (n *PortalNode) ReplaceChildren(old, new Node) error {
+	fragment := FragmentNode{Children: n.Children}
+	return fragment.ReplaceChildren(old, new)
+}
+
+// RemoveChildren removed the provided children, if found, from the portal's children.
+// Returns an error if the children cannot be removed.
+func (n *PortalNode) RemoveChildren(node Node) error {
+	fragment := FragmentNode{Children: n.Children}
+	err := fragment.RemoveChildren(node)
+	n.Children = fragment.Children
+
+	return err
+}
+
+// sameTarget returns true if both portal targets are the same selector or the same element.
+func sameTarget(target, other interface{}) bool {
+	switch typed := target.(type) {
+	case string:
+		otherString, ok := other.(string)
+		return ok && typed == otherString
+	case dom.Element:
+		otherElement, ok := other.(dom.Element)
+		return ok && typed.IsSameNode(otherElement)
+	default:
+		return target == nil && other == nil
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !(js && wasm)
+
+package nodes
+
+import (
+	"github.com/minivera/go-lander/dom"
+)
+
+// toElement converts a portal target to a DOM element. Returns nil if the target is not an element.
+func toElement(target interface{}) dom.Element {
+	element, _ := target.(dom.Element)
+	return element
+}
@@ -0,0 +1,84 @@
+package nodes
+
+import (
+	"github.com/minivera/go-lander/dom"
+)
+
+// DefaultRawHTMLTag is the tag of the container element of raw HTML nodes, unless another tag is given.
+const DefaultRawHTMLTag = "div"
+
+// Sanitizer is a function that cleans up an HTML string before it is rendered by a raw HTML node, for
+// example by removing scripts and event handler attributes. See RawHTMLNode.
+type Sanitizer func(html string) string
+
+// RawHTMLNode is an implementation of the Node interface which renders an HTML string as is inside a container
+// element, using innerHTML. The content is never escaped, raw HTML nodes must only be given trusted markup, or
+// markup cleaned by a sanitizer, otherwise they open the app to cross-site scripting.
+//
+// The content of the container is owned by the node, it is replaced as a whole when the HTML string changes.
+type RawHTMLNode struct {
+	baseNode
+
+	// DomNode is the real DOM container associated with this virtual node. If set, this node is mounted.
+	DomNode dom.Element
+
+	// Tag is the tag of the container element.
+	Tag string
+
+	// HTML is the HTML string set as the content of the container, after sanitizing.
+	HTML string
+}
+
+// NewRawHTMLNode creates a new raw HTML node with the provided information. The HTML string is passed through
+// the sanitizer, if any, before being stored. An empty tag uses DefaultRawHTMLTag.
+func NewRawHTMLNode(tag, html string, sanitizer Sanitizer) *RawHTMLNode {
+	if tag == "" {
+		tag = DefaultRawHTMLTag
+	}
+	if sanitizer != nil {
+		html = sanitizer(html)
+	}
+
+	return &RawHTMLNode{
+		Tag:  tag,
+		HTML: html,
+	}
+}
+
+// Mount sets the real DOM container on this node, then sets its content.
+func (n *RawHTMLNode) Mount(domNode dom.Element) {
+	n.DomNode = domNode
+	n.DomNode.SetInnerHTML(n.HTML)
+}
+
+// Hydrate sets the real DOM container on this node, without replacing its content. The content was rendered
+// by the server from the same HTML string, the browser may serialize it differently so it is never compared.
+func (n *RawHTMLNode) Hydrate(domNode dom.Element) {
+	n.DomNode = domNode
+}
+
+// Update updates this node with the provided HTML string, then replaces the content of the underlying
+// real DOM container. The HTML string must have been sanitized already.
+func (n *RawHTMLNode) Update(html string) {
+	n.HTML = html
+
+	n.DomNode.SetInnerHTML(n.HTML)
+}
+
+// ToString returns the container element with the HTML string as its content, verbatim.
+func (n *RawHTMLNode) ToString() string {
+	return "<" + n.Tag + ">" + n.HTML + "</" + n.Tag + ">"
+}
+
+func (n *RawHTMLNode) Diff(other Node) bool {
+	otherAsRaw, ok := other.(*RawHTMLNode)
+	if !ok {
+		return true
+	}
+
+	return otherAsRaw.Tag != n.Tag || otherAsRaw.HTML != n.HTML
+}
+
+func (n *RawHTMLNode) Type() NodeType {
+	return RawHTMLNodeType
+}
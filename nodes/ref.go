@@ -0,0 +1,52 @@
+package nodes
+
+import (
+	"github.com/minivera/go-lander/dom"
+)
+
+// RefAttribute is the name of the attribute used to give a ref to an HTML node. See Ref.
+const RefAttribute = "ref"
+
+// Ref holds the DOM element of the HTML node it was given to through the "ref" attribute. Current is set
+// once the node is mounted, before the mount listeners are triggered, kept up to date when the node is
+// replaced, and set back to nil once the node is removed. Use it to access the element imperatively, for
+// example to focus an input or to measure it, `dom.JSValue(ref.Current)` returns its `js.Value`.
+//
+// The same ref should only be given to a single node at a time. Refs are not used when rendering on
+// the server.
+type Ref struct {
+	// Current is the DOM element of the node the ref is given to, or nil if the node is not mounted.
+	Current dom.Element
+}
+
+// attach sets the element as the current element of the ref, if any.
+func (r *Ref) attach(element dom.Element) {
+	if r != nil && element != nil {
+		r.Current = element
+	}
+}
+
+// detach clears the current element of the ref, but only if it still holds the given element. The ref
+// may have been given to another element since, when the node was replaced for example.
+func (r *Ref) detach(element dom.Element) {
+	if r != nil && r.Current != nil && element != nil && r.Current.IsSameNode(element) {
+		r.Current = nil
+	}
+}
+
+// SetRef replaces the ref of this node, the previous ref is cleared. The new ref holds the DOM element of
+// this node, if mounted.
+func (n *HTMLNode) SetRef(ref *Ref) {
+	if n.Ref != ref {
+		n.Ref.detach(n.DomNode)
+	}
+
+	n.Ref = ref
+	ref.attach(n.DomNode)
+}
+
+// ClearRef clears the ref of this node, if it still holds the DOM element of this node. It must be called
+// once the node is removed from the DOM.
+func (n *HTMLNode) ClearRef() {
+	n.Ref.detach(n.DomNode)
+}
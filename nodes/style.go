@@ -0,0 +1,80 @@
+package nodes
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/minivera/go-lander/dom"
+)
+
+// StyleAttribute is the name of the attribute holding the inline styles of an HTML node.
+const StyleAttribute = "style"
+
+// StyleMap is a map of CSS properties to their values, such as `{"width": "10px"}`, used as the value of
+// the "style" attribute. Unlike a style string, each property is diffed and patched on its own with
+// `style.setProperty`, updating a single property never rewrites the others. Property names must use the
+// CSS syntax, such as "background-color" or "--custom-property".
+type StyleMap map[string]string
+
+// String serializes the style map as a style attribute, with the properties sorted by name.
+func (s StyleMap) String() string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	declarations := make([]string, len(names))
+	for i, name := range names {
+		declarations[i] = name + ": " + s[name] + ";"
+	}
+
+	return strings.Join(declarations, " ")
+}
+
+// toStyleMap returns the value as a style map, if it is one.
+func toStyleMap(value interface{}) (StyleMap, bool) {
+	switch casted := value.(type) {
+	case StyleMap:
+		return casted, true
+	case map[string]string:
+		return casted, true
+	default:
+		return nil, false
+	}
+}
+
+// extractStyle returns a copy of the style map given as the "style" attribute, if any, and removes its
+// serialized version from the extracted attributes. The map is copied so the components can reuse it
+// between renders.
+func extractStyle(attributes map[string]interface{}, attrs map[string]string) StyleMap {
+	style, ok := toStyleMap(attributes[StyleAttribute])
+	if !ok {
+		return nil
+	}
+
+	delete(attrs, StyleAttribute)
+
+	copied := make(StyleMap, len(style))
+	for name, value := range style {
+		copied[name] = value
+	}
+
+	return copied
+}
+
+// patchStyle applies the difference between the old and new inline styles on the DOM element, only the
+// changed properties are set or removed.
+func patchStyle(element dom.Element, oldStyle, newStyle StyleMap) {
+	for name := range oldStyle {
+		if _, ok := newStyle[name]; !ok {
+			element.RemoveStyleProperty(name)
+		}
+	}
+
+	for name, value := range newStyle {
+		if oldValue, ok := oldStyle[name]; !ok || oldValue != value {
+			element.SetStyleProperty(name, value)
+		}
+	}
+}
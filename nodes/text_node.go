@@ -1,7 +1,9 @@
 package nodes
 
 import (
-	"syscall/js"
+	"html"
+
+	"github.com/minivera/go-lander/dom"
 )
 
 // TextNode is an implementation of the Node interface which implements the logic to handle
@@ -11,7 +13,7 @@ type TextNode struct {
 
 	// DomNode is the real DOM node associated with this virtual node. If set, this node is
 	// mounted.
-	DomNode js.Value
+	DomNode dom.Text
 
 	// Text is the stored text of this node, is assigned directly as the text of the DomNode.
 	Text string
@@ -29,18 +31,18 @@ func NewTextNode(text string) *TextNode {
 func (n *TextNode) Update(newText string) {
 	n.Text = newText
 
-	n.DomNode.Set("nodeValue", n.Text)
+	n.DomNode.SetNodeValue(n.Text)
 }
 
 // Mount sets the real DOM node on this text node, the applies the text on the underlying real
 // DOM node.
-func (n *TextNode) Mount(domNode js.Value) {
+func (n *TextNode) Mount(domNode dom.Text) {
 	n.DomNode = domNode
-	n.DomNode.Set("nodeValue", n.Text)
+	n.DomNode.SetNodeValue(n.Text)
 }
 
 func (n *TextNode) ToString() string {
-	return n.Text
+	return html.EscapeString(n.Text)
 }
 
 func (n *TextNode) Diff(other Node) bool {
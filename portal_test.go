@@ -0,0 +1,189 @@
+package lander_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/nodes"
+)
+
+// setupModals adds a container for portals next to the app, with an existing node the portals must keep.
+func setupModals(t *testing.T, document *dom.MemoryDocument) *dom.MemoryElement {
+	t.Helper()
+
+	modals := document.CreateElement("div")
+	modals.SetProperty("id", "modals")
+	modals.AppendChild(document.CreateElement("hr"))
+	document.Body().AppendChild(modals)
+
+	return modals.(*dom.MemoryElement)
+}
+
+func TestPortal_Render(t *testing.T) {
+	document, app := setupDocument(t)
+	modals := setupModals(t, document)
+
+	var events []string
+	open := true
+	title := "first"
+	footer := false
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		var portal nodes.Node
+		if open {
+			var footerNode nodes.Node
+			if footer {
+				footerNode = lander.Html("footer", nodes.Attributes{}, nodes.Children{})
+			}
+
+			portal = lander.Portal("#modals", nodes.Children{
+				lander.Component(lifecycleComponent, lifecycleProps{name: "modal", events: &events}, nodes.Children{
+					lander.Component(themed, nodes.Props{}, nodes.Children{}),
+					lander.Text(title),
+				}),
+				footerNode,
+				lander.Html("p", nodes.Attributes{}, nodes.Children{}),
+			})
+		}
+
+		return lander.Html("main", nodes.Attributes{}, nodes.Children{
+			lander.Provider(themeContext, "dark", nodes.Children{
+				lander.Text("before"),
+				portal,
+				lander.Text("after"),
+			}),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	// The children are appended to the container, with the values of their ancestors in the tree
+	assert.Equal(t, `<main>beforeafter</main>`, app.InnerHTML())
+	assert.Equal(t, `<hr></hr><div><span>dark</span>first</div><p></p>`, modals.InnerHTML())
+	assert.Equal(t, []string{"mount modal", "render modal"}, events)
+
+	// The children are diffed like any other children, in the container
+	events = nil
+	title = "second"
+	footer = true
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<main>beforeafter</main>`, app.InnerHTML())
+	assert.Equal(t, `<hr></hr><div><span>dark</span>second</div><footer></footer><p></p>`, modals.InnerHTML())
+	assert.Equal(t, []string{"render modal"}, events)
+
+	// The children are removed from the container when the portal is unmounted
+	events = nil
+	open = false
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<main>beforeafter</main>`, app.InnerHTML())
+	assert.Equal(t, `<hr></hr>`, modals.InnerHTML())
+	assert.Equal(t, []string{"unmount modal"}, events)
+
+	events = nil
+	open = true
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<hr></hr><div><span>dark</span>second</div><footer></footer><p></p>`, modals.InnerHTML())
+	assert.Equal(t, []string{"mount modal", "render modal"}, events)
+}
+
+func TestPortal_Events(t *testing.T) {
+	document, app := setupDocument(t)
+
+	var calls []string
+	record := func(name string) events.EventListenerFunc {
+		return func(*events.DOMEvent) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+
+	open := true
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		var portal nodes.Node
+		if open {
+			// The body is an ancestor of the app, events of the app must not be dispatched twice
+			portal = lander.Portal(document.Body(), nodes.Children{
+				lander.Html("dialog", nodes.Attributes{"click": record("dialog")}, nodes.Children{
+					lander.Html("button", nodes.Attributes{"click": record("dialog button")}, nodes.Children{}),
+				}),
+			})
+		}
+
+		return lander.Html("section", nodes.Attributes{"click": record("section")}, nodes.Children{
+			lander.Html("div", nodes.Attributes{"click": record("host")}, nodes.Children{
+				lander.Html("button", nodes.Attributes{"click": record("app button")}, nodes.Children{}),
+				portal,
+			}),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	// Events of the portal bubble through the ancestors of the portal in the tree
+	dialog := document.Body().QuerySelector("dialog")
+	require.NotNil(t, dialog)
+	dialog.QuerySelector("button").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, []string{"dialog button", "dialog", "host", "section"}, calls)
+
+	calls = nil
+	app.QuerySelector("button").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, []string{"app button", "host", "section"}, calls)
+
+	// Events outside the app are never dispatched
+	calls = nil
+	outside := document.CreateElement("button")
+	document.Body().AppendChild(outside)
+	outside.(*dom.MemoryElement).Dispatch("click")
+	assert.Empty(t, calls)
+
+	// Events of the app are still dispatched once the portal is unmounted
+	open = false
+	require.NoError(t, env.Update())
+	assert.Nil(t, document.Body().QuerySelector("dialog"))
+
+	calls = nil
+	app.QuerySelector("button").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, []string{"app button", "host", "section"}, calls)
+}
+
+func TestPortal_ChangingTarget(t *testing.T) {
+	document, app := setupDocument(t)
+	modals := setupModals(t, document)
+
+	target := "#modals"
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Html("div", nodes.Attributes{}, nodes.Children{
+			lander.Portal(target, nodes.Children{
+				lander.Html("p", nodes.Attributes{}, nodes.Children{lander.Text("content")}),
+			}),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, `<hr></hr><p>content</p>`, modals.InnerHTML())
+
+	// The children move to the new target
+	target = "#app"
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<hr></hr>`, modals.InnerHTML())
+	assert.Equal(t, `<div></div><p>content</p>`, app.InnerHTML())
+
+	// Missing targets render nothing, the portal is mounted once the target exists
+	target = "#missing"
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<div></div>`, app.InnerHTML())
+
+	missing := document.CreateElement("aside")
+	missing.SetProperty("id", "missing")
+	document.Body().AppendChild(missing)
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<aside id="missing"><p>content</p></aside>`, missing.(*dom.MemoryElement).OuterHTML())
+}
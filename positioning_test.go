@@ -0,0 +1,386 @@
+package lander_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/experimental/hooks"
+	"github.com/minivera/go-lander/nodes"
+)
+
+func nothing(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+	return nil
+}
+
+type maybeProps struct {
+	show bool
+}
+
+func maybe(_ context.Context, props maybeProps, _ nodes.Children) nodes.Child {
+	if !props.show {
+		return nil
+	}
+
+	return lander.Html("i", nodes.Attributes{}, nodes.Children{})
+}
+
+// growing renders a fragment with an extra element when show is set.
+func growing(_ context.Context, props maybeProps, _ nodes.Children) nodes.Child {
+	return lander.Fragment(nodes.Children{
+		lander.Text("x"),
+		when(props.show, lander.Html("i", nodes.Attributes{}, nodes.Children{})),
+	})
+}
+
+// keyed returns a keyed element with the key as its tag.
+func keyed(key string) nodes.Node {
+	return lander.Html(key, nodes.Attributes{"key": key}, nodes.Children{})
+}
+
+// when returns the node if show is set, nil otherwise.
+func when(show bool, node nodes.Node) nodes.Node {
+	if !show {
+		return nil
+	}
+
+	return node
+}
+
+func TestDomEnvironment_Positioning(t *testing.T) {
+	tcs := []struct {
+		scenario string
+		children func(show bool) nodes.Children
+		shown    string
+		hidden   string
+	}{
+		{
+			scenario: "element between text nodes",
+			children: func(show bool) nodes.Children {
+				return nodes.Children{
+					lander.Text("a"),
+					when(show, lander.Html("i", nodes.Attributes{}, nodes.Children{})),
+					lander.Text("b"),
+				}
+			},
+			shown:  `a<i></i>b<b></b>`,
+			hidden: `ab<b></b>`,
+		},
+		{
+			scenario: "element before text and element",
+			children: func(show bool) nodes.Children {
+				return nodes.Children{
+					when(show, lander.Html("i", nodes.Attributes{}, nodes.Children{})),
+					lander.Text("a"),
+				}
+			},
+			shown:  `<i></i>a<b></b>`,
+			hidden: `a<b></b>`,
+		},
+		{
+			scenario: "text between elements",
+			children: func(show bool) nodes.Children {
+				return nodes.Children{
+					lander.Html("s", nodes.Attributes{}, nodes.Children{}),
+					when(show, lander.Text("x")),
+					lander.Text("y"),
+				}
+			},
+			shown:  `<s></s>xy<b></b>`,
+			hidden: `<s></s>y<b></b>`,
+		},
+		{
+			scenario: "element at the end of a fragment followed by text",
+			children: func(show bool) nodes.Children {
+				return nodes.Children{
+					lander.Fragment(nodes.Children{
+						lander.Text("a"),
+						when(show, lander.Html("i", nodes.Attributes{}, nodes.Children{})),
+					}),
+					lander.Text("b"),
+				}
+			},
+			shown:  `a<i></i>b<b></b>`,
+			hidden: `ab<b></b>`,
+		},
+		{
+			scenario: "element appended to a fragment followed by text",
+			children: func(show bool) nodes.Children {
+				children := nodes.Children{lander.Text("a")}
+				if show {
+					children = append(children, lander.Html("i", nodes.Attributes{}, nodes.Children{}))
+				}
+
+				return nodes.Children{
+					lander.Fragment(children),
+					lander.Text("b"),
+				}
+			},
+			shown:  `a<i></i>b<b></b>`,
+			hidden: `ab<b></b>`,
+		},
+		{
+			scenario: "element after an empty fragment",
+			children: func(show bool) nodes.Children {
+				return nodes.Children{
+					lander.Text("a"),
+					lander.Fragment(nodes.Children{}),
+					when(show, lander.Html("i", nodes.Attributes{}, nodes.Children{})),
+					lander.Text("b"),
+				}
+			},
+			shown:  `a<i></i>b<b></b>`,
+			hidden: `ab<b></b>`,
+		},
+		{
+			scenario: "element next to a component rendering nil",
+			children: func(show bool) nodes.Children {
+				return nodes.Children{
+					lander.Component(nothing, nodes.Props{}, nodes.Children{}),
+					when(show, lander.Html("i", nodes.Attributes{}, nodes.Children{})),
+					lander.Component(nothing, nodes.Props{}, nodes.Children{}),
+					lander.Text("b"),
+				}
+			},
+			shown:  `<i></i>b<b></b>`,
+			hidden: `b<b></b>`,
+		},
+		{
+			scenario: "component toggling between nil and an element",
+			children: func(show bool) nodes.Children {
+				return nodes.Children{
+					lander.Text("a"),
+					lander.Component(maybe, maybeProps{show: show}, nodes.Children{}),
+					lander.Text("b"),
+				}
+			},
+			shown:  `a<i></i>b<b></b>`,
+			hidden: `ab<b></b>`,
+		},
+		{
+			scenario: "nested fragments with nil children",
+			children: func(show bool) nodes.Children {
+				return nodes.Children{
+					lander.Fragment(nodes.Children{
+						nil,
+						lander.Fragment(nodes.Children{
+							when(show, lander.Text("x")),
+							nil,
+						}),
+						lander.Component(nothing, nodes.Props{}, nodes.Children{}),
+					}),
+					lander.Text("b"),
+				}
+			},
+			shown:  `xb<b></b>`,
+			hidden: `b<b></b>`,
+		},
+		{
+			scenario: "text replaced by an element",
+			children: func(show bool) nodes.Children {
+				var child nodes.Node = lander.Text("t")
+				if show {
+					child = lander.Html("i", nodes.Attributes{}, nodes.Children{})
+				}
+
+				return nodes.Children{lander.Text("a"), child, lander.Text("b")}
+			},
+			shown:  `a<i></i>b<b></b>`,
+			hidden: `atb<b></b>`,
+		},
+		{
+			scenario: "fragment replaced by an element",
+			children: func(show bool) nodes.Children {
+				var child nodes.Node = lander.Html("s", nodes.Attributes{}, nodes.Children{})
+				if show {
+					child = lander.Fragment(nodes.Children{
+						lander.Html("i", nodes.Attributes{}, nodes.Children{}),
+						lander.Text("x"),
+					})
+				}
+
+				return nodes.Children{lander.Text("a"), child, lander.Text("b")}
+			},
+			shown:  `a<i></i>xb<b></b>`,
+			hidden: `a<s></s>b<b></b>`,
+		},
+		{
+			scenario: "empty fragment replaced by an element",
+			children: func(show bool) nodes.Children {
+				var child nodes.Node = lander.Fragment(nodes.Children{})
+				if show {
+					child = lander.Html("i", nodes.Attributes{}, nodes.Children{})
+				}
+
+				return nodes.Children{lander.Text("a"), child, lander.Text("b")}
+			},
+			shown:  `a<i></i>b<b></b>`,
+			hidden: `ab<b></b>`,
+		},
+		{
+			scenario: "keyed component toggling between nil and an element",
+			children: func(show bool) nodes.Children {
+				return nodes.Children{
+					keyed("s"),
+					lander.Component(maybe, maybeProps{show: show}, nodes.Children{}, lander.WithKey("maybe")),
+					keyed("u"),
+				}
+			},
+			shown:  `<s></s><i></i><u></u><b></b>`,
+			hidden: `<s></s><u></u><b></b>`,
+		},
+		{
+			scenario: "keyed fragment growing",
+			children: func(show bool) nodes.Children {
+				return nodes.Children{
+					keyed("s"),
+					lander.Component(growing, maybeProps{show: show}, nodes.Children{}, lander.WithKey("growing")),
+					keyed("u"),
+				}
+			},
+			shown:  `<s></s>x<i></i><u></u><b></b>`,
+			hidden: `<s></s>x<u></u><b></b>`,
+		},
+		{
+			scenario: "keyed fragment growing while its siblings move",
+			children: func(show bool) nodes.Children {
+				children := nodes.Children{
+					keyed("s"),
+					lander.Component(growing, maybeProps{show: show}, nodes.Children{}, lander.WithKey("growing")),
+					keyed("u"),
+				}
+				if show {
+					children[0], children[2] = children[2], children[0]
+				}
+
+				return children
+			},
+			shown:  `<u></u>x<i></i><s></s><b></b>`,
+			hidden: `<s></s>x<u></u><b></b>`,
+		},
+		{
+			scenario: "keyed component swapped for another component",
+			children: func(show bool) nodes.Children {
+				component := lander.Component(nothing, nodes.Props{}, nodes.Children{}, lander.WithKey("swapped"))
+				if show {
+					component = lander.Component(maybe, maybeProps{show: true}, nodes.Children{}, lander.WithKey("swapped"))
+				}
+
+				return nodes.Children{keyed("s"), component, keyed("u")}
+			},
+			shown:  `<s></s><i></i><u></u><b></b>`,
+			hidden: `<s></s><u></u><b></b>`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.scenario, func(t *testing.T) {
+			for _, initial := range []bool{false, true} {
+				document, app := setupDocument(t)
+
+				show := initial
+				root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+					// The last element is kept through all renders, it must never be moved or mounted again
+					children := append(tc.children(show), lander.Html("b", nodes.Attributes{}, nodes.Children{}))
+					return lander.Html("div", nodes.Attributes{}, children)
+				}
+
+				env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+				require.NoError(t, err)
+
+				last := document.QuerySelector("b")
+				for _, next := range []bool{!initial, initial, !initial} {
+					show = next
+					require.NoError(t, env.Update())
+
+					expected := tc.hidden
+					if show {
+						expected = tc.shown
+					}
+					assert.Equal(t, "<div>"+expected+"</div>", app.InnerHTML())
+					assert.True(t, document.QuerySelector("b").IsSameNode(last))
+				}
+			}
+		})
+	}
+}
+
+type toggleProps struct {
+	id string
+}
+
+func toggle(ctx context.Context, props toggleProps, _ nodes.Children) nodes.Child {
+	show, setShow, _ := hooks.UseState[bool](ctx, false)
+
+	button := lander.Html("button", nodes.Attributes{
+		"id": props.id,
+		"click": func(*events.DOMEvent) error {
+			return setShow(func(value bool) bool {
+				return !value
+			})
+		},
+	}, nodes.Children{})
+
+	return lander.Fragment(nodes.Children{
+		button,
+		when(show, lander.Text("on")),
+		when(show, lander.Html("i", nodes.Attributes{}, nodes.Children{})),
+	})
+}
+
+func TestDomEnvironment_PositioningUpdateComponent(t *testing.T) {
+	document, app := setupDocument(t)
+
+	var show func(bool) error
+	hidden := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		visible, setVisible, _ := hooks.UseState[bool](ctx, false)
+		show = func(value bool) error {
+			return setVisible(func(bool) bool {
+				return value
+			})
+		}
+
+		if !visible {
+			return nil
+		}
+
+		return lander.Text("shown")
+	}
+
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Html("div", nodes.Attributes{}, nodes.Children{
+			lander.Text("a"),
+			lander.Component(toggle, toggleProps{id: "first"}, nodes.Children{}),
+			lander.Component(hidden, nodes.Props{}, nodes.Children{}),
+			lander.Text("b"),
+			lander.Component(toggle, toggleProps{id: "second"}, nodes.Children{}),
+		})
+	}
+
+	_, err := lander.RenderIntoDocument(document, lander.Component(hooks.Provider, nodes.Props{}, nodes.Children{
+		lander.Component(root, nodes.Props{}, nodes.Children{}),
+	}), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, `<div>a<button id="first"></button>b<button id="second"></button></div>`, app.InnerHTML())
+
+	first := document.QuerySelector("#first").(*dom.MemoryElement)
+	first.Dispatch("click")
+	assert.Equal(t, `<div>a<button id="first"></button>on<i></i>b<button id="second"></button></div>`, app.InnerHTML())
+
+	// A component rendering nil is positioned against its siblings when rendered on its own
+	require.NoError(t, show(true))
+	assert.Equal(t, `<div>a<button id="first"></button>on<i></i>shownb<button id="second"></button></div>`, app.InnerHTML())
+
+	document.QuerySelector("#second").(*dom.MemoryElement).Dispatch("click")
+	first.Dispatch("click")
+	assert.Equal(t, `<div>a<button id="first"></button>shownb<button id="second"></button>on<i></i></div>`, app.InnerHTML())
+
+	require.NoError(t, show(false))
+	assert.Equal(t, `<div>a<button id="first"></button>b<button id="second"></button>on<i></i></div>`, app.InnerHTML())
+	assert.True(t, document.QuerySelector("#first").IsSameNode(first))
+}
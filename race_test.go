@@ -0,0 +1,138 @@
+package lander_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/nodes"
+)
+
+func TestDomEnvironment_ConcurrentUpdates(t *testing.T) {
+	document, app := setupDocument(t)
+
+	count := 0
+	render := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Html("p", nodes.Attributes{}, nodes.Children{
+			lander.Text(fmt.Sprintf("%d", count)),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(render, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				assert.NoError(t, env.UpdateWith(func() {
+					count++
+				}))
+				assert.NoError(t, env.Update())
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.NoError(t, <-env.UpdateAsync())
+	assert.Equal(t, `<p>500</p>`, app.InnerHTML())
+}
+
+func TestDomEnvironment_UpdateAsync(t *testing.T) {
+	document, app := setupDocument(t)
+
+	count := 0
+	render := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Text(fmt.Sprintf("%d", count))
+	}
+
+	env, err := lander.RenderIntoDocument(
+		document,
+		lander.Component(render, nodes.Props{}, nodes.Children{}),
+		"#app",
+		lander.WithScheduler(lander.ManualScheduler),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, env.UpdateWith(func() {
+		count = 1
+	}))
+	first := env.UpdateAsync()
+	second := env.UpdateAsync()
+
+	select {
+	case <-first:
+		t.Fatal("the update resolved before being flushed")
+	default:
+	}
+
+	go func() {
+		assert.NoError(t, env.Flush())
+	}()
+
+	// Both waiting updates are resolved by the same render
+	for _, result := range []<-chan error{first, second} {
+		select {
+		case err := <-result:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("the update was never resolved")
+		}
+	}
+	assert.Equal(t, "1", app.InnerHTML())
+	assert.Equal(t, 2, env.RenderCount())
+
+	// Render errors are sent to the waiting updates
+	document.Body().RemoveChild(app)
+	result := env.UpdateAsync()
+	assert.Error(t, env.Flush())
+	assert.Error(t, <-result)
+}
+
+func TestDomEnvironment_ConcurrentListeners(t *testing.T) {
+	document, app := setupDocument(t)
+
+	renders := 0
+	mounted := make(chan bool, 1)
+	var env *lander.DomEnvironment
+	render := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		renders++
+		ctx.OnMount(func() error {
+			// Lifecycle listeners run in the render loop, they can read the state without racing
+			renders++
+			mounted <- true
+			return nil
+		})
+
+		return lander.Text(fmt.Sprintf("%d", renders))
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(render, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, <-env.UpdateAsync())
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case <-mounted:
+	case <-time.After(time.Second):
+		t.Fatal("the mount listener was never triggered")
+	}
+	require.NoError(t, <-env.UpdateAsync())
+	assert.Equal(t, fmt.Sprintf("%d", renders), app.InnerHTML())
+}
@@ -0,0 +1,107 @@
+package lander_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/nodes"
+)
+
+func TestRawHTML_Render(t *testing.T) {
+	document, app := setupDocument(t)
+
+	content := "<p>first</p>"
+	tag := ""
+	show := true
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		var raw nodes.Node
+		if show {
+			var options []lander.RawHTMLOption
+			if tag != "" {
+				options = append(options, lander.WithContainerTag(tag))
+			}
+			raw = lander.RawHTML(content, options...)
+		}
+
+		return lander.Html("main", nodes.Attributes{}, nodes.Children{
+			lander.Text("before"),
+			raw,
+			lander.Html("hr", nodes.Attributes{}, nodes.Children{}),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, `<main>before<div><p>first</p></div><hr></hr></main>`, app.InnerHTML())
+
+	// The content is replaced as a whole when the string changes
+	content = "<p>second</p><script>alert()</script>"
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<main>before<div><p>second</p><script>alert()</script></div><hr></hr></main>`, app.InnerHTML())
+
+	// The container is replaced when its tag changes
+	tag = "article"
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<main>before<article><p>second</p><script>alert()</script></article><hr></hr></main>`, app.InnerHTML())
+
+	show = false
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<main>before<hr></hr></main>`, app.InnerHTML())
+
+	show = true
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<main>before<article><p>second</p><script>alert()</script></article><hr></hr></main>`, app.InnerHTML())
+}
+
+func TestRawHTML_Sanitizer(t *testing.T) {
+	stripScripts := func(html string) string {
+		return strings.ReplaceAll(strings.ReplaceAll(html, "<script>", ""), "</script>", "")
+	}
+
+	node := lander.RawHTML("<p>text</p><script>alert()</script>", lander.WithSanitizer(stripScripts))
+	assert.Equal(t, "<p>text</p>alert()", node.HTML)
+	assert.Equal(t, "<div><p>text</p>alert()</div>", node.ToString())
+}
+
+func TestRawHTML_RenderToString(t *testing.T) {
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Html("main", nodes.Attributes{}, nodes.Children{
+			lander.RawHTML(`<h1 class="title">Title &amp; <em>more</em></h1>`, lander.WithContainerTag("section")),
+		})
+	}
+
+	html, err := lander.RenderToString(lander.Component(root, nodes.Props{}, nodes.Children{}))
+	require.NoError(t, err)
+	assert.Contains(t, html, `<main><section><h1 class="title">Title &amp; <em>more</em></h1></section></main>`)
+}
+
+func TestRawHTML_Hydrate(t *testing.T) {
+	document, app := setupDocument(t)
+
+	server := element(document, "div", nil, element(document, "p", nil, document.CreateTextNode("server")))
+	app.AppendChild(element(document, "main", nil, server))
+
+	content := "<p>server</p>"
+	root := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Html("main", nodes.Attributes{}, nodes.Children{
+			lander.RawHTML(content),
+		})
+	}
+
+	env, err := lander.HydrateIntoDocument(document, lander.Component(root, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+
+	// The container and the content rendered by the server are kept
+	assert.Equal(t, `<main><div><p>server</p></div></main>`, app.InnerHTML())
+	assert.Same(t, server, app.Children()[0].Children()[0])
+
+	content = "<p>client</p>"
+	require.NoError(t, env.Update())
+	assert.Equal(t, `<main><div><p>client</p></div></main>`, app.InnerHTML())
+	assert.Same(t, server, app.Children()[0].Children()[0])
+}
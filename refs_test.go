@@ -0,0 +1,60 @@
+package lander_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/nodes"
+)
+
+func TestRefs_ChangingRefs(t *testing.T) {
+	document, app := setupDocument(t)
+
+	first := &nodes.Ref{}
+	second := &nodes.Ref{}
+	current := first
+	title := "title"
+	var mounted nodes.Ref
+	env, err := lander.RenderIntoDocument(document, lander.Component(
+		func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			ctx.OnMount(func() error {
+				mounted = *current
+				return nil
+			})
+
+			return lander.Html("p", nodes.Attributes{"ref": current, "title": title}, nodes.Children{})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+
+	paragraph := app.QuerySelector("p")
+	require.NotNil(t, mounted.Current)
+	assert.True(t, mounted.Current.IsSameNode(paragraph))
+
+	// Refs are replaced when the node did not change
+	require.NoError(t, env.UpdateWith(func() {
+		current = second
+	}))
+	assert.Nil(t, first.Current)
+	require.NotNil(t, second.Current)
+	assert.True(t, second.Current.IsSameNode(paragraph))
+
+	// And when it was patched
+	require.NoError(t, env.UpdateWith(func() {
+		current = first
+		title = "changed"
+	}))
+	assert.Nil(t, second.Current)
+	require.NotNil(t, first.Current)
+	assert.True(t, first.Current.IsSameNode(paragraph))
+
+	// Refs never reach the DOM
+	_, ok := paragraph.GetAttribute("ref")
+	assert.False(t, ok)
+}
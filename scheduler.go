@@ -0,0 +1,37 @@
+package lander
+
+// Scheduler decides when the renders requested through Update are flushed. Update never renders by itself,
+// it marks the environment as needing a render and asks the scheduler to call flush at a later time. All the
+// updates requested before flush is called are coalesced into a single render.
+type Scheduler interface {
+	Schedule(flush func())
+}
+
+// SchedulerFunc is an adapter to allow the use of ordinary functions as a Scheduler.
+type SchedulerFunc func(flush func())
+
+// Schedule calls f(flush).
+func (f SchedulerFunc) Schedule(flush func()) {
+	f(flush)
+}
+
+// ImmediateScheduler flushes as soon as a render is requested. Updates requested while an event listener
+// is running, or while the tree is being rendered, are still coalesced and flushed once the listener or
+// render is done. This is the default scheduler of RenderIntoDocument and HydrateIntoDocument.
+var ImmediateScheduler Scheduler = SchedulerFunc(func(flush func()) {
+	flush()
+})
+
+// ManualScheduler never flushes on its own, renders only happen when Flush is called on the environment.
+// This is mostly useful in tests, to control exactly when the tree is rendered.
+var ManualScheduler Scheduler = SchedulerFunc(func(func()) {})
+
+// EnvironmentOption configures a DOM environment when it is created.
+type EnvironmentOption func(env *DomEnvironment)
+
+// WithScheduler sets the scheduler used by the environment to flush the requested updates.
+func WithScheduler(scheduler Scheduler) EnvironmentOption {
+	return func(env *DomEnvironment) {
+		env.scheduler = scheduler
+	}
+}
@@ -0,0 +1,20 @@
+//go:build js && wasm
+
+package lander
+
+import (
+	"syscall/js"
+)
+
+// AnimationFrameScheduler flushes the requested updates on the next animation frame, using the browser's
+// requestAnimationFrame. This is the default scheduler of RenderInto and HydrateInto.
+var AnimationFrameScheduler Scheduler = SchedulerFunc(func(flush func()) {
+	var callback js.Func
+	callback = js.FuncOf(func(js.Value, []js.Value) interface{} {
+		callback.Release()
+		flush()
+		return nil
+	})
+
+	js.Global().Call("requestAnimationFrame", callback)
+})
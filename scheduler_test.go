@@ -0,0 +1,137 @@
+package lander_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/nodes"
+)
+
+func TestDomEnvironment_UpdateBatchedInListener(t *testing.T) {
+	document, app := setupDocument(t)
+
+	count := 0
+	renders := 0
+	var env *lander.DomEnvironment
+	render := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		renders++
+		return lander.Html("button", nodes.Attributes{
+			"click": func(*events.DOMEvent) error {
+				for i := 0; i < 3; i++ {
+					count++
+					err := env.Update()
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}, nodes.Children{
+			lander.Text(fmt.Sprintf("%d", count)),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(render, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	assert.Equal(t, 1, env.RenderCount())
+
+	app.QuerySelector("button").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, `<button>3</button>`, app.InnerHTML())
+	assert.Equal(t, 2, env.RenderCount())
+	assert.Equal(t, 2, renders)
+}
+
+func TestDomEnvironment_Flush(t *testing.T) {
+	document, app := setupDocument(t)
+
+	counter := &counterApp{}
+	env, err := lander.RenderIntoDocument(
+		document,
+		lander.Component(counter.render, nodes.Props{}, nodes.Children{}),
+		"#app",
+		lander.WithScheduler(lander.ManualScheduler),
+	)
+	require.NoError(t, err)
+	counter.env = env
+
+	plus := app.QuerySelector("button").(*dom.MemoryElement)
+	plus.Dispatch("click")
+	plus.Dispatch("click")
+
+	// Nothing is rendered until flushed
+	assert.Equal(t, 1, env.RenderCount())
+	assert.Equal(t, "Counter is at: 0", app.QuerySelector("span").(*dom.MemoryElement).InnerHTML())
+
+	require.NoError(t, env.Flush())
+	assert.Equal(t, 2, env.RenderCount())
+	assert.Equal(t, "Counter is at: 2", app.QuerySelector("span").(*dom.MemoryElement).InnerHTML())
+
+	// Flushing without any pending update does not render
+	require.NoError(t, env.Flush())
+	assert.Equal(t, 2, env.RenderCount())
+}
+
+func TestDomEnvironment_UpdateScheduledOnce(t *testing.T) {
+	document, app := setupDocument(t)
+
+	var flushes []func()
+	scheduler := lander.SchedulerFunc(func(flush func()) {
+		flushes = append(flushes, flush)
+	})
+
+	counter := &counterApp{}
+	env, err := lander.RenderIntoDocument(
+		document,
+		lander.Component(counter.render, nodes.Props{}, nodes.Children{}),
+		"#app",
+		lander.WithScheduler(scheduler),
+	)
+	require.NoError(t, err)
+	counter.env = env
+
+	for i := 0; i < 3; i++ {
+		counter.count++
+		require.NoError(t, env.Update())
+	}
+	require.Len(t, flushes, 1)
+
+	flushes[0]()
+	assert.Equal(t, 2, env.RenderCount())
+	assert.Equal(t, "Counter is at: 3", app.QuerySelector("span").(*dom.MemoryElement).InnerHTML())
+
+	// Once flushed, the next update is scheduled again
+	require.NoError(t, env.Update())
+	assert.Len(t, flushes, 2)
+}
+
+func TestDomEnvironment_ReportError(t *testing.T) {
+	document, _ := setupDocument(t)
+
+	failure := errors.New("callback failed")
+	var reported []error
+	var captured context.Context
+	_, err := lander.RenderIntoDocument(document, lander.Component(
+		func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			captured = ctx
+			return lander.Html("div", nodes.Attributes{}, nodes.Children{})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app", lander.WithErrorHandler(func(err error) {
+		reported = append(reported, err)
+	}))
+	require.NoError(t, err)
+
+	// Callbacks outside the render loop send their errors to the handler of the environment
+	captured.ReportError(failure)
+	require.Len(t, reported, 1)
+	assert.ErrorIs(t, reported[0], failure)
+}
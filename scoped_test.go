@@ -0,0 +1,124 @@
+package lander_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/experimental/hooks"
+	"github.com/minivera/go-lander/nodes"
+)
+
+var themeContext = context.CreateContext[string]("default")
+
+func themed(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+	return lander.Html("span", nodes.Attributes{}, nodes.Children{
+		lander.Text(themeContext.Use(ctx)),
+	})
+}
+
+func TestProvider(t *testing.T) {
+	document, app := setupDocument(t)
+
+	outer := "dark"
+	extra := false
+	render := func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		inner := nodes.Children{
+			lander.Component(themed, nodes.Props{}, nodes.Children{}),
+		}
+		if extra {
+			inner = append(inner, lander.Component(themed, nodes.Props{}, nodes.Children{}))
+		}
+
+		return lander.Html("div", nodes.Attributes{}, nodes.Children{
+			lander.Component(themed, nodes.Props{}, nodes.Children{}),
+			lander.Provider(themeContext, outer, nodes.Children{
+				lander.Component(themed, nodes.Props{}, nodes.Children{}),
+				lander.Provider(themeContext, "light", nodes.Children{
+					lander.Html("p", nodes.Attributes{}, inner),
+				}),
+				lander.Component(themed, nodes.Props{}, nodes.Children{}),
+			}),
+			lander.Component(themed, nodes.Props{}, nodes.Children{}),
+		})
+	}
+
+	env, err := lander.RenderIntoDocument(document, lander.Component(render, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`<div><span>default</span><span>dark</span><p><span>light</span></p><span>dark</span><span>default</span></div>`,
+		app.InnerHTML(),
+	)
+
+	// Inserted components get the value of their nearest provider
+	outer = "blue"
+	extra = true
+	require.NoError(t, env.Update())
+	assert.Equal(
+		t,
+		`<div><span>default</span><span>blue</span><p><span>light</span><span>light</span></p>`+
+			`<span>blue</span><span>default</span></div>`,
+		app.InnerHTML(),
+	)
+}
+
+type themedCounterProps struct {
+	renders *int
+}
+
+func themedCounter(ctx context.Context, props themedCounterProps, _ nodes.Children) nodes.Child {
+	*props.renders++
+	count, setCount, _ := hooks.UseState[int](ctx, 0)
+
+	return lander.Html("button", nodes.Attributes{
+		"click": func(*events.DOMEvent) error {
+			return setCount(func(value int) int {
+				return value + 1
+			})
+		},
+	}, nodes.Children{
+		lander.Text(fmt.Sprintf("%s %d", themeContext.Use(ctx), count)),
+	})
+}
+
+func TestProvider_UpdateComponent(t *testing.T) {
+	document, app := setupDocument(t)
+
+	renders := 0
+	env, err := lander.RenderIntoDocument(document, lander.Component(hooks.Provider, nodes.Props{}, nodes.Children{
+		lander.Provider(themeContext, "dark", nodes.Children{
+			lander.Component(themedCounter, themedCounterProps{renders: &renders}, nodes.Children{}),
+		}),
+	}), "#app")
+	require.NoError(t, err)
+
+	// The component is rendered on its own, with the value of its ancestor
+	app.QuerySelector("button").(*dom.MemoryElement).Dispatch("click")
+	assert.Equal(t, `<button>dark 1</button>`, app.InnerHTML())
+	assert.Equal(t, 2, renders)
+	assert.Equal(t, 2, env.RenderCount())
+}
+
+func TestProvider_RenderToString(t *testing.T) {
+	result, err := lander.RenderToString(lander.Component(
+		func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			return lander.Fragment(nodes.Children{
+				lander.Provider(themeContext, "dark", nodes.Children{
+					lander.Component(themed, nodes.Props{}, nodes.Children{}),
+				}),
+				lander.Component(themed, nodes.Props{}, nodes.Children{}),
+			})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, `<style id="lander-style-tag"></style><span>dark</span><span>default</span>`, result)
+}
@@ -0,0 +1,64 @@
+package lander
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/diffing"
+	"github.com/minivera/go-lander/internal"
+	"github.com/minivera/go-lander/nodes"
+)
+
+// RenderToString renders the provided root component node to an HTML string, which can be sent by a
+// server as the first paint of the app. Components are rendered under a throwaway context, calling Update
+// from that context will return an error and lifecycle listeners are never triggered. Text and attribute
+// values are escaped.
+//
+// The styles collected from the HTML nodes are emitted in a `<style id="lander-style-tag">` tag, placed
+// before the app's HTML. The whole string is expected to be inserted in the app's root DOM node.
+func RenderToString(rootNode *nodes.FuncNode) (string, error) {
+	var styles []string
+	err := context.WithNewContext(stringUpdater{}, nil, func() error {
+		styles = diffing.RecursivelyRender(rootNode)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	stylesString, err := minifyStyles(styles)
+	if err != nil {
+		return "", err
+	}
+
+	// The CSS is minified, but make sure it can never close the style tag early
+	stylesString = strings.ReplaceAll(stylesString, "</", "<\\/")
+
+	return fmt.Sprintf(
+		"<style id=\"%s\">%s</style>%s",
+		styleTagID,
+		stylesString,
+		rootNode.ToString(),
+	), nil
+}
+
+// stringUpdater is the updater of the throwaway context used by RenderToString, a tree rendered to a string
+// can never be updated.
+type stringUpdater struct{}
+
+func (stringUpdater) Update() error {
+	return fmt.Errorf("cannot update a tree rendered to a string")
+}
+
+func (stringUpdater) UpdateWith(func()) error {
+	return fmt.Errorf("cannot update a tree rendered to a string")
+}
+
+func (stringUpdater) UpdateComponent(interface{}, func()) error {
+	return fmt.Errorf("cannot update a tree rendered to a string")
+}
+
+func (stringUpdater) ReportError(err error) {
+	internal.Debugf("unhandled error: %s\n", err)
+}
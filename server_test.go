@@ -0,0 +1,107 @@
+package lander_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/nodes"
+)
+
+type greetingProps struct {
+	name string
+}
+
+func greeting(_ context.Context, props greetingProps, children nodes.Children) nodes.Child {
+	return lander.Html("p", nodes.Attributes{"title": props.name}, nodes.Children{
+		lander.Text("Hello, " + props.name),
+		lander.Fragment(children),
+	})
+}
+
+func TestRenderToString(t *testing.T) {
+	app := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Html("form", nodes.Attributes{"id": "login", "class": "form wide"}, nodes.Children{
+			lander.Component(greeting, greetingProps{name: `<b>"Tom" & Jerry</b>`}, nodes.Children{
+				lander.Html("br", nodes.Attributes{}, nodes.Children{}),
+				nil,
+			}),
+			lander.Html("input", nodes.Attributes{
+				"type":      "text",
+				"required":  true,
+				"disabled":  false,
+				"maxlength": 10,
+			}, nodes.Children{}),
+			lander.Html("style", nodes.Attributes{}, nodes.Children{
+				lander.Text("form > p { color: red; }"),
+			}),
+		})
+	}
+
+	result, err := lander.RenderToString(lander.Component(app, nodes.Props{}, nodes.Children{}))
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		`<style id="lander-style-tag"></style>`+
+			`<form class="form wide" id="login">`+
+			`<p title="&lt;b&gt;&#34;Tom&#34; &amp; Jerry&lt;/b&gt;">Hello, &lt;b&gt;&#34;Tom&#34; &amp; Jerry&lt;/b&gt;<br></p>`+
+			`<input maxlength="10" required type="text">`+
+			`<style>form > p { color: red; }</style>`+
+			`</form>`,
+		result,
+	)
+}
+
+func TestRenderToString_Styles(t *testing.T) {
+	app := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		node := lander.Html("div", nodes.Attributes{}, nodes.Children{
+			lander.Text("styled"),
+		}).Style("color: red;")
+
+		return node
+	}
+
+	root := lander.Component(app, nodes.Props{}, nodes.Children{})
+	result, err := lander.RenderToString(root)
+	require.NoError(t, err)
+
+	className := root.RenderResult.(*nodes.HTMLNode).ActiveClass
+	assert.Equal(
+		t,
+		`<style id="lander-style-tag">.`+className+`{color:red}</style><div class="`+className+`">styled</div>`,
+		result,
+	)
+}
+
+func TestRenderToString_Update(t *testing.T) {
+	var updateErr error
+	app := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		updateErr = ctx.Update()
+		return nil
+	}
+
+	result, err := lander.RenderToString(lander.Component(app, nodes.Props{}, nodes.Children{}))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(result, `<style id="lander-style-tag">`))
+	assert.Error(t, updateErr)
+}
+
+func TestRenderToString_InlineStyle(t *testing.T) {
+	app := func(ctx context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+		return lander.Html("div", nodes.Attributes{
+			"style": nodes.StyleMap{"width": "50%", "background-color": `url("a.png")`},
+		}, nodes.Children{})
+	}
+
+	result, err := lander.RenderToString(lander.Component(app, nodes.Props{}, nodes.Children{}))
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(
+		result,
+		`<div style="background-color: url(&#34;a.png&#34;); width: 50%;"></div>`,
+	), result)
+}
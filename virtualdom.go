@@ -1,28 +1,24 @@
-//go:build js && wasm
-
 package lander
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
-	"syscall/js"
 
 	"github.com/tdewolff/minify/v2"
 	"github.com/tdewolff/minify/v2/css"
 
 	"github.com/minivera/go-lander/context"
 	"github.com/minivera/go-lander/diffing"
+	"github.com/minivera/go-lander/dom"
 	"github.com/minivera/go-lander/events"
 	"github.com/minivera/go-lander/internal"
 	"github.com/minivera/go-lander/nodes"
 )
 
-var document js.Value
-
-func init() {
-	document = js.Global().Get("document")
-}
+// styleTagID is the ID of the style tag lander generates in the head for the styles of HTML nodes.
+const styleTagID = "lander-style-tag"
 
 // DomEnvironment is the lander DOM environment that stores the necessary information to allow mounting
 // and rendering a lander app. Keep this environment in the main method or in global memory to avoid any
@@ -30,104 +26,512 @@ func init() {
 type DomEnvironment struct {
 	sync.RWMutex
 
-	root string
+	document dom.Document
+	root     string
 
 	tree *nodes.FuncNode
 
 	prevContext context.Context
+
+	// delegator dispatches the DOM events of the app from the root element, it is created on the first mount
+	delegator *events.Delegator
+
+	// updateLock protects the render queue below, which is accessed without holding the environment lock
+	updateLock  sync.Mutex
+	scheduler   Scheduler
+	pending     bool
+	scheduled   bool
+	batching    int
+	renderCount int
+	mutations   []func()
+	waiting     []chan error
+	renderAll   bool
+	components  map[*nodes.FuncNode]bool
+
+	// onError receives the errors that have no caller to be returned to, see WithErrorHandler
+	onError func(err error)
 }
 
-// RenderInto renders the provided root component node into the given DOM root. The root selector must
-// lead to a valid node, otherwise the mounting will error. The tree is only mounted in this method,
-// no diffing will happen. Returns the mounted DOM environment, which can be used to trigger updates.
+func newEnvironment(document dom.Document, rootNode *nodes.FuncNode, root string,
+	options []EnvironmentOption) *DomEnvironment {
+	env := &DomEnvironment{
+		document:  document,
+		root:      root,
+		tree:      rootNode,
+		scheduler: ImmediateScheduler,
+	}
+
+	for _, option := range options {
+		option(env)
+	}
+
+	return env
+}
+
+// WithErrorHandler sets the function receiving the errors the environment cannot return to a caller, the
+// errors of the renders flushed by the scheduler after the update was requested, and the errors returned by
+// event listeners. These errors are only logged in debug mode without a handler.
+func WithErrorHandler(handler func(err error)) EnvironmentOption {
+	return func(env *DomEnvironment) {
+		env.onError = handler
+	}
+}
+
+// ReportError sends the error to the handler given with WithErrorHandler. Use it for errors that have no
+// caller to be returned to, such as the errors of updates requested from a browser callback. The error is
+// only logged in debug mode without a handler.
+func (e *DomEnvironment) ReportError(err error) {
+	if e.onError == nil {
+		internal.Debugf("unhandled error: %s\n", err)
+		return
+	}
+
+	e.onError(err)
+}
+
+// RenderIntoDocument renders the provided root component node into the given DOM root of the provided
+// document. It works like RenderInto, but allows using any implementation of the DOM interfaces, such
+// as the in-memory document of the dom package.
+//
+// Updates are flushed with the ImmediateScheduler unless another scheduler is given with WithScheduler.
 //
 // This function is thread safe and will not allow any updates while the first mount is in progress. Event
 // listeners or effects triggered during the mount process will have to wait.
-func RenderInto(rootNode *nodes.FuncNode, root string) (*DomEnvironment, error) {
-	env := &DomEnvironment{
-		root: root,
-		tree: rootNode,
-	}
+func RenderIntoDocument(document dom.Document, rootNode *nodes.FuncNode, root string,
+	options ...EnvironmentOption) (*DomEnvironment, error) {
+	env := newEnvironment(document, rootNode, root, options)
 
+	env.startBatch()
+	defer env.endBatch()
 	env.Lock()
 	defer env.Unlock()
 
 	err := env.renderIntoRoot()
-	if err != nil {
+	var listenerErr *context.ListenerError
+	if errors.As(err, &listenerErr) {
+		// The app was rendered, only its lifecycle listeners failed
+		env.countRender()
+		return env, err
+	} else if err != nil {
+		return nil, err
+	}
+	env.countRender()
+
+	return env, nil
+}
+
+// HydrateIntoDocument hydrates the existing content of the given DOM root of the provided document with
+// the provided root component node. It works like HydrateInto, but allows using any implementation of the
+// DOM interfaces, such as the in-memory document of the dom package.
+//
+// Updates are flushed with the ImmediateScheduler unless another scheduler is given with WithScheduler.
+//
+// This function is thread safe and will not allow any updates while the hydration is in progress.
+func HydrateIntoDocument(document dom.Document, rootNode *nodes.FuncNode, root string,
+	options ...EnvironmentOption) (*DomEnvironment, error) {
+	env := newEnvironment(document, rootNode, root, options)
+
+	env.startBatch()
+	defer env.endBatch()
+	env.Lock()
+	defer env.Unlock()
+
+	err := env.hydrateRoot()
+	var hydrationErr *diffing.HydrationError
+	var listenerErr *context.ListenerError
+	if errors.As(err, &hydrationErr) || errors.As(err, &listenerErr) {
+		env.countRender()
+		return env, err
+	} else if err != nil {
 		return nil, err
 	}
+	env.countRender()
 
 	return env, nil
 }
 
-// Update triggers the diffing process and updates the virtual and real DOM tree. The app provided to
-// RenderInto will rerender fully and be diffed against the previously store tree. The diffing process
-// generates a set of patches, which are executed in sequence against both the real and virtual DOM trees
-// to update the stored tree with the new changes.
+// Update requests a render of the app. The render is not executed right away, it is queued in the render
+// loop and the environment's scheduler decides when to flush it. Any update requested while an event listener
+// is running, while the tree is rendering, or before the scheduler flushes is coalesced into the same render.
 //
-// This function is NOT thread safe and many allow other updates while another is in progress. Trigger an
-// Update in an event listener to use the thread safe features of Lander.
+// When flushed, the app provided to RenderInto will rerender fully and be diffed against the previously store
+// tree. The diffing process generates a set of patches, which are executed in sequence against both the real
+// and virtual DOM trees to update the stored tree with the new changes. Errors from a scheduled render are
+// sent to the handler given with WithErrorHandler, use UpdateAsync or Flush to get the error back.
+//
+// This function is thread safe and can be called from any goroutine, renders are always executed one at a time.
 func (e *DomEnvironment) Update() error {
-	err := e.patchDom()
-	if err != nil {
-		return err
+	e.updateLock.Lock()
+	e.renderAll = true
+	schedule := e.requestRender()
+	e.updateLock.Unlock()
+
+	if schedule {
+		return e.schedule()
+	}
+
+	return nil
+}
+
+// UpdateWith queues the mutation in the render loop and requests a render, like Update. Mutations are executed
+// in the order they were queued, right before the next render and while holding the environment's lock. Use
+// UpdateWith to change the state used by the components from a goroutine without racing with the renders.
+func (e *DomEnvironment) UpdateWith(mutation func()) error {
+	e.updateLock.Lock()
+	e.mutations = append(e.mutations, mutation)
+	e.renderAll = true
+	schedule := e.requestRender()
+	e.updateLock.Unlock()
+
+	if schedule {
+		return e.schedule()
+	}
+
+	return nil
+}
+
+// UpdateComponent queues the mutation in the render loop, like UpdateWith, but only requests a render of the
+// given component, which must be a *nodes.FuncNode of the tree. Only the component and its descendants are
+// rendered again and diffed, its ancestors and siblings are skipped. Any other value requests a render of the
+// whole tree. Hook setters use UpdateComponent to only render the component owning the hook.
+func (e *DomEnvironment) UpdateComponent(component interface{}, mutation func()) error {
+	e.updateLock.Lock()
+	if mutation != nil {
+		e.mutations = append(e.mutations, mutation)
+	}
+	if funcNode, ok := component.(*nodes.FuncNode); ok && funcNode != nil {
+		if e.components == nil {
+			e.components = map[*nodes.FuncNode]bool{}
+		}
+		e.components[funcNode] = true
+	} else {
+		e.renderAll = true
+	}
+	schedule := e.requestRender()
+	e.updateLock.Unlock()
+
+	if schedule {
+		return e.schedule()
 	}
 
 	return nil
 }
 
+// UpdateAsync requests a render, like Update, and returns a channel receiving the result of the render once
+// it was flushed. The channel receives a single value and is never closed.
+func (e *DomEnvironment) UpdateAsync() <-chan error {
+	result := make(chan error, 1)
+
+	e.updateLock.Lock()
+	e.waiting = append(e.waiting, result)
+	e.renderAll = true
+	schedule := e.requestRender()
+	e.updateLock.Unlock()
+
+	if schedule {
+		// The result is sent to the channel
+		_ = e.schedule()
+	}
+
+	return result
+}
+
+// Flush executes the queued mutations and the pending render right away, if any update was requested since
+// the last render. Flush waits for any render or event listener in progress, it must not be called from an
+// event listener or a component.
+func (e *DomEnvironment) Flush() error {
+	e.startBatch()
+	defer e.endBatch()
+	e.Lock()
+	defer e.Unlock()
+
+	e.updateLock.Lock()
+	pending := e.pending
+	mutations := e.mutations
+	waiting := e.waiting
+	renderAll := e.renderAll
+	components := e.components
+	e.pending = false
+	e.scheduled = false
+	e.mutations = nil
+	e.waiting = nil
+	e.renderAll = false
+	e.components = nil
+	e.updateLock.Unlock()
+
+	if !pending {
+		return nil
+	}
+
+	for _, mutation := range mutations {
+		mutation()
+	}
+
+	var err error
+	if renderAll {
+		err = e.patchDom()
+	} else {
+		err = e.patchComponents(components)
+	}
+	var listenerErr *context.ListenerError
+	if err == nil || errors.As(err, &listenerErr) {
+		e.countRender()
+	}
+
+	for _, result := range waiting {
+		result <- err
+	}
+
+	return err
+}
+
+// RenderCount returns the number of times the tree was rendered into the DOM since the environment was
+// created, including the first mount or hydration.
+func (e *DomEnvironment) RenderCount() int {
+	e.updateLock.Lock()
+	defer e.updateLock.Unlock()
+
+	return e.renderCount
+}
+
+// schedule asks the scheduler to flush the pending render. If the scheduler flushes right away, the error of
+// the render is returned. Errors of the renders flushed later are sent to the error handler of the environment,
+// as there is no caller to return them to.
+func (e *DomEnvironment) schedule() error {
+	var lock sync.Mutex
+	waiting := true
+	var result error
+
+	e.scheduler.Schedule(func() {
+		err := e.Flush()
+
+		lock.Lock()
+		defer lock.Unlock()
+		if waiting {
+			result = err
+			return
+		}
+
+		if err != nil {
+			e.ReportError(err)
+		}
+	})
+
+	lock.Lock()
+	defer lock.Unlock()
+	waiting = false
+
+	return result
+}
+
+// requestRender marks the environment as needing a render and returns if the flush should be scheduled. It
+// must be called while holding updateLock.
+func (e *DomEnvironment) requestRender() bool {
+	e.pending = true
+	schedule := e.batching == 0 && !e.scheduled
+	if schedule {
+		e.scheduled = true
+	}
+
+	return schedule
+}
+
+// startBatch holds any update requested until the matching endBatch call, so they are coalesced.
+func (e *DomEnvironment) startBatch() {
+	e.updateLock.Lock()
+	defer e.updateLock.Unlock()
+
+	e.batching++
+}
+
+// endBatch releases the updates held since startBatch, scheduling a render if any update was requested.
+func (e *DomEnvironment) endBatch() {
+	e.updateLock.Lock()
+	e.batching--
+	schedule := e.batching == 0 && e.pending && !e.scheduled
+	if schedule {
+		e.scheduled = true
+	}
+	e.updateLock.Unlock()
+
+	if schedule {
+		err := e.schedule()
+		if err != nil {
+			e.ReportError(err)
+		}
+	}
+}
+
+func (e *DomEnvironment) countRender() {
+	e.updateLock.Lock()
+	defer e.updateLock.Unlock()
+
+	e.renderCount++
+}
+
 func (e *DomEnvironment) renderIntoRoot() error {
-	rootElem := document.Call("querySelector", e.root)
-	if !rootElem.Truthy() {
+	rootElem := e.document.QuerySelector(e.root)
+	if rootElem == nil {
 		return fmt.Errorf("failed to find mount parent using query selector %q", e.root)
 	}
 
 	var styles []string
-	err := context.WithNewContext(e.Update, nil, func() error {
-		styles = diffing.RecursivelyMount(e.handleDOMEvent, document, rootElem, e.tree)
+	err := context.WithNewContext(e, nil, func() error {
+		styles = diffing.RecursivelyMount(e.eventDelegator(rootElem), e.document, rootElem, e.tree)
 		e.prevContext = context.CurrentContext
 		return nil
 	})
+	listenersErr, err := splitListenerError(err)
 	if err != nil {
 		return err
 	}
 
 	e.printTree(e.tree, 0)
 
-	m := minify.New()
-	m.AddFunc("text/css", css.Minify)
-	stylesString, err := m.String("text/css", strings.Join(styles, " "))
+	stylesString, err := minifyStyles(styles)
 	if err != nil {
-		return fmt.Errorf("could not minify CSS styles from HTML nodes. %w", err)
+		return err
 	}
 
-	head := document.Call("querySelector", "head")
-	if !head.Truthy() {
+	head := e.document.QuerySelector("head")
+	if head == nil {
 		return fmt.Errorf("failed to find head using query selector")
 	}
 
-	styleTag := document.Call("createElement", "style")
-	styleTag.Set("id", "lander-style-tag")
-	styleTag.Set("innerHTML", stylesString)
-	head.Call("appendChild", styleTag)
+	styleTag := e.document.CreateElement("style")
+	styleTag.SetProperty("id", styleTagID)
+	styleTag.SetInnerHTML(stylesString)
+	head.AppendChild(styleTag)
 
-	return nil
+	return listenersErr
+}
+
+func (e *DomEnvironment) hydrateRoot() error {
+	rootElem := e.document.QuerySelector(e.root)
+	if rootElem == nil {
+		return fmt.Errorf("failed to find mount parent using query selector %q", e.root)
+	}
+
+	head := e.document.QuerySelector("head")
+	if head == nil {
+		return fmt.Errorf("failed to find head using query selector")
+	}
+
+	// Reuse the style tag rendered by the server, moving it into the head so it is not hydrated
+	// as part of the app.
+	styleTag := e.document.QuerySelector("#" + styleTagID)
+	if styleTag == nil {
+		styleTag = e.document.CreateElement("style")
+		styleTag.SetProperty("id", styleTagID)
+	}
+	head.AppendChild(styleTag)
+
+	var start dom.Node
+	if childNodes := rootElem.ChildNodes(); len(childNodes) > 0 {
+		start = childNodes[0]
+	}
+
+	var styles []string
+	var mismatches []diffing.HydrationMismatch
+	err := context.WithNewContext(e, nil, func() error {
+		styles, mismatches = diffing.HydrateChildren(e.eventDelegator(rootElem), e.document, rootElem, start, nodes.Children{e.tree})
+		e.prevContext = context.CurrentContext
+		return nil
+	})
+	listenersErr, err := splitListenerError(err)
+	if err != nil {
+		return err
+	}
+
+	e.printTree(e.tree, 0)
+
+	stylesString, err := minifyStyles(styles)
+	if err != nil {
+		return err
+	}
+
+	styleTag.SetInnerHTML(stylesString)
+
+	if len(mismatches) > 0 {
+		hydrationErr := &diffing.HydrationError{Mismatches: mismatches}
+		if listenersErr != nil {
+			return errors.Join(hydrationErr, listenersErr)
+		}
+
+		return hydrationErr
+	}
+
+	return listenersErr
+}
+
+// patchComponents renders the given components again, without rendering the rest of the tree. Components
+// are positioned against the DOM nodes of their siblings, even when they render no DOM node.
+func (e *DomEnvironment) patchComponents(components map[*nodes.FuncNode]bool) error {
+	rootElem := e.document.QuerySelector(e.root)
+	if rootElem == nil {
+		return fmt.Errorf("failed to find mount parent using query selector %q", e.root)
+	}
+
+	roots := diffing.FindComponentRoots(e.tree, rootElem, components)
+
+	err := context.WithNewContext(e, e.prevContext, func() error {
+		var renderedStyles []string
+		for _, root := range roots {
+			// Render with the values provided by the component's ancestors, which are not rendered
+			context.RestoreScope(context.ComponentScope(root.Component))
+
+			patches, _, err := diffing.GeneratePatches(
+				e.eventDelegator(rootElem),
+				nil,
+				root.DOMParent,
+				-1,
+				root.Next,
+				root.Component,
+				root.Component.Clone(),
+			)
+			if err != nil {
+				return err
+			}
+
+			err = diffing.ExecutePatches(e.document, patches, &renderedStyles)
+			if err != nil {
+				return err
+			}
+		}
+
+		e.prevContext = context.CurrentContext
+		return nil
+	})
+	listenersErr, err := splitListenerError(err)
+	if err != nil {
+		return err
+	}
+
+	e.printTree(e.tree, 0)
+
+	// The styles of the components that were not rendered are still needed, collect them from the whole tree
+	err = e.updateStyles(diffing.CollectStyles(e.tree))
+	if err != nil {
+		return err
+	}
+
+	return listenersErr
 }
 
 func (e *DomEnvironment) patchDom() error {
-	rootElem := document.Call("querySelector", e.root)
-	if !rootElem.Truthy() {
+	rootElem := e.document.QuerySelector(e.root)
+	if rootElem == nil {
 		return fmt.Errorf("failed to find mount parent using query selector %q", e.root)
 	}
 
 	var styles []string
-	err := context.WithNewContext(e.Update, e.prevContext, func() error {
-		baseIndex := 0
+	err := context.WithNewContext(e, e.prevContext, func() error {
 		patches, renderedStyles, err := diffing.GeneratePatches(
-			e.handleDOMEvent,
+			e.eventDelegator(rootElem),
 			nil,
 			rootElem,
-			&baseIndex,
+			-1,
+			nil,
 			e.tree,
 			e.tree.Clone(),
 		)
@@ -135,59 +539,87 @@ func (e *DomEnvironment) patchDom() error {
 			return err
 		}
 
-		for _, patch := range patches {
-			err := patch.Execute(document, &renderedStyles)
-			if err != nil {
-				return err
-			}
+		err = diffing.ExecutePatches(e.document, patches, &renderedStyles)
+		if err != nil {
+			return err
 		}
 
 		styles = renderedStyles
 		e.prevContext = context.CurrentContext
 		return nil
 	})
+	listenersErr, err := splitListenerError(err)
 	if err != nil {
 		return err
 	}
 
 	e.printTree(e.tree, 0)
 
-	styleTag := document.Call("querySelector", "#lander-style-tag")
-	if !styleTag.Truthy() {
-		return fmt.Errorf("failed to find the style selector, failing %s", "#lander-style-tag")
+	err = e.updateStyles(styles)
+	if err != nil {
+		return err
 	}
 
-	m := minify.New()
-	m.AddFunc("text/css", css.Minify)
-	stylesString, err := m.String("text/css", strings.Join(styles, " "))
+	return listenersErr
+}
+
+// splitListenerError separates the errors of the lifecycle listeners from the errors of the render. When only
+// listeners failed, the render is complete and the listener errors must be returned once it is done.
+func splitListenerError(err error) (listenersErr error, renderErr error) {
+	var listenerErr *context.ListenerError
+	if errors.As(err, &listenerErr) {
+		return err, nil
+	}
+
+	return nil, err
+}
+
+// updateStyles replaces the content of the style tag with the given styles.
+func (e *DomEnvironment) updateStyles(styles []string) error {
+	styleTag := e.document.QuerySelector("#" + styleTagID)
+	if styleTag == nil {
+		return fmt.Errorf("failed to find the style selector, failing %s", "#"+styleTagID)
+	}
+
+	stylesString, err := minifyStyles(styles)
 	if err != nil {
 		return err
 	}
 
-	styleTag.Set("innerHTML", stylesString)
+	styleTag.SetInnerHTML(stylesString)
 
 	return nil
 }
 
-func (e *DomEnvironment) handleDOMEvent(listener events.EventListenerFunc, this js.Value, args []js.Value) interface{} {
-	if len(args) < 1 {
-		panic(fmt.Errorf("args should be at least 1 element, instead was: %#v", args))
+// eventDelegator returns the event delegator of the app, creating it for the given root element on the first
+// mount.
+func (e *DomEnvironment) eventDelegator(rootElem dom.Element) *events.Delegator {
+	if e.delegator == nil {
+		e.delegator = events.NewDelegator(rootElem, e.handleDOMEvent)
+
+		// Controlled inputs are not synced during a composition, render once it ends to sync them
+		e.delegator.TrackComposition(e.Update)
 	}
 
-	jsEvent := args[0]
+	return e.delegator
+}
 
-	event := events.NewDOMEvent(jsEvent, this)
+func (e *DomEnvironment) handleDOMEvent(eventType string, dispatch func() error) {
+	// Updates requested by the listeners are rendered once they are all done
+	e.startBatch()
+	defer e.endBatch()
 
-	// acquire exclusive lock before we actually process event
-	e.Lock()
-	defer e.Unlock()
-	err := listener(event)
+	// acquire exclusive lock before we actually process event, the error is reported once it is released
+	err := func() error {
+		e.Lock()
+		defer e.Unlock()
+
+		return dispatch()
+	}()
 	if err != nil {
-		// Return the error message
-		return err.Error()
+		// The DOM ignores the return value of listeners, report the error so it is not lost
+		e.ReportError(fmt.Errorf("error in %s event listener: %w", eventType, err))
 	}
-
-	return true
 }
 
 func (e *DomEnvironment) printTree(currentNode nodes.Node, layers int) {
@@ -205,6 +637,8 @@ func (e *DomEnvironment) printTree(currentNode nodes.Node, layers int) {
 		children = []nodes.Node{typedNode.RenderResult}
 	case *nodes.FragmentNode:
 		children = typedNode.Children
+	case *nodes.PortalNode:
+		children = typedNode.Children
 	case *nodes.HTMLNode:
 		children = typedNode.Children
 	default:
@@ -219,3 +653,15 @@ func (e *DomEnvironment) printTree(currentNode nodes.Node, layers int) {
 		e.printTree(child, layers+1)
 	}
 }
+
+// minifyStyles joins and minifies the given CSS styles so they can be added to the style tag.
+func minifyStyles(styles []string) (string, error) {
+	m := minify.New()
+	m.AddFunc("text/css", css.Minify)
+	stylesString, err := m.String("text/css", strings.Join(styles, " "))
+	if err != nil {
+		return "", fmt.Errorf("could not minify CSS styles from HTML nodes. %w", err)
+	}
+
+	return stylesString, nil
+}
@@ -0,0 +1,37 @@
+//go:build js && wasm
+
+package lander
+
+import (
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/nodes"
+)
+
+// RenderInto renders the provided root component node into the given DOM root. The root selector must
+// lead to a valid node, otherwise the mounting will error. The tree is only mounted in this method,
+// no diffing will happen. Returns the mounted DOM environment, which can be used to trigger updates.
+//
+// Updates are flushed on the next animation frame with the AnimationFrameScheduler, unless another scheduler
+// is given with WithScheduler.
+//
+// This function is thread safe and will not allow any updates while the first mount is in progress. Event
+// listeners or effects triggered during the mount process will have to wait.
+func RenderInto(rootNode *nodes.FuncNode, root string, options ...EnvironmentOption) (*DomEnvironment, error) {
+	return RenderIntoDocument(dom.JSDocument(), rootNode, root, withDefaultScheduler(options)...)
+}
+
+// HydrateInto hydrates the content of the given DOM root, usually rendered on the server with RenderToString,
+// using the provided root component node. Rather than creating the DOM nodes, hydration walks the existing DOM
+// alongside the virtual tree to bind the DOM nodes, attach event listeners and trigger the mount listeners.
+// Returns the DOM environment, which can be used to trigger updates.
+//
+// Any mismatch between the existing DOM and the virtual tree is fixed so the DOM matches the virtual tree. The
+// mismatches are returned as a *diffing.HydrationError alongside the valid DOM environment. Any other error
+// returns a nil environment. Updates are scheduled like RenderInto.
+func HydrateInto(rootNode *nodes.FuncNode, root string, options ...EnvironmentOption) (*DomEnvironment, error) {
+	return HydrateIntoDocument(dom.JSDocument(), rootNode, root, withDefaultScheduler(options)...)
+}
+
+func withDefaultScheduler(options []EnvironmentOption) []EnvironmentOption {
+	return append([]EnvironmentOption{WithScheduler(AnimationFrameScheduler)}, options...)
+}
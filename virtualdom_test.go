@@ -0,0 +1,206 @@
+package lander_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/minivera/go-lander"
+	"github.com/minivera/go-lander/context"
+	"github.com/minivera/go-lander/dom"
+	"github.com/minivera/go-lander/events"
+	"github.com/minivera/go-lander/nodes"
+)
+
+func setupDocument(t *testing.T) (*dom.MemoryDocument, *dom.MemoryElement) {
+	t.Helper()
+
+	document := dom.NewMemoryDocument()
+	app := document.CreateElement("div")
+	app.SetProperty("id", "app")
+	document.Body().AppendChild(app)
+
+	return document, app.(*dom.MemoryElement)
+}
+
+type counterApp struct {
+	env   *lander.DomEnvironment
+	count int
+}
+
+func (a *counterApp) render(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+	items := nodes.Children{}
+	for i := 0; i < a.count; i++ {
+		items = append(items, lander.Html("li", nodes.Attributes{}, nodes.Children{
+			lander.Text(fmt.Sprintf("item %d", i)),
+		}))
+	}
+
+	return lander.Html("div", nodes.Attributes{"class": "counter"}, nodes.Children{
+		lander.Html("button", nodes.Attributes{
+			"click": func(*events.DOMEvent) error {
+				a.count += 1
+				return a.env.Update()
+			},
+		}, nodes.Children{
+			lander.Text("+"),
+		}),
+		lander.Html("button", nodes.Attributes{
+			"click": func(*events.DOMEvent) error {
+				a.count -= 1
+				return a.env.Update()
+			},
+		}, nodes.Children{
+			lander.Text("-"),
+		}),
+		lander.Html("span", nodes.Attributes{}, nodes.Children{
+			lander.Text(fmt.Sprintf("Counter is at: %d", a.count)),
+		}),
+		lander.Html("ul", nodes.Attributes{}, items),
+	})
+}
+
+func TestRenderIntoDocument(t *testing.T) {
+	document, app := setupDocument(t)
+
+	counter := &counterApp{}
+	env, err := lander.RenderIntoDocument(
+		document, lander.Component(counter.render, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	counter.env = env
+
+	assert.Equal(
+		t,
+		`<div class="counter"><button>+</button><button>-</button><span>Counter is at: 0</span><ul></ul></div>`,
+		app.InnerHTML(),
+	)
+	assert.NotNil(t, document.QuerySelector("#lander-style-tag"))
+
+	_, err = lander.RenderIntoDocument(
+		document, lander.Component(counter.render, nodes.Props{}, nodes.Children{}), "#missing")
+	assert.Error(t, err)
+}
+
+func TestDomEnvironment_Update(t *testing.T) {
+	document, app := setupDocument(t)
+
+	counter := &counterApp{}
+	env, err := lander.RenderIntoDocument(
+		document, lander.Component(counter.render, nodes.Props{}, nodes.Children{}), "#app")
+	require.NoError(t, err)
+	counter.env = env
+
+	plus := app.QuerySelector("button").(*dom.MemoryElement)
+
+	plus.Dispatch("click")
+	plus.Dispatch("click")
+	assert.Equal(
+		t,
+		`<div class="counter"><button>+</button><button>-</button><span>Counter is at: 2</span>`+
+			`<ul><li>item 0</li><li>item 1</li></ul></div>`,
+		app.InnerHTML(),
+	)
+
+	minus := app.Children()[0].Children()[1].(*dom.MemoryElement)
+	minus.Dispatch("click")
+	assert.Equal(
+		t,
+		`<div class="counter"><button>+</button><button>-</button><span>Counter is at: 1</span>`+
+			`<ul><li>item 0</li></ul></div>`,
+		app.InnerHTML(),
+	)
+
+	// Listeners are delegated to a single native listener on the root, which is never stacked
+	assert.Equal(t, 0, plus.ListenerCount("click"))
+	assert.Equal(t, 0, minus.ListenerCount("click"))
+	assert.Equal(t, 1, app.ListenerCount("click"))
+}
+
+func TestDomEnvironment_AttributeTypes(t *testing.T) {
+	document, app := setupDocument(t)
+
+	disabled := true
+	env, err := lander.RenderIntoDocument(document, lander.Component(
+		func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			var title interface{}
+			if disabled {
+				title = "disabled"
+			}
+
+			return lander.Html("button", nodes.Attributes{
+				"class":    []string{"primary", "large"},
+				"tabindex": int64(2),
+				"data":     map[string]interface{}{"ratio": 0.25, "active": disabled},
+				"title":    title,
+				"disabled": disabled,
+			}, nodes.Children{})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+
+	button := app.QuerySelector("button")
+	assert.Equal(t, []string{"primary", "large"}, button.ClassList())
+	for name, expected := range map[string]string{"tabindex": "2", "data-ratio": "0.25", "data-active": "", "title": "disabled"} {
+		value, ok := button.GetAttribute(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, expected, value, name)
+	}
+
+	// Nil and false values remove the attributes set by the previous render
+	disabled = false
+	require.NoError(t, env.Update())
+	for _, name := range []string{"data-active", "title", "disabled"} {
+		_, ok := button.GetAttribute(name)
+		assert.False(t, ok, name)
+	}
+
+	// Attributes without a property are kept when patching
+	value, _ := button.GetAttribute("data-ratio")
+	assert.Equal(t, "0.25", value)
+}
+
+func TestDomEnvironment_InlineStyle(t *testing.T) {
+	document, app := setupDocument(t)
+
+	var style interface{} = nodes.StyleMap{"width": "10%", "color": "red"}
+	env, err := lander.RenderIntoDocument(document, lander.Component(
+		func(_ context.Context, _ nodes.Props, _ nodes.Children) nodes.Child {
+			return lander.Html("div", nodes.Attributes{"style": style}, nodes.Children{})
+		},
+		nodes.Props{},
+		nodes.Children{},
+	), "#app")
+	require.NoError(t, err)
+
+	bar := app.QuerySelector("div")
+	assert.Equal(t, "10%", bar.GetStyleProperty("width"))
+	assert.Equal(t, "red", bar.GetStyleProperty("color"))
+
+	// Only the changed properties are patched, properties set outside the app are kept
+	bar.SetStyleProperty("transition", "width 1s")
+	style = map[string]string{"width": "20%"}
+	require.NoError(t, env.Update())
+	assert.Equal(t, "20%", bar.GetStyleProperty("width"))
+	assert.Equal(t, "", bar.GetStyleProperty("color"))
+	assert.Equal(t, "width 1s", bar.GetStyleProperty("transition"))
+
+	// Style strings replace the whole attribute, maps can be used again afterward
+	style = "height: 5px"
+	require.NoError(t, env.Update())
+	value, _ := bar.GetAttribute("style")
+	assert.Equal(t, "height: 5px", value)
+
+	style = nodes.StyleMap{"width": "30%"}
+	require.NoError(t, env.Update())
+	assert.Equal(t, "30%", bar.GetStyleProperty("width"))
+	assert.Equal(t, "", bar.GetStyleProperty("height"))
+
+	style = nil
+	require.NoError(t, env.Update())
+	_, ok := bar.GetAttribute("style")
+	assert.False(t, ok)
+}